@@ -0,0 +1,268 @@
+// Package analysis computes exact Baccarat probabilities by enumerating every
+// possible sequence of cards dealt from a shoe of known composition.
+package analysis
+
+import (
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// Composition is the number of cards of each rank in a shoe, indexed by model.Rank (index 0 is unused).
+type Composition [model.King + 1]int
+
+// NewComposition returns the composition of a full shoe of decksCount standard decks.
+func NewComposition(decksCount int) Composition {
+	var c Composition
+	for r := model.Ace; r <= model.King; r++ {
+		c[r] = 4 * decksCount
+	}
+	return c
+}
+
+// Total returns the number of cards in the composition.
+func (c Composition) Total() int {
+	n := 0
+	for r := model.Ace; r <= model.King; r++ {
+		n += c[r]
+	}
+	return n
+}
+
+// evUnit is the stake each bet is settled on during enumeration. Payouts are
+// integral, so it must be large enough for commissions to come out exact.
+const evUnit = 1000
+
+// Result holds the exact outcome distribution of a single hand.
+// Every count is the number of ordered 6-card sequences that produce the outcome,
+// so Probability(o) = Counts[o] / Total is an exact ratio.
+type Result struct {
+	Composition Composition
+	Rules       rules.RuleSet
+	Counts      map[rules.Outcome]int64
+	Total       int64
+
+	// payoffs[bet][net] counts the sequences on which a bet of evUnit nets net.
+	// Suit-dependent bets are weighted fractionally, so the counts are floats.
+	payoffs map[rules.BetType]map[int]float64
+}
+
+// Probability returns the exact probability of an outcome.
+func (r *Result) Probability(o rules.Outcome) float64 {
+	return float64(r.Counts[o]) / float64(r.Total)
+}
+
+// EV returns the expected net return per unit wagered on a bet type.
+// It is 0 for bets the rule set does not offer.
+func (r *Result) EV(bet rules.BetType) float64 {
+	ev := 0.0
+	for net, n := range r.payoffs[bet] {
+		ev += float64(net) * n
+	}
+	return ev / float64(r.Total) / evUnit
+}
+
+// Variance returns the variance of the net return per unit wagered on a bet type.
+func (r *Result) Variance(bet rules.BetType) float64 {
+	ev := r.EV(bet)
+	v := 0.0
+	for net, n := range r.payoffs[bet] {
+		d := float64(net)/evUnit - ev
+		v += d * d * n
+	}
+	return v / float64(r.Total)
+}
+
+// Analyze enumerates every way the next hand can be dealt from a shoe with the
+// given composition, weighting each rank by the number of cards of that rank
+// still in the shoe (sampling without replacement), and settles every bet
+// offered by rs on each deal. It needs at least six cards.
+//
+// Pair bets are settled separately on the four initial cards, with suits. Since
+// a Composition only records ranks, each rank's cards are assumed to be spread
+// evenly over the four suits, which is exact for a full shoe.
+func Analyze(comp Composition, rs rules.RuleSet) *Result {
+	res := &Result{
+		Composition: comp,
+		Rules:       rs,
+		Counts:      make(map[rules.Outcome]int64),
+		payoffs:     make(map[rules.BetType]map[int]float64),
+	}
+	e := enumerator{comp: comp, remaining: comp.Total(), res: res}
+	for _, bet := range rs.BetTypes() {
+		res.payoffs[bet] = make(map[int]float64)
+		if bet.IsPair() {
+			e.pairBets = append(e.pairBets, bet)
+		} else {
+			e.bets = append(e.bets, bet)
+		}
+	}
+	e.player.Cards = e.pCards[:0]
+	e.banker.Cards = e.bCards[:0]
+	e.dealInitial(0, 1)
+	for _, n := range res.Counts {
+		res.Total += n
+	}
+	if len(e.pairBets) > 0 {
+		for r := model.Ace; r <= model.King; r++ {
+			for s := range e.suits[r] {
+				e.suits[r][s] = float64(comp[r]) / 4
+			}
+		}
+		e.dealPairs(0, 0, 1)
+	}
+	return res
+}
+
+type enumerator struct {
+	comp      Composition
+	remaining int
+	res       *Result
+
+	// bets are settled on every deal; pairBets only on the initial four cards.
+	bets, pairBets []rules.BetType
+
+	player, banker model.Hand
+	pCards, bCards [3]model.Card
+
+	// suits[r][s] is the number of cards of rank r and suit s left, for dealPairs.
+	suits [model.King + 1][4]float64
+}
+
+// take removes a card of rank r and returns the number of ways to draw it.
+func (e *enumerator) take(r model.Rank) int64 {
+	ways := int64(e.comp[r])
+	e.comp[r]--
+	e.remaining--
+	return ways
+}
+
+func (e *enumerator) put(r model.Rank) {
+	e.comp[r]++
+	e.remaining++
+}
+
+// fill returns the number of ways to draw n further (irrelevant) cards, which puts
+// hands that finish early on the same 6-card denominator as three-card hands.
+func (e *enumerator) fill(n int) int64 {
+	ways := int64(1)
+	for i := 0; i < n; i++ {
+		ways *= int64(e.remaining - i)
+	}
+	return ways
+}
+
+// dealInitial deals the four initial cards in order: Player, Banker, Player, Banker.
+func (e *enumerator) dealInitial(i int, ways int64) {
+	if i == 4 {
+		e.drawThird(ways)
+		return
+	}
+	for r := model.Ace; r <= model.King; r++ {
+		if e.comp[r] == 0 {
+			continue
+		}
+		w := e.take(r)
+		c := model.Card{Rank: r}
+		if i%2 == 0 {
+			e.player.Cards = append(e.player.Cards, c)
+		} else {
+			e.banker.Cards = append(e.banker.Cards, c)
+		}
+		e.dealInitial(i+1, ways*w)
+		if i%2 == 0 {
+			e.player.Cards = e.player.Cards[:len(e.player.Cards)-1]
+		} else {
+			e.banker.Cards = e.banker.Cards[:len(e.banker.Cards)-1]
+		}
+		e.put(r)
+	}
+}
+
+func (e *enumerator) drawThird(ways int64) {
+	if !rules.DeterminePlayerHit(&e.player, &e.banker) {
+		e.drawBanker(ways, false, nil)
+		return
+	}
+	for r := model.Ace; r <= model.King; r++ {
+		if e.comp[r] == 0 {
+			continue
+		}
+		w := e.take(r)
+		c := model.Card{Rank: r}
+		e.player.Cards = append(e.player.Cards, c)
+		e.drawBanker(ways*w, true, &c)
+		e.player.Cards = e.player.Cards[:2]
+		e.put(r)
+	}
+}
+
+func (e *enumerator) drawBanker(ways int64, playerHit bool, pThird *model.Card) {
+	drawn := 4
+	if playerHit {
+		drawn = 5
+	}
+	if !rules.DetermineBankerHit(&e.banker, &e.player, playerHit, pThird) {
+		e.record(ways * e.fill(6-drawn))
+		return
+	}
+	for r := model.Ace; r <= model.King; r++ {
+		if e.comp[r] == 0 {
+			continue
+		}
+		w := e.take(r)
+		e.banker.Cards = append(e.banker.Cards, model.Card{Rank: r})
+		e.record(ways * w * e.fill(5-drawn))
+		e.banker.Cards = e.banker.Cards[:2]
+		e.put(r)
+	}
+}
+
+func (e *enumerator) record(ways int64) {
+	e.res.Counts[rules.DetermineOutcome(&e.player, &e.banker)] += ways
+	for _, bet := range e.bets {
+		e.res.payoffs[bet][e.res.Rules.Payout(&e.player, &e.banker, bet, evUnit).NetChange(evUnit)] += float64(ways)
+	}
+}
+
+// dealPairs deals the four initial cards by rank and suit and settles the pair
+// bets on them. Suits are only told apart by which earlier cards they match: the
+// i-th card either repeats one of the usedSuits suits seen so far or takes any of
+// the 4-usedSuits others, which are interchangeable.
+func (e *enumerator) dealPairs(i, usedSuits int, ways float64) {
+	if i == 4 {
+		ways *= float64(e.fill(2))
+		for _, bet := range e.pairBets {
+			e.res.payoffs[bet][e.res.Rules.Payout(&e.player, &e.banker, bet, evUnit).NetChange(evUnit)] += ways
+		}
+		return
+	}
+	for r := model.Ace; r <= model.King; r++ {
+		for s := 0; s <= usedSuits && s < 4; s++ {
+			w := e.suits[r][s]
+			if w <= 0 {
+				continue
+			}
+			next := usedSuits
+			if s == usedSuits {
+				w *= float64(4 - usedSuits)
+				next++
+			}
+			e.suits[r][s]--
+			e.remaining--
+			c := model.Card{Suit: model.Suit(s), Rank: r}
+			if i%2 == 0 {
+				e.player.Cards = append(e.player.Cards, c)
+			} else {
+				e.banker.Cards = append(e.banker.Cards, c)
+			}
+			e.dealPairs(i+1, next, ways*w)
+			if i%2 == 0 {
+				e.player.Cards = e.player.Cards[:len(e.player.Cards)-1]
+			} else {
+				e.banker.Cards = e.banker.Cards[:len(e.banker.Cards)-1]
+			}
+			e.suits[r][s]++
+			e.remaining++
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+func TestAnalyzeEightDecks(t *testing.T) {
+	res := Analyze(NewComposition(8), rules.EZ)
+
+	// Published EZ Baccarat figures for an 8-deck shoe.
+	probs := []struct {
+		outcome rules.Outcome
+		want    float64
+	}{
+		{rules.OutcomePanda8, 0.034543},
+		{rules.OutcomeBanker, 0.436064},
+		{rules.OutcomeTie, 0.095156},
+		{rules.OutcomeDragon7, 0.022534},
+	}
+	for _, tt := range probs {
+		if got := res.Probability(tt.outcome); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("P(%s) = %.7f, want %.6f", tt.outcome, got, tt.want)
+		}
+	}
+
+	evs := []struct {
+		bet  rules.BetType
+		want float64
+	}{
+		{rules.Banker, -0.010183},
+		{rules.Player, -0.012351},
+		{rules.Tie, -0.143596},
+		{rules.Dragon, -0.076106},
+		{rules.Panda, -0.101882},
+	}
+	// The published EVs were derived from rounded probabilities.
+	for _, tt := range evs {
+		if got := res.EV(tt.bet); math.Abs(got-tt.want) > 2e-5 {
+			t.Errorf("EV(%s) = %.7f, want %.6f", tt.bet, got, tt.want)
+		}
+	}
+
+	sum := 0.0
+	for o := range res.Counts {
+		sum += res.Probability(o)
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Errorf("probabilities sum to %v", sum)
+	}
+}
+
+func TestAnalyzeVariants(t *testing.T) {
+	// Published 8-deck house edges.
+	tests := []struct {
+		rs   rules.RuleSet
+		bet  rules.BetType
+		want float64
+	}{
+		{rules.Classic, rules.Banker, -0.010579},
+		{rules.Classic, rules.Player, -0.012351},
+		{rules.Classic, rules.Tie, -0.143596},
+		{rules.Super6, rules.Banker, -0.014581},
+		{rules.NoCommission, rules.Tie, -0.048440},
+	}
+	for _, tt := range tests {
+		res := Analyze(NewComposition(8), tt.rs)
+		if got := res.EV(tt.bet); math.Abs(got-tt.want) > 2e-5 {
+			t.Errorf("%s EV(%s) = %.6f, want %.6f", tt.rs.Name(), tt.bet, got, tt.want)
+		}
+	}
+
+	if ev := Analyze(NewComposition(8), rules.Classic).EV(rules.Dragon); ev != 0 {
+		t.Errorf("Classic does not offer Dragon 7, EV = %v", ev)
+	}
+}
+
+func TestAnalyzePairs(t *testing.T) {
+	res := Analyze(NewComposition(8), rules.EZ)
+	tests := []struct {
+		bet  rules.BetType
+		want float64
+	}{
+		{rules.PlayerPair, -0.103614}, // 12 * 31/415 - 1
+		{rules.BankerPair, -0.103614},
+		{rules.EitherPair, -0.137099},
+		{rules.PerfectPair, -0.080470},
+	}
+	for _, tt := range tests {
+		if got := res.EV(tt.bet); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("EV(%s) = %.7f, want %.6f", tt.bet, got, tt.want)
+		}
+	}
+
+	// A suited pair needs two copies of the same card.
+	if ev := Analyze(NewComposition(1), rules.EZ).EV(rules.PerfectPair); ev != -1 {
+		t.Errorf("single deck Perfect Pair EV = %v, want -1", ev)
+	}
+}
+
+func TestAnalyzeDragonBonus(t *testing.T) {
+	// Published 8-deck house edges, quoted to a hundredth of a percent.
+	res := Analyze(NewComposition(8), rules.EZ)
+	for bet, want := range map[rules.BetType]float64{
+		rules.DragonBonusPlayer: -0.0265,
+		rules.DragonBonusBanker: -0.0937,
+	} {
+		if got := res.EV(bet); math.Abs(got-want) > 1e-4 {
+			t.Errorf("EV(%s) = %.6f, want %.4f", bet, got, want)
+		}
+	}
+	if a, b := res.EV(rules.DragonBonusPlayer), Analyze(NewComposition(8), rules.Classic).EV(rules.DragonBonusPlayer); a != b {
+		t.Errorf("Dragon Bonus should not depend on the variant: %v vs %v", a, b)
+	}
+}
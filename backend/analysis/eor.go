@@ -0,0 +1,40 @@
+package analysis
+
+import (
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// RemovalEffects holds the effect of removal of every rank: how the expected
+// return of each bet changes when a single card of that rank is taken out of
+// the shoe.
+type RemovalEffects struct {
+	Base *Result
+	// Effects[r][bet] is EV(bet) with one card of rank r removed minus the base
+	// EV, per unit wagered. Ranks with no cards left in the shoe have no entry.
+	Effects [model.King + 1]map[rules.BetType]float64
+}
+
+// EffectOfRemoval analyzes comp and, for each rank, comp with one card of that
+// rank removed. Tens and face cards have the same value, so Jacks, Queens and
+// Kings share the Ten's analysis when their counts match.
+func EffectOfRemoval(comp Composition, rs rules.RuleSet) *RemovalEffects {
+	eor := &RemovalEffects{Base: Analyze(comp, rs)}
+	for r := model.Ace; r <= model.King; r++ {
+		if comp[r] == 0 {
+			continue
+		}
+		if r > model.Ten && comp[r] == comp[model.Ten] {
+			eor.Effects[r] = eor.Effects[model.Ten]
+			continue
+		}
+		removed := comp
+		removed[r]--
+		res := Analyze(removed, rs)
+		eor.Effects[r] = make(map[rules.BetType]float64)
+		for _, bet := range rs.BetTypes() {
+			eor.Effects[r][bet] = res.EV(bet) - eor.Base.EV(bet)
+		}
+	}
+	return eor
+}
@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// sideBets restricts a rule set to a few bets, to keep enumeration fast.
+type sideBets struct {
+	rules.RuleSet
+	bets []rules.BetType
+}
+
+func (s sideBets) BetTypes() []rules.BetType { return s.bets }
+
+func TestEffectOfRemoval(t *testing.T) {
+	comp := NewComposition(8)
+	eor := EffectOfRemoval(comp, sideBets{rules.EZ, []rules.BetType{rules.Dragon, rules.Panda}})
+
+	if got := eor.Base.EV(rules.Dragon); math.Abs(got+0.076106) > 1e-4 {
+		t.Errorf("base Dragon 7 EV = %.6f, want -0.076106", got)
+	}
+
+	// Removing small cards makes a three-card 7 less likely; removing 8s and 9s
+	// means fewer naturals, so more hands go to a third card.
+	dragon := func(r model.Rank) float64 { return eor.Effects[r][rules.Dragon] }
+	for r := model.Four; r <= model.Seven; r++ {
+		if dragon(r) >= 0 {
+			t.Errorf("Dragon 7 EoR of rank %d = %+.6f, want negative", r, dragon(r))
+		}
+	}
+	for _, r := range []model.Rank{model.Eight, model.Nine} {
+		if dragon(r) <= 0 {
+			t.Errorf("Dragon 7 EoR of rank %d = %+.6f, want positive", r, dragon(r))
+		}
+	}
+	if dragon(model.King) != dragon(model.Ten) {
+		t.Errorf("face cards should share the Ten's effect of removal")
+	}
+
+	// Removing every card in proportion leaves the EV unchanged, so the effects
+	// weighted by the composition sum to about zero.
+	for _, bet := range []rules.BetType{rules.Dragon, rules.Panda} {
+		sum := 0.0
+		for r := model.Ace; r <= model.King; r++ {
+			sum += float64(comp[r]) * eor.Effects[r][bet]
+		}
+		if math.Abs(sum) > 2e-3 {
+			t.Errorf("%s: weighted sum of effects = %.6f, want about 0", bet, sum)
+		}
+	}
+}
+
+func TestEffectOfRemovalSkipsMissingRanks(t *testing.T) {
+	comp := NewComposition(1)
+	comp[model.Nine] = 0
+	eor := EffectOfRemoval(comp, sideBets{rules.EZ, []rules.BetType{rules.Dragon}})
+	if eor.Effects[model.Nine] != nil {
+		t.Errorf("a rank with no cards left should have no effect of removal")
+	}
+	if eor.Effects[model.Eight] == nil {
+		t.Errorf("missing effect of removal for 8s")
+	}
+}
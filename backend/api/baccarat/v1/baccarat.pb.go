@@ -0,0 +1,2792 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: baccarat.proto
+
+package baccaratv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LoginRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Provider   string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`     // e.g., "google"
+	Credential string                 `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"` // The provider's token for the user
+	// Player name to register on the first login; defaults to the name the
+	// provider suggests. Ignored once the identity has a player.
+	PlayerName    string `protobuf:"bytes,3,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_baccarat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LoginRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken     string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresAtUnixMs int64                  `protobuf:"varint,2,opt,name=expires_at_unix_ms,json=expiresAtUnixMs,proto3" json:"expires_at_unix_ms,omitempty"`
+	PlayerName      string                 `protobuf:"bytes,3,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	Balance         int64                  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	NewPlayer       bool                   `protobuf:"varint,5,opt,name=new_player,json=newPlayer,proto3" json:"new_player,omitempty"` // The login created the player's profile
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_baccarat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LoginResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresAtUnixMs() int64 {
+	if x != nil {
+		return x.ExpiresAtUnixMs
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetNewPlayer() bool {
+	if x != nil {
+		return x.NewPlayer
+	}
+	return false
+}
+
+type ListTablesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTablesRequest) Reset() {
+	*x = ListTablesRequest{}
+	mi := &file_baccarat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTablesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTablesRequest) ProtoMessage() {}
+
+func (x *ListTablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTablesRequest.ProtoReflect.Descriptor instead.
+func (*ListTablesRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{2}
+}
+
+type ListTablesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tables        []*TableSummary        `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTablesResponse) Reset() {
+	*x = ListTablesResponse{}
+	mi := &file_baccarat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTablesResponse) ProtoMessage() {}
+
+func (x *ListTablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTablesResponse.ProtoReflect.Descriptor instead.
+func (*ListTablesResponse) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTablesResponse) GetTables() []*TableSummary {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type TableSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	PlayersSeated int32                  `protobuf:"varint,2,opt,name=players_seated,json=playersSeated,proto3" json:"players_seated,omitempty"`
+	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"` // Usually 7
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                            // "BETTING_OPEN", "DEALING", "RESOLVED" or "FULL"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableSummary) Reset() {
+	*x = TableSummary{}
+	mi := &file_baccarat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableSummary) ProtoMessage() {}
+
+func (x *TableSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableSummary.ProtoReflect.Descriptor instead.
+func (*TableSummary) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TableSummary) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *TableSummary) GetPlayersSeated() int32 {
+	if x != nil {
+		return x.PlayersSeated
+	}
+	return 0
+}
+
+func (x *TableSummary) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *TableSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CreateTableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxPlayers    int32                  `protobuf:"varint,1,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTableRequest) Reset() {
+	*x = CreateTableRequest{}
+	mi := &file_baccarat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTableRequest) ProtoMessage() {}
+
+func (x *CreateTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTableRequest.ProtoReflect.Descriptor instead.
+func (*CreateTableRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateTableRequest) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+type CreateTableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTableResponse) Reset() {
+	*x = CreateTableResponse{}
+	mi := &file_baccarat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTableResponse) ProtoMessage() {}
+
+func (x *CreateTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTableResponse.ProtoReflect.Descriptor instead.
+func (*CreateTableResponse) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateTableResponse) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+type JoinTableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinTableRequest) Reset() {
+	*x = JoinTableRequest{}
+	mi := &file_baccarat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinTableRequest) ProtoMessage() {}
+
+func (x *JoinTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinTableRequest.ProtoReflect.Descriptor instead.
+func (*JoinTableRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JoinTableRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+type JoinTableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	SeatNumber    int32                  `protobuf:"varint,2,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"` // 1 to 7
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinTableResponse) Reset() {
+	*x = JoinTableResponse{}
+	mi := &file_baccarat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinTableResponse) ProtoMessage() {}
+
+func (x *JoinTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinTableResponse.ProtoReflect.Descriptor instead.
+func (*JoinTableResponse) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JoinTableResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *JoinTableResponse) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+func (x *JoinTableResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type LeaveTableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveTableRequest) Reset() {
+	*x = LeaveTableRequest{}
+	mi := &file_baccarat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveTableRequest) ProtoMessage() {}
+
+func (x *LeaveTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveTableRequest.ProtoReflect.Descriptor instead.
+func (*LeaveTableRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LeaveTableRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+type LeaveTableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveTableResponse) Reset() {
+	*x = LeaveTableResponse{}
+	mi := &file_baccarat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveTableResponse) ProtoMessage() {}
+
+func (x *LeaveTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveTableResponse.ProtoReflect.Descriptor instead.
+func (*LeaveTableResponse) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LeaveTableResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetTableStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTableStateRequest) Reset() {
+	*x = GetTableStateRequest{}
+	mi := &file_baccarat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTableStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTableStateRequest) ProtoMessage() {}
+
+func (x *GetTableStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTableStateRequest.ProtoReflect.Descriptor instead.
+func (*GetTableStateRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTableStateRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+type GetTableStateResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TableId            string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	Status             string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                                      // "BETTING_OPEN", "DEALING", "RESOLVED"
+	ShoeCardsRemaining int32                  `protobuf:"varint,3,opt,name=shoe_cards_remaining,json=shoeCardsRemaining,proto3" json:"shoe_cards_remaining,omitempty"` // e.g., 416
+	Players            []*SeatedPlayer        `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	// Scoreboard of the current shoe, cleared when a new shoe is brought out.
+	Roadmap *Roadmap `protobuf:"bytes,5,opt,name=roadmap,proto3" json:"roadmap,omitempty"`
+	ShoeId  string   `protobuf:"bytes,6,opt,name=shoe_id,json=shoeId,proto3" json:"shoe_id,omitempty"`
+	// What the server committed to before the current shoe's first hand, on a
+	// provably-fair server.
+	ShoeCommitment *ShoeCommitment `protobuf:"bytes,7,opt,name=shoe_commitment,json=shoeCommitment,proto3" json:"shoe_commitment,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetTableStateResponse) Reset() {
+	*x = GetTableStateResponse{}
+	mi := &file_baccarat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTableStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTableStateResponse) ProtoMessage() {}
+
+func (x *GetTableStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTableStateResponse.ProtoReflect.Descriptor instead.
+func (*GetTableStateResponse) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTableStateResponse) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *GetTableStateResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetTableStateResponse) GetShoeCardsRemaining() int32 {
+	if x != nil {
+		return x.ShoeCardsRemaining
+	}
+	return 0
+}
+
+func (x *GetTableStateResponse) GetPlayers() []*SeatedPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *GetTableStateResponse) GetRoadmap() *Roadmap {
+	if x != nil {
+		return x.Roadmap
+	}
+	return nil
+}
+
+func (x *GetTableStateResponse) GetShoeId() string {
+	if x != nil {
+		return x.ShoeId
+	}
+	return ""
+}
+
+func (x *GetTableStateResponse) GetShoeCommitment() *ShoeCommitment {
+	if x != nil {
+		return x.ShoeCommitment
+	}
+	return nil
+}
+
+// A provably-fair shoe's commitment. The cards follow from the server seed
+// hashed to server_seed_hash and the client seed; see ShoeRevealEvent.
+type ShoeCommitment struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ShoeId           string                 `protobuf:"bytes,1,opt,name=shoe_id,json=shoeId,proto3" json:"shoe_id,omitempty"`
+	ServerSeedHash   string                 `protobuf:"bytes,2,opt,name=server_seed_hash,json=serverSeedHash,proto3" json:"server_seed_hash,omitempty"` // Hex SHA-256 of the server seed
+	ClientSeed       string                 `protobuf:"bytes,3,opt,name=client_seed,json=clientSeed,proto3" json:"client_seed,omitempty"`
+	OrderHash        string                 `protobuf:"bytes,4,opt,name=order_hash,json=orderHash,proto3" json:"order_hash,omitempty"` // Hex SHA-256 of the card order before the burn
+	DecksCount       int32                  `protobuf:"varint,5,opt,name=decks_count,json=decksCount,proto3" json:"decks_count,omitempty"`
+	CutCardThreshold int32                  `protobuf:"varint,6,opt,name=cut_card_threshold,json=cutCardThreshold,proto3" json:"cut_card_threshold,omitempty"` // Cards left in the shoe when the cut card comes out
+	BurnRule         string                 `protobuf:"bytes,7,opt,name=burn_rule,json=burnRule,proto3" json:"burn_rule,omitempty"`                            // "face", "none" or the number of cards burned
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ShoeCommitment) Reset() {
+	*x = ShoeCommitment{}
+	mi := &file_baccarat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoeCommitment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoeCommitment) ProtoMessage() {}
+
+func (x *ShoeCommitment) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoeCommitment.ProtoReflect.Descriptor instead.
+func (*ShoeCommitment) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ShoeCommitment) GetShoeId() string {
+	if x != nil {
+		return x.ShoeId
+	}
+	return ""
+}
+
+func (x *ShoeCommitment) GetServerSeedHash() string {
+	if x != nil {
+		return x.ServerSeedHash
+	}
+	return ""
+}
+
+func (x *ShoeCommitment) GetClientSeed() string {
+	if x != nil {
+		return x.ClientSeed
+	}
+	return ""
+}
+
+func (x *ShoeCommitment) GetOrderHash() string {
+	if x != nil {
+		return x.OrderHash
+	}
+	return ""
+}
+
+func (x *ShoeCommitment) GetDecksCount() int32 {
+	if x != nil {
+		return x.DecksCount
+	}
+	return 0
+}
+
+func (x *ShoeCommitment) GetCutCardThreshold() int32 {
+	if x != nil {
+		return x.CutCardThreshold
+	}
+	return 0
+}
+
+func (x *ShoeCommitment) GetBurnRule() string {
+	if x != nil {
+		return x.BurnRule
+	}
+	return ""
+}
+
+type SetClientSeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	ClientSeed    string                 `protobuf:"bytes,2,opt,name=client_seed,json=clientSeed,proto3" json:"client_seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetClientSeedRequest) Reset() {
+	*x = SetClientSeedRequest{}
+	mi := &file_baccarat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetClientSeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetClientSeedRequest) ProtoMessage() {}
+
+func (x *SetClientSeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetClientSeedRequest.ProtoReflect.Descriptor instead.
+func (*SetClientSeedRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetClientSeedRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *SetClientSeedRequest) GetClientSeed() string {
+	if x != nil {
+		return x.ClientSeed
+	}
+	return ""
+}
+
+type SetClientSeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetClientSeedResponse) Reset() {
+	*x = SetClientSeedResponse{}
+	mi := &file_baccarat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetClientSeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetClientSeedResponse) ProtoMessage() {}
+
+func (x *SetClientSeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetClientSeedResponse.ProtoReflect.Descriptor instead.
+func (*SetClientSeedResponse) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{15}
+}
+
+type SeatedPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatNumber    int32                  `protobuf:"varint,1,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"`
+	PlayerName    string                 `protobuf:"bytes,2,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	Balance       int64                  `protobuf:"varint,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatedPlayer) Reset() {
+	*x = SeatedPlayer{}
+	mi := &file_baccarat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatedPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatedPlayer) ProtoMessage() {}
+
+func (x *SeatedPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatedPlayer.ProtoReflect.Descriptor instead.
+func (*SeatedPlayer) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SeatedPlayer) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+func (x *SeatedPlayer) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *SeatedPlayer) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type Roadmap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every outcome of the current shoe in order (e.g., "Banker", "Tie", "Dragon 7")
+	Outcomes     []string `protobuf:"bytes,1,rep,name=outcomes,proto3" json:"outcomes,omitempty"`
+	BeadPlate    *Road    `protobuf:"bytes,2,opt,name=bead_plate,json=beadPlate,proto3" json:"bead_plate,omitempty"`
+	BigRoad      *Road    `protobuf:"bytes,3,opt,name=big_road,json=bigRoad,proto3" json:"big_road,omitempty"`
+	BigEyeBoy    *Road    `protobuf:"bytes,4,opt,name=big_eye_boy,json=bigEyeBoy,proto3" json:"big_eye_boy,omitempty"`
+	SmallRoad    *Road    `protobuf:"bytes,5,opt,name=small_road,json=smallRoad,proto3" json:"small_road,omitempty"`
+	CockroachPig *Road    `protobuf:"bytes,6,opt,name=cockroach_pig,json=cockroachPig,proto3" json:"cockroach_pig,omitempty"`
+	// What each derived road would draw if the next hand went Banker or Player
+	AskRoads      []*AskRoad `protobuf:"bytes,7,rep,name=ask_roads,json=askRoads,proto3" json:"ask_roads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Roadmap) Reset() {
+	*x = Roadmap{}
+	mi := &file_baccarat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Roadmap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Roadmap) ProtoMessage() {}
+
+func (x *Roadmap) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Roadmap.ProtoReflect.Descriptor instead.
+func (*Roadmap) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Roadmap) GetOutcomes() []string {
+	if x != nil {
+		return x.Outcomes
+	}
+	return nil
+}
+
+func (x *Roadmap) GetBeadPlate() *Road {
+	if x != nil {
+		return x.BeadPlate
+	}
+	return nil
+}
+
+func (x *Roadmap) GetBigRoad() *Road {
+	if x != nil {
+		return x.BigRoad
+	}
+	return nil
+}
+
+func (x *Roadmap) GetBigEyeBoy() *Road {
+	if x != nil {
+		return x.BigEyeBoy
+	}
+	return nil
+}
+
+func (x *Roadmap) GetSmallRoad() *Road {
+	if x != nil {
+		return x.SmallRoad
+	}
+	return nil
+}
+
+func (x *Roadmap) GetCockroachPig() *Road {
+	if x != nil {
+		return x.CockroachPig
+	}
+	return nil
+}
+
+func (x *Roadmap) GetAskRoads() []*AskRoad {
+	if x != nil {
+		return x.AskRoads
+	}
+	return nil
+}
+
+// A road is a grid 6 rows high; only occupied cells are listed.
+type Road struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Columns       int32                  `protobuf:"varint,1,opt,name=columns,proto3" json:"columns,omitempty"`
+	Cells         []*RoadCell            `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Road) Reset() {
+	*x = Road{}
+	mi := &file_baccarat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Road) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Road) ProtoMessage() {}
+
+func (x *Road) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Road.ProtoReflect.Descriptor instead.
+func (*Road) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Road) GetColumns() int32 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
+}
+
+func (x *Road) GetCells() []*RoadCell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type RoadCell struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        int32                  `protobuf:"varint,1,opt,name=column,proto3" json:"column,omitempty"` // 0-based, left to right
+	Row           int32                  `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`       // 0-based, top to bottom
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`    // The outcome (Bead Plate, Big Road) or "red" / "blue" (derived roads)
+	Ties          int32                  `protobuf:"varint,4,opt,name=ties,proto3" json:"ties,omitempty"`     // Big Road only: ties marked on this cell
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoadCell) Reset() {
+	*x = RoadCell{}
+	mi := &file_baccarat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoadCell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoadCell) ProtoMessage() {}
+
+func (x *RoadCell) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoadCell.ProtoReflect.Descriptor instead.
+func (*RoadCell) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RoadCell) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *RoadCell) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *RoadCell) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RoadCell) GetTies() int32 {
+	if x != nil {
+		return x.Ties
+	}
+	return 0
+}
+
+type AskRoad struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Next          string                 `protobuf:"bytes,1,opt,name=next,proto3" json:"next,omitempty"`                              // "Banker" or "Player"
+	BigEyeBoy     string                 `protobuf:"bytes,2,opt,name=big_eye_boy,json=bigEyeBoy,proto3" json:"big_eye_boy,omitempty"` // "red", "blue", or empty if the road would not draw yet
+	SmallRoad     string                 `protobuf:"bytes,3,opt,name=small_road,json=smallRoad,proto3" json:"small_road,omitempty"`
+	CockroachPig  string                 `protobuf:"bytes,4,opt,name=cockroach_pig,json=cockroachPig,proto3" json:"cockroach_pig,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AskRoad) Reset() {
+	*x = AskRoad{}
+	mi := &file_baccarat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AskRoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AskRoad) ProtoMessage() {}
+
+func (x *AskRoad) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AskRoad.ProtoReflect.Descriptor instead.
+func (*AskRoad) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AskRoad) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+func (x *AskRoad) GetBigEyeBoy() string {
+	if x != nil {
+		return x.BigEyeBoy
+	}
+	return ""
+}
+
+func (x *AskRoad) GetSmallRoad() string {
+	if x != nil {
+		return x.SmallRoad
+	}
+	return ""
+}
+
+func (x *AskRoad) GetCockroachPig() string {
+	if x != nil {
+		return x.CockroachPig
+	}
+	return ""
+}
+
+type PlaceBetRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TableId string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	// A map of BetType to amount (e.g., {"Player": 100, "Dragon": 20})
+	Bets          map[string]int64 `protobuf:"bytes,2,rep,name=bets,proto3" json:"bets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceBetRequest) Reset() {
+	*x = PlaceBetRequest{}
+	mi := &file_baccarat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceBetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBetRequest) ProtoMessage() {}
+
+func (x *PlaceBetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBetRequest.ProtoReflect.Descriptor instead.
+func (*PlaceBetRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PlaceBetRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *PlaceBetRequest) GetBets() map[string]int64 {
+	if x != nil {
+		return x.Bets
+	}
+	return nil
+}
+
+type PlaceBetResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Success      bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// The hand dealt to the whole table when the betting window closed, settled
+	// for the caller's bets. The Flutter client will delay to show these cards
+	// sequentially.
+	Result        *HandResult `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceBetResponse) Reset() {
+	*x = PlaceBetResponse{}
+	mi := &file_baccarat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceBetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBetResponse) ProtoMessage() {}
+
+func (x *PlaceBetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBetResponse.ProtoReflect.Descriptor instead.
+func (*PlaceBetResponse) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PlaceBetResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PlaceBetResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *PlaceBetResponse) GetResult() *HandResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type HandResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered sequence of cards drawn (e.g., "SA", "H8")
+	PlayerCards []string `protobuf:"bytes,1,rep,name=player_cards,json=playerCards,proto3" json:"player_cards,omitempty"`
+	BankerCards []string `protobuf:"bytes,2,rep,name=banker_cards,json=bankerCards,proto3" json:"banker_cards,omitempty"`
+	PlayerTotal int32    `protobuf:"varint,3,opt,name=player_total,json=playerTotal,proto3" json:"player_total,omitempty"`
+	BankerTotal int32    `protobuf:"varint,4,opt,name=banker_total,json=bankerTotal,proto3" json:"banker_total,omitempty"`
+	Outcome     string   `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"` // "OutcomePlayer", "OutcomeBanker", "OutcomePanda8", etc.
+	// Total amount won (or refunded) for this specific user in this round
+	TotalPayout   int64 `protobuf:"varint,6,opt,name=total_payout,json=totalPayout,proto3" json:"total_payout,omitempty"`
+	NewBalance    int64 `protobuf:"varint,7,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandResult) Reset() {
+	*x = HandResult{}
+	mi := &file_baccarat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandResult) ProtoMessage() {}
+
+func (x *HandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandResult.ProtoReflect.Descriptor instead.
+func (*HandResult) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HandResult) GetPlayerCards() []string {
+	if x != nil {
+		return x.PlayerCards
+	}
+	return nil
+}
+
+func (x *HandResult) GetBankerCards() []string {
+	if x != nil {
+		return x.BankerCards
+	}
+	return nil
+}
+
+func (x *HandResult) GetPlayerTotal() int32 {
+	if x != nil {
+		return x.PlayerTotal
+	}
+	return 0
+}
+
+func (x *HandResult) GetBankerTotal() int32 {
+	if x != nil {
+		return x.BankerTotal
+	}
+	return 0
+}
+
+func (x *HandResult) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *HandResult) GetTotalPayout() int64 {
+	if x != nil {
+		return x.TotalPayout
+	}
+	return 0
+}
+
+func (x *HandResult) GetNewBalance() int64 {
+	if x != nil {
+		return x.NewBalance
+	}
+	return 0
+}
+
+type SubscribeTableRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TableId string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	// Resume after the event with this seq; 0 replays every event the server
+	// still holds. Unset streams live events only. If the events are no longer
+	// held the call fails with OUT_OF_RANGE, and the client should resync with
+	// GetTableState and subscribe again without after_seq.
+	AfterSeq      *uint64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3,oneof" json:"after_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeTableRequest) Reset() {
+	*x = SubscribeTableRequest{}
+	mi := &file_baccarat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTableRequest) ProtoMessage() {}
+
+func (x *SubscribeTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTableRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTableRequest) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SubscribeTableRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *SubscribeTableRequest) GetAfterSeq() uint64 {
+	if x != nil && x.AfterSeq != nil {
+		return *x.AfterSeq
+	}
+	return 0
+}
+
+type TableEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Seq        uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"` // Increases by one with every event of the table
+	TimeUnixMs int64                  `protobuf:"varint,2,opt,name=time_unix_ms,json=timeUnixMs,proto3" json:"time_unix_ms,omitempty"`
+	TableId    string                 `protobuf:"bytes,3,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	Round      int32                  `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"` // Number of the round the event belongs to
+	// Types that are valid to be assigned to Event:
+	//
+	//	*TableEvent_Phase
+	//	*TableEvent_Countdown
+	//	*TableEvent_Seat
+	//	*TableEvent_Bet
+	//	*TableEvent_Card
+	//	*TableEvent_Decision
+	//	*TableEvent_Outcome
+	//	*TableEvent_Settlement
+	//	*TableEvent_CutCard
+	//	*TableEvent_NewShoe
+	//	*TableEvent_ShoeReveal
+	Event         isTableEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableEvent) Reset() {
+	*x = TableEvent{}
+	mi := &file_baccarat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableEvent) ProtoMessage() {}
+
+func (x *TableEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableEvent.ProtoReflect.Descriptor instead.
+func (*TableEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TableEvent) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *TableEvent) GetTimeUnixMs() int64 {
+	if x != nil {
+		return x.TimeUnixMs
+	}
+	return 0
+}
+
+func (x *TableEvent) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *TableEvent) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TableEvent) GetEvent() isTableEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *TableEvent) GetPhase() *PhaseEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_Phase); ok {
+			return x.Phase
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetCountdown() *CountdownEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_Countdown); ok {
+			return x.Countdown
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetSeat() *SeatEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_Seat); ok {
+			return x.Seat
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetBet() *BetEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_Bet); ok {
+			return x.Bet
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetCard() *CardEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_Card); ok {
+			return x.Card
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetDecision() *DecisionEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_Decision); ok {
+			return x.Decision
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetOutcome() *OutcomeEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_Outcome); ok {
+			return x.Outcome
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetSettlement() *SettlementEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_Settlement); ok {
+			return x.Settlement
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetCutCard() *CutCardEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_CutCard); ok {
+			return x.CutCard
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetNewShoe() *NewShoeEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_NewShoe); ok {
+			return x.NewShoe
+		}
+	}
+	return nil
+}
+
+func (x *TableEvent) GetShoeReveal() *ShoeRevealEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TableEvent_ShoeReveal); ok {
+			return x.ShoeReveal
+		}
+	}
+	return nil
+}
+
+type isTableEvent_Event interface {
+	isTableEvent_Event()
+}
+
+type TableEvent_Phase struct {
+	Phase *PhaseEvent `protobuf:"bytes,10,opt,name=phase,proto3,oneof"`
+}
+
+type TableEvent_Countdown struct {
+	Countdown *CountdownEvent `protobuf:"bytes,11,opt,name=countdown,proto3,oneof"`
+}
+
+type TableEvent_Seat struct {
+	Seat *SeatEvent `protobuf:"bytes,12,opt,name=seat,proto3,oneof"`
+}
+
+type TableEvent_Bet struct {
+	Bet *BetEvent `protobuf:"bytes,13,opt,name=bet,proto3,oneof"`
+}
+
+type TableEvent_Card struct {
+	Card *CardEvent `protobuf:"bytes,14,opt,name=card,proto3,oneof"`
+}
+
+type TableEvent_Decision struct {
+	Decision *DecisionEvent `protobuf:"bytes,15,opt,name=decision,proto3,oneof"`
+}
+
+type TableEvent_Outcome struct {
+	Outcome *OutcomeEvent `protobuf:"bytes,16,opt,name=outcome,proto3,oneof"`
+}
+
+type TableEvent_Settlement struct {
+	Settlement *SettlementEvent `protobuf:"bytes,17,opt,name=settlement,proto3,oneof"`
+}
+
+type TableEvent_CutCard struct {
+	CutCard *CutCardEvent `protobuf:"bytes,18,opt,name=cut_card,json=cutCard,proto3,oneof"`
+}
+
+type TableEvent_NewShoe struct {
+	NewShoe *NewShoeEvent `protobuf:"bytes,19,opt,name=new_shoe,json=newShoe,proto3,oneof"`
+}
+
+type TableEvent_ShoeReveal struct {
+	ShoeReveal *ShoeRevealEvent `protobuf:"bytes,20,opt,name=shoe_reveal,json=shoeReveal,proto3,oneof"`
+}
+
+func (*TableEvent_Phase) isTableEvent_Event() {}
+
+func (*TableEvent_Countdown) isTableEvent_Event() {}
+
+func (*TableEvent_Seat) isTableEvent_Event() {}
+
+func (*TableEvent_Bet) isTableEvent_Event() {}
+
+func (*TableEvent_Card) isTableEvent_Event() {}
+
+func (*TableEvent_Decision) isTableEvent_Event() {}
+
+func (*TableEvent_Outcome) isTableEvent_Event() {}
+
+func (*TableEvent_Settlement) isTableEvent_Event() {}
+
+func (*TableEvent_CutCard) isTableEvent_Event() {}
+
+func (*TableEvent_NewShoe) isTableEvent_Event() {}
+
+func (*TableEvent_ShoeReveal) isTableEvent_Event() {}
+
+type PhaseEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                          // "BETTING_OPEN", "DEALING", "RESOLVED"
+	DeadlineUnixMs int64                  `protobuf:"varint,2,opt,name=deadline_unix_ms,json=deadlineUnixMs,proto3" json:"deadline_unix_ms,omitempty"` // When betting closes; set for "BETTING_OPEN"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PhaseEvent) Reset() {
+	*x = PhaseEvent{}
+	mi := &file_baccarat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseEvent) ProtoMessage() {}
+
+func (x *PhaseEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseEvent.ProtoReflect.Descriptor instead.
+func (*PhaseEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PhaseEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PhaseEvent) GetDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.DeadlineUnixMs
+	}
+	return 0
+}
+
+type CountdownEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RemainingMs    int64                  `protobuf:"varint,1,opt,name=remaining_ms,json=remainingMs,proto3" json:"remaining_ms,omitempty"`
+	DeadlineUnixMs int64                  `protobuf:"varint,2,opt,name=deadline_unix_ms,json=deadlineUnixMs,proto3" json:"deadline_unix_ms,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CountdownEvent) Reset() {
+	*x = CountdownEvent{}
+	mi := &file_baccarat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountdownEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountdownEvent) ProtoMessage() {}
+
+func (x *CountdownEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountdownEvent.ProtoReflect.Descriptor instead.
+func (*CountdownEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CountdownEvent) GetRemainingMs() int64 {
+	if x != nil {
+		return x.RemainingMs
+	}
+	return 0
+}
+
+func (x *CountdownEvent) GetDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.DeadlineUnixMs
+	}
+	return 0
+}
+
+type SeatEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatNumber    int32                  `protobuf:"varint,1,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"`
+	PlayerName    string                 `protobuf:"bytes,2,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	Joined        bool                   `protobuf:"varint,3,opt,name=joined,proto3" json:"joined,omitempty"` // false when the player left the seat
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatEvent) Reset() {
+	*x = SeatEvent{}
+	mi := &file_baccarat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatEvent) ProtoMessage() {}
+
+func (x *SeatEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatEvent.ProtoReflect.Descriptor instead.
+func (*SeatEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SeatEvent) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+func (x *SeatEvent) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *SeatEvent) GetJoined() bool {
+	if x != nil {
+		return x.Joined
+	}
+	return false
+}
+
+type BetEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatNumber    int32                  `protobuf:"varint,1,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"`
+	PlayerName    string                 `protobuf:"bytes,2,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	Bets          map[string]int64       `protobuf:"bytes,3,rep,name=bets,proto3" json:"bets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // The player's bets for the round, replacing earlier ones
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BetEvent) Reset() {
+	*x = BetEvent{}
+	mi := &file_baccarat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BetEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BetEvent) ProtoMessage() {}
+
+func (x *BetEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BetEvent.ProtoReflect.Descriptor instead.
+func (*BetEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BetEvent) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+func (x *BetEvent) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *BetEvent) GetBets() map[string]int64 {
+	if x != nil {
+		return x.Bets
+	}
+	return nil
+}
+
+type CardEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Side          string                 `protobuf:"bytes,1,opt,name=side,proto3" json:"side,omitempty"` // "Player" or "Banker"
+	Card          string                 `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"` // e.g., "K♣", "5♥"
+	ThirdCard     bool                   `protobuf:"varint,3,opt,name=third_card,json=thirdCard,proto3" json:"third_card,omitempty"`
+	PlayerTotal   int32                  `protobuf:"varint,4,opt,name=player_total,json=playerTotal,proto3" json:"player_total,omitempty"` // Totals after this card
+	BankerTotal   int32                  `protobuf:"varint,5,opt,name=banker_total,json=bankerTotal,proto3" json:"banker_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CardEvent) Reset() {
+	*x = CardEvent{}
+	mi := &file_baccarat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardEvent) ProtoMessage() {}
+
+func (x *CardEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardEvent.ProtoReflect.Descriptor instead.
+func (*CardEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CardEvent) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *CardEvent) GetCard() string {
+	if x != nil {
+		return x.Card
+	}
+	return ""
+}
+
+func (x *CardEvent) GetThirdCard() bool {
+	if x != nil {
+		return x.ThirdCard
+	}
+	return false
+}
+
+func (x *CardEvent) GetPlayerTotal() int32 {
+	if x != nil {
+		return x.PlayerTotal
+	}
+	return 0
+}
+
+func (x *CardEvent) GetBankerTotal() int32 {
+	if x != nil {
+		return x.BankerTotal
+	}
+	return 0
+}
+
+type DecisionEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Side          string                 `protobuf:"bytes,1,opt,name=side,proto3" json:"side,omitempty"` // "Player" or "Banker"
+	Hit           bool                   `protobuf:"varint,2,opt,name=hit,proto3" json:"hit,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // e.g., "Banker hits on 4 against Player third card 5"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecisionEvent) Reset() {
+	*x = DecisionEvent{}
+	mi := &file_baccarat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecisionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecisionEvent) ProtoMessage() {}
+
+func (x *DecisionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecisionEvent.ProtoReflect.Descriptor instead.
+func (*DecisionEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DecisionEvent) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *DecisionEvent) GetHit() bool {
+	if x != nil {
+		return x.Hit
+	}
+	return false
+}
+
+func (x *DecisionEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type OutcomeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outcome       string                 `protobuf:"bytes,1,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	PlayerTotal   int32                  `protobuf:"varint,2,opt,name=player_total,json=playerTotal,proto3" json:"player_total,omitempty"`
+	BankerTotal   int32                  `protobuf:"varint,3,opt,name=banker_total,json=bankerTotal,proto3" json:"banker_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutcomeEvent) Reset() {
+	*x = OutcomeEvent{}
+	mi := &file_baccarat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutcomeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutcomeEvent) ProtoMessage() {}
+
+func (x *OutcomeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutcomeEvent.ProtoReflect.Descriptor instead.
+func (*OutcomeEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *OutcomeEvent) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *OutcomeEvent) GetPlayerTotal() int32 {
+	if x != nil {
+		return x.PlayerTotal
+	}
+	return 0
+}
+
+func (x *OutcomeEvent) GetBankerTotal() int32 {
+	if x != nil {
+		return x.BankerTotal
+	}
+	return 0
+}
+
+type SettlementEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatNumber    int32                  `protobuf:"varint,1,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"`
+	PlayerName    string                 `protobuf:"bytes,2,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	TotalPayout   int64                  `protobuf:"varint,3,opt,name=total_payout,json=totalPayout,proto3" json:"total_payout,omitempty"`
+	NetChange     int64                  `protobuf:"varint,4,opt,name=net_change,json=netChange,proto3" json:"net_change,omitempty"`
+	NewBalance    int64                  `protobuf:"varint,5,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SettlementEvent) Reset() {
+	*x = SettlementEvent{}
+	mi := &file_baccarat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettlementEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementEvent) ProtoMessage() {}
+
+func (x *SettlementEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettlementEvent.ProtoReflect.Descriptor instead.
+func (*SettlementEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SettlementEvent) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+func (x *SettlementEvent) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *SettlementEvent) GetTotalPayout() int64 {
+	if x != nil {
+		return x.TotalPayout
+	}
+	return 0
+}
+
+func (x *SettlementEvent) GetNetChange() int64 {
+	if x != nil {
+		return x.NetChange
+	}
+	return 0
+}
+
+func (x *SettlementEvent) GetNewBalance() int64 {
+	if x != nil {
+		return x.NewBalance
+	}
+	return 0
+}
+
+// The cut card came out; the hand just dealt was the last of the shoe.
+type CutCardEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CutCardEvent) Reset() {
+	*x = CutCardEvent{}
+	mi := &file_baccarat_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CutCardEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CutCardEvent) ProtoMessage() {}
+
+func (x *CutCardEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CutCardEvent.ProtoReflect.Descriptor instead.
+func (*CutCardEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{34}
+}
+
+type NewShoeEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShoeId         string                 `protobuf:"bytes,1,opt,name=shoe_id,json=shoeId,proto3" json:"shoe_id,omitempty"`
+	CardsRemaining int32                  `protobuf:"varint,2,opt,name=cards_remaining,json=cardsRemaining,proto3" json:"cards_remaining,omitempty"` // After the burn
+	Commitment     *ShoeCommitment        `protobuf:"bytes,3,opt,name=commitment,proto3" json:"commitment,omitempty"`                                // Set on a provably-fair server
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NewShoeEvent) Reset() {
+	*x = NewShoeEvent{}
+	mi := &file_baccarat_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewShoeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewShoeEvent) ProtoMessage() {}
+
+func (x *NewShoeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewShoeEvent.ProtoReflect.Descriptor instead.
+func (*NewShoeEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *NewShoeEvent) GetShoeId() string {
+	if x != nil {
+		return x.ShoeId
+	}
+	return ""
+}
+
+func (x *NewShoeEvent) GetCardsRemaining() int32 {
+	if x != nil {
+		return x.CardsRemaining
+	}
+	return 0
+}
+
+func (x *NewShoeEvent) GetCommitment() *ShoeCommitment {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+// A provably-fair shoe was retired and its server seed is revealed. Sent
+// before the NewShoeEvent of the shoe replacing it.
+type ShoeRevealEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commitment    *ShoeCommitment        `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	ServerSeed    string                 `protobuf:"bytes,2,opt,name=server_seed,json=serverSeed,proto3" json:"server_seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoeRevealEvent) Reset() {
+	*x = ShoeRevealEvent{}
+	mi := &file_baccarat_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoeRevealEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoeRevealEvent) ProtoMessage() {}
+
+func (x *ShoeRevealEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_baccarat_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoeRevealEvent.ProtoReflect.Descriptor instead.
+func (*ShoeRevealEvent) Descriptor() ([]byte, []int) {
+	return file_baccarat_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ShoeRevealEvent) GetCommitment() *ShoeCommitment {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *ShoeRevealEvent) GetServerSeed() string {
+	if x != nil {
+		return x.ServerSeed
+	}
+	return ""
+}
+
+var File_baccarat_proto protoreflect.FileDescriptor
+
+const file_baccarat_proto_rawDesc = "" +
+	"\n" +
+	"\x0ebaccarat.proto\x12\vbaccarat.v1\"k\n" +
+	"\fLoginRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\tR\n" +
+	"credential\x12\x1f\n" +
+	"\vplayer_name\x18\x03 \x01(\tR\n" +
+	"playerName\"\xb9\x01\n" +
+	"\rLoginResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12+\n" +
+	"\x12expires_at_unix_ms\x18\x02 \x01(\x03R\x0fexpiresAtUnixMs\x12\x1f\n" +
+	"\vplayer_name\x18\x03 \x01(\tR\n" +
+	"playerName\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x03R\abalance\x12\x1d\n" +
+	"\n" +
+	"new_player\x18\x05 \x01(\bR\tnewPlayer\"\x13\n" +
+	"\x11ListTablesRequest\"G\n" +
+	"\x12ListTablesResponse\x121\n" +
+	"\x06tables\x18\x01 \x03(\v2\x19.baccarat.v1.TableSummaryR\x06tables\"\x89\x01\n" +
+	"\fTableSummary\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12%\n" +
+	"\x0eplayers_seated\x18\x02 \x01(\x05R\rplayersSeated\x12\x1f\n" +
+	"\vmax_players\x18\x03 \x01(\x05R\n" +
+	"maxPlayers\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"5\n" +
+	"\x12CreateTableRequest\x12\x1f\n" +
+	"\vmax_players\x18\x01 \x01(\x05R\n" +
+	"maxPlayers\"0\n" +
+	"\x13CreateTableResponse\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\"-\n" +
+	"\x10JoinTableRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\"s\n" +
+	"\x11JoinTableResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1f\n" +
+	"\vseat_number\x18\x02 \x01(\x05R\n" +
+	"seatNumber\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\".\n" +
+	"\x11LeaveTableRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\".\n" +
+	"\x12LeaveTableResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"1\n" +
+	"\x14GetTableStateRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\"\xc0\x02\n" +
+	"\x15GetTableStateResponse\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x120\n" +
+	"\x14shoe_cards_remaining\x18\x03 \x01(\x05R\x12shoeCardsRemaining\x123\n" +
+	"\aplayers\x18\x04 \x03(\v2\x19.baccarat.v1.SeatedPlayerR\aplayers\x12.\n" +
+	"\aroadmap\x18\x05 \x01(\v2\x14.baccarat.v1.RoadmapR\aroadmap\x12\x17\n" +
+	"\ashoe_id\x18\x06 \x01(\tR\x06shoeId\x12D\n" +
+	"\x0fshoe_commitment\x18\a \x01(\v2\x1b.baccarat.v1.ShoeCommitmentR\x0eshoeCommitment\"\xff\x01\n" +
+	"\x0eShoeCommitment\x12\x17\n" +
+	"\ashoe_id\x18\x01 \x01(\tR\x06shoeId\x12(\n" +
+	"\x10server_seed_hash\x18\x02 \x01(\tR\x0eserverSeedHash\x12\x1f\n" +
+	"\vclient_seed\x18\x03 \x01(\tR\n" +
+	"clientSeed\x12\x1d\n" +
+	"\n" +
+	"order_hash\x18\x04 \x01(\tR\torderHash\x12\x1f\n" +
+	"\vdecks_count\x18\x05 \x01(\x05R\n" +
+	"decksCount\x12,\n" +
+	"\x12cut_card_threshold\x18\x06 \x01(\x05R\x10cutCardThreshold\x12\x1b\n" +
+	"\tburn_rule\x18\a \x01(\tR\bburnRule\"R\n" +
+	"\x14SetClientSeedRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x1f\n" +
+	"\vclient_seed\x18\x02 \x01(\tR\n" +
+	"clientSeed\"\x17\n" +
+	"\x15SetClientSeedResponse\"j\n" +
+	"\fSeatedPlayer\x12\x1f\n" +
+	"\vseat_number\x18\x01 \x01(\x05R\n" +
+	"seatNumber\x12\x1f\n" +
+	"\vplayer_name\x18\x02 \x01(\tR\n" +
+	"playerName\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\x03R\abalance\"\xd5\x02\n" +
+	"\aRoadmap\x12\x1a\n" +
+	"\boutcomes\x18\x01 \x03(\tR\boutcomes\x120\n" +
+	"\n" +
+	"bead_plate\x18\x02 \x01(\v2\x11.baccarat.v1.RoadR\tbeadPlate\x12,\n" +
+	"\bbig_road\x18\x03 \x01(\v2\x11.baccarat.v1.RoadR\abigRoad\x121\n" +
+	"\vbig_eye_boy\x18\x04 \x01(\v2\x11.baccarat.v1.RoadR\tbigEyeBoy\x120\n" +
+	"\n" +
+	"small_road\x18\x05 \x01(\v2\x11.baccarat.v1.RoadR\tsmallRoad\x126\n" +
+	"\rcockroach_pig\x18\x06 \x01(\v2\x11.baccarat.v1.RoadR\fcockroachPig\x121\n" +
+	"\task_roads\x18\a \x03(\v2\x14.baccarat.v1.AskRoadR\baskRoads\"M\n" +
+	"\x04Road\x12\x18\n" +
+	"\acolumns\x18\x01 \x01(\x05R\acolumns\x12+\n" +
+	"\x05cells\x18\x02 \x03(\v2\x15.baccarat.v1.RoadCellR\x05cells\"^\n" +
+	"\bRoadCell\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\x05R\x06column\x12\x10\n" +
+	"\x03row\x18\x02 \x01(\x05R\x03row\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x12\n" +
+	"\x04ties\x18\x04 \x01(\x05R\x04ties\"\x81\x01\n" +
+	"\aAskRoad\x12\x12\n" +
+	"\x04next\x18\x01 \x01(\tR\x04next\x12\x1e\n" +
+	"\vbig_eye_boy\x18\x02 \x01(\tR\tbigEyeBoy\x12\x1d\n" +
+	"\n" +
+	"small_road\x18\x03 \x01(\tR\tsmallRoad\x12#\n" +
+	"\rcockroach_pig\x18\x04 \x01(\tR\fcockroachPig\"\xa1\x01\n" +
+	"\x0fPlaceBetRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12:\n" +
+	"\x04bets\x18\x02 \x03(\v2&.baccarat.v1.PlaceBetRequest.BetsEntryR\x04bets\x1a7\n" +
+	"\tBetsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x82\x01\n" +
+	"\x10PlaceBetResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12/\n" +
+	"\x06result\x18\x03 \x01(\v2\x17.baccarat.v1.HandResultR\x06result\"\xf6\x01\n" +
+	"\n" +
+	"HandResult\x12!\n" +
+	"\fplayer_cards\x18\x01 \x03(\tR\vplayerCards\x12!\n" +
+	"\fbanker_cards\x18\x02 \x03(\tR\vbankerCards\x12!\n" +
+	"\fplayer_total\x18\x03 \x01(\x05R\vplayerTotal\x12!\n" +
+	"\fbanker_total\x18\x04 \x01(\x05R\vbankerTotal\x12\x18\n" +
+	"\aoutcome\x18\x05 \x01(\tR\aoutcome\x12!\n" +
+	"\ftotal_payout\x18\x06 \x01(\x03R\vtotalPayout\x12\x1f\n" +
+	"\vnew_balance\x18\a \x01(\x03R\n" +
+	"newBalance\"b\n" +
+	"\x15SubscribeTableRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12 \n" +
+	"\tafter_seq\x18\x02 \x01(\x04H\x00R\bafterSeq\x88\x01\x01B\f\n" +
+	"\n" +
+	"_after_seq\"\xd1\x05\n" +
+	"\n" +
+	"TableEvent\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12 \n" +
+	"\ftime_unix_ms\x18\x02 \x01(\x03R\n" +
+	"timeUnixMs\x12\x19\n" +
+	"\btable_id\x18\x03 \x01(\tR\atableId\x12\x14\n" +
+	"\x05round\x18\x04 \x01(\x05R\x05round\x12/\n" +
+	"\x05phase\x18\n" +
+	" \x01(\v2\x17.baccarat.v1.PhaseEventH\x00R\x05phase\x12;\n" +
+	"\tcountdown\x18\v \x01(\v2\x1b.baccarat.v1.CountdownEventH\x00R\tcountdown\x12,\n" +
+	"\x04seat\x18\f \x01(\v2\x16.baccarat.v1.SeatEventH\x00R\x04seat\x12)\n" +
+	"\x03bet\x18\r \x01(\v2\x15.baccarat.v1.BetEventH\x00R\x03bet\x12,\n" +
+	"\x04card\x18\x0e \x01(\v2\x16.baccarat.v1.CardEventH\x00R\x04card\x128\n" +
+	"\bdecision\x18\x0f \x01(\v2\x1a.baccarat.v1.DecisionEventH\x00R\bdecision\x125\n" +
+	"\aoutcome\x18\x10 \x01(\v2\x19.baccarat.v1.OutcomeEventH\x00R\aoutcome\x12>\n" +
+	"\n" +
+	"settlement\x18\x11 \x01(\v2\x1c.baccarat.v1.SettlementEventH\x00R\n" +
+	"settlement\x126\n" +
+	"\bcut_card\x18\x12 \x01(\v2\x19.baccarat.v1.CutCardEventH\x00R\acutCard\x126\n" +
+	"\bnew_shoe\x18\x13 \x01(\v2\x19.baccarat.v1.NewShoeEventH\x00R\anewShoe\x12?\n" +
+	"\vshoe_reveal\x18\x14 \x01(\v2\x1c.baccarat.v1.ShoeRevealEventH\x00R\n" +
+	"shoeRevealB\a\n" +
+	"\x05event\"N\n" +
+	"\n" +
+	"PhaseEvent\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12(\n" +
+	"\x10deadline_unix_ms\x18\x02 \x01(\x03R\x0edeadlineUnixMs\"]\n" +
+	"\x0eCountdownEvent\x12!\n" +
+	"\fremaining_ms\x18\x01 \x01(\x03R\vremainingMs\x12(\n" +
+	"\x10deadline_unix_ms\x18\x02 \x01(\x03R\x0edeadlineUnixMs\"e\n" +
+	"\tSeatEvent\x12\x1f\n" +
+	"\vseat_number\x18\x01 \x01(\x05R\n" +
+	"seatNumber\x12\x1f\n" +
+	"\vplayer_name\x18\x02 \x01(\tR\n" +
+	"playerName\x12\x16\n" +
+	"\x06joined\x18\x03 \x01(\bR\x06joined\"\xba\x01\n" +
+	"\bBetEvent\x12\x1f\n" +
+	"\vseat_number\x18\x01 \x01(\x05R\n" +
+	"seatNumber\x12\x1f\n" +
+	"\vplayer_name\x18\x02 \x01(\tR\n" +
+	"playerName\x123\n" +
+	"\x04bets\x18\x03 \x03(\v2\x1f.baccarat.v1.BetEvent.BetsEntryR\x04bets\x1a7\n" +
+	"\tBetsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x98\x01\n" +
+	"\tCardEvent\x12\x12\n" +
+	"\x04side\x18\x01 \x01(\tR\x04side\x12\x12\n" +
+	"\x04card\x18\x02 \x01(\tR\x04card\x12\x1d\n" +
+	"\n" +
+	"third_card\x18\x03 \x01(\bR\tthirdCard\x12!\n" +
+	"\fplayer_total\x18\x04 \x01(\x05R\vplayerTotal\x12!\n" +
+	"\fbanker_total\x18\x05 \x01(\x05R\vbankerTotal\"M\n" +
+	"\rDecisionEvent\x12\x12\n" +
+	"\x04side\x18\x01 \x01(\tR\x04side\x12\x10\n" +
+	"\x03hit\x18\x02 \x01(\bR\x03hit\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"n\n" +
+	"\fOutcomeEvent\x12\x18\n" +
+	"\aoutcome\x18\x01 \x01(\tR\aoutcome\x12!\n" +
+	"\fplayer_total\x18\x02 \x01(\x05R\vplayerTotal\x12!\n" +
+	"\fbanker_total\x18\x03 \x01(\x05R\vbankerTotal\"\xb6\x01\n" +
+	"\x0fSettlementEvent\x12\x1f\n" +
+	"\vseat_number\x18\x01 \x01(\x05R\n" +
+	"seatNumber\x12\x1f\n" +
+	"\vplayer_name\x18\x02 \x01(\tR\n" +
+	"playerName\x12!\n" +
+	"\ftotal_payout\x18\x03 \x01(\x03R\vtotalPayout\x12\x1d\n" +
+	"\n" +
+	"net_change\x18\x04 \x01(\x03R\tnetChange\x12\x1f\n" +
+	"\vnew_balance\x18\x05 \x01(\x03R\n" +
+	"newBalance\"\x0e\n" +
+	"\fCutCardEvent\"\x8d\x01\n" +
+	"\fNewShoeEvent\x12\x17\n" +
+	"\ashoe_id\x18\x01 \x01(\tR\x06shoeId\x12'\n" +
+	"\x0fcards_remaining\x18\x02 \x01(\x05R\x0ecardsRemaining\x12;\n" +
+	"\n" +
+	"commitment\x18\x03 \x01(\v2\x1b.baccarat.v1.ShoeCommitmentR\n" +
+	"commitment\"o\n" +
+	"\x0fShoeRevealEvent\x12;\n" +
+	"\n" +
+	"commitment\x18\x01 \x01(\v2\x1b.baccarat.v1.ShoeCommitmentR\n" +
+	"commitment\x12\x1f\n" +
+	"\vserver_seed\x18\x02 \x01(\tR\n" +
+	"serverSeed2M\n" +
+	"\vAuthService\x12>\n" +
+	"\x05Login\x12\x19.baccarat.v1.LoginRequest\x1a\x1a.baccarat.v1.LoginResponse2\xaf\x01\n" +
+	"\fLobbyService\x12M\n" +
+	"\n" +
+	"ListTables\x12\x1e.baccarat.v1.ListTablesRequest\x1a\x1f.baccarat.v1.ListTablesResponse\x12P\n" +
+	"\vCreateTable\x12\x1f.baccarat.v1.CreateTableRequest\x1a .baccarat.v1.CreateTableResponse2\xf3\x03\n" +
+	"\fTableService\x12J\n" +
+	"\tJoinTable\x12\x1d.baccarat.v1.JoinTableRequest\x1a\x1e.baccarat.v1.JoinTableResponse\x12M\n" +
+	"\n" +
+	"LeaveTable\x12\x1e.baccarat.v1.LeaveTableRequest\x1a\x1f.baccarat.v1.LeaveTableResponse\x12V\n" +
+	"\rGetTableState\x12!.baccarat.v1.GetTableStateRequest\x1a\".baccarat.v1.GetTableStateResponse\x12G\n" +
+	"\bPlaceBet\x12\x1c.baccarat.v1.PlaceBetRequest\x1a\x1d.baccarat.v1.PlaceBetResponse\x12O\n" +
+	"\x0eSubscribeTable\x12\".baccarat.v1.SubscribeTableRequest\x1a\x17.baccarat.v1.TableEvent0\x01\x12V\n" +
+	"\rSetClientSeed\x12!.baccarat.v1.SetClientSeedRequest\x1a\".baccarat.v1.SetClientSeedResponseBEZCgithub.com/niubaoshu/es-Baccarat/backend/api/baccarat/v1;baccaratv1b\x06proto3"
+
+var (
+	file_baccarat_proto_rawDescOnce sync.Once
+	file_baccarat_proto_rawDescData []byte
+)
+
+func file_baccarat_proto_rawDescGZIP() []byte {
+	file_baccarat_proto_rawDescOnce.Do(func() {
+		file_baccarat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_baccarat_proto_rawDesc), len(file_baccarat_proto_rawDesc)))
+	})
+	return file_baccarat_proto_rawDescData
+}
+
+var file_baccarat_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_baccarat_proto_goTypes = []any{
+	(*LoginRequest)(nil),          // 0: baccarat.v1.LoginRequest
+	(*LoginResponse)(nil),         // 1: baccarat.v1.LoginResponse
+	(*ListTablesRequest)(nil),     // 2: baccarat.v1.ListTablesRequest
+	(*ListTablesResponse)(nil),    // 3: baccarat.v1.ListTablesResponse
+	(*TableSummary)(nil),          // 4: baccarat.v1.TableSummary
+	(*CreateTableRequest)(nil),    // 5: baccarat.v1.CreateTableRequest
+	(*CreateTableResponse)(nil),   // 6: baccarat.v1.CreateTableResponse
+	(*JoinTableRequest)(nil),      // 7: baccarat.v1.JoinTableRequest
+	(*JoinTableResponse)(nil),     // 8: baccarat.v1.JoinTableResponse
+	(*LeaveTableRequest)(nil),     // 9: baccarat.v1.LeaveTableRequest
+	(*LeaveTableResponse)(nil),    // 10: baccarat.v1.LeaveTableResponse
+	(*GetTableStateRequest)(nil),  // 11: baccarat.v1.GetTableStateRequest
+	(*GetTableStateResponse)(nil), // 12: baccarat.v1.GetTableStateResponse
+	(*ShoeCommitment)(nil),        // 13: baccarat.v1.ShoeCommitment
+	(*SetClientSeedRequest)(nil),  // 14: baccarat.v1.SetClientSeedRequest
+	(*SetClientSeedResponse)(nil), // 15: baccarat.v1.SetClientSeedResponse
+	(*SeatedPlayer)(nil),          // 16: baccarat.v1.SeatedPlayer
+	(*Roadmap)(nil),               // 17: baccarat.v1.Roadmap
+	(*Road)(nil),                  // 18: baccarat.v1.Road
+	(*RoadCell)(nil),              // 19: baccarat.v1.RoadCell
+	(*AskRoad)(nil),               // 20: baccarat.v1.AskRoad
+	(*PlaceBetRequest)(nil),       // 21: baccarat.v1.PlaceBetRequest
+	(*PlaceBetResponse)(nil),      // 22: baccarat.v1.PlaceBetResponse
+	(*HandResult)(nil),            // 23: baccarat.v1.HandResult
+	(*SubscribeTableRequest)(nil), // 24: baccarat.v1.SubscribeTableRequest
+	(*TableEvent)(nil),            // 25: baccarat.v1.TableEvent
+	(*PhaseEvent)(nil),            // 26: baccarat.v1.PhaseEvent
+	(*CountdownEvent)(nil),        // 27: baccarat.v1.CountdownEvent
+	(*SeatEvent)(nil),             // 28: baccarat.v1.SeatEvent
+	(*BetEvent)(nil),              // 29: baccarat.v1.BetEvent
+	(*CardEvent)(nil),             // 30: baccarat.v1.CardEvent
+	(*DecisionEvent)(nil),         // 31: baccarat.v1.DecisionEvent
+	(*OutcomeEvent)(nil),          // 32: baccarat.v1.OutcomeEvent
+	(*SettlementEvent)(nil),       // 33: baccarat.v1.SettlementEvent
+	(*CutCardEvent)(nil),          // 34: baccarat.v1.CutCardEvent
+	(*NewShoeEvent)(nil),          // 35: baccarat.v1.NewShoeEvent
+	(*ShoeRevealEvent)(nil),       // 36: baccarat.v1.ShoeRevealEvent
+	nil,                           // 37: baccarat.v1.PlaceBetRequest.BetsEntry
+	nil,                           // 38: baccarat.v1.BetEvent.BetsEntry
+}
+var file_baccarat_proto_depIdxs = []int32{
+	4,  // 0: baccarat.v1.ListTablesResponse.tables:type_name -> baccarat.v1.TableSummary
+	16, // 1: baccarat.v1.GetTableStateResponse.players:type_name -> baccarat.v1.SeatedPlayer
+	17, // 2: baccarat.v1.GetTableStateResponse.roadmap:type_name -> baccarat.v1.Roadmap
+	13, // 3: baccarat.v1.GetTableStateResponse.shoe_commitment:type_name -> baccarat.v1.ShoeCommitment
+	18, // 4: baccarat.v1.Roadmap.bead_plate:type_name -> baccarat.v1.Road
+	18, // 5: baccarat.v1.Roadmap.big_road:type_name -> baccarat.v1.Road
+	18, // 6: baccarat.v1.Roadmap.big_eye_boy:type_name -> baccarat.v1.Road
+	18, // 7: baccarat.v1.Roadmap.small_road:type_name -> baccarat.v1.Road
+	18, // 8: baccarat.v1.Roadmap.cockroach_pig:type_name -> baccarat.v1.Road
+	20, // 9: baccarat.v1.Roadmap.ask_roads:type_name -> baccarat.v1.AskRoad
+	19, // 10: baccarat.v1.Road.cells:type_name -> baccarat.v1.RoadCell
+	37, // 11: baccarat.v1.PlaceBetRequest.bets:type_name -> baccarat.v1.PlaceBetRequest.BetsEntry
+	23, // 12: baccarat.v1.PlaceBetResponse.result:type_name -> baccarat.v1.HandResult
+	26, // 13: baccarat.v1.TableEvent.phase:type_name -> baccarat.v1.PhaseEvent
+	27, // 14: baccarat.v1.TableEvent.countdown:type_name -> baccarat.v1.CountdownEvent
+	28, // 15: baccarat.v1.TableEvent.seat:type_name -> baccarat.v1.SeatEvent
+	29, // 16: baccarat.v1.TableEvent.bet:type_name -> baccarat.v1.BetEvent
+	30, // 17: baccarat.v1.TableEvent.card:type_name -> baccarat.v1.CardEvent
+	31, // 18: baccarat.v1.TableEvent.decision:type_name -> baccarat.v1.DecisionEvent
+	32, // 19: baccarat.v1.TableEvent.outcome:type_name -> baccarat.v1.OutcomeEvent
+	33, // 20: baccarat.v1.TableEvent.settlement:type_name -> baccarat.v1.SettlementEvent
+	34, // 21: baccarat.v1.TableEvent.cut_card:type_name -> baccarat.v1.CutCardEvent
+	35, // 22: baccarat.v1.TableEvent.new_shoe:type_name -> baccarat.v1.NewShoeEvent
+	36, // 23: baccarat.v1.TableEvent.shoe_reveal:type_name -> baccarat.v1.ShoeRevealEvent
+	38, // 24: baccarat.v1.BetEvent.bets:type_name -> baccarat.v1.BetEvent.BetsEntry
+	13, // 25: baccarat.v1.NewShoeEvent.commitment:type_name -> baccarat.v1.ShoeCommitment
+	13, // 26: baccarat.v1.ShoeRevealEvent.commitment:type_name -> baccarat.v1.ShoeCommitment
+	0,  // 27: baccarat.v1.AuthService.Login:input_type -> baccarat.v1.LoginRequest
+	2,  // 28: baccarat.v1.LobbyService.ListTables:input_type -> baccarat.v1.ListTablesRequest
+	5,  // 29: baccarat.v1.LobbyService.CreateTable:input_type -> baccarat.v1.CreateTableRequest
+	7,  // 30: baccarat.v1.TableService.JoinTable:input_type -> baccarat.v1.JoinTableRequest
+	9,  // 31: baccarat.v1.TableService.LeaveTable:input_type -> baccarat.v1.LeaveTableRequest
+	11, // 32: baccarat.v1.TableService.GetTableState:input_type -> baccarat.v1.GetTableStateRequest
+	21, // 33: baccarat.v1.TableService.PlaceBet:input_type -> baccarat.v1.PlaceBetRequest
+	24, // 34: baccarat.v1.TableService.SubscribeTable:input_type -> baccarat.v1.SubscribeTableRequest
+	14, // 35: baccarat.v1.TableService.SetClientSeed:input_type -> baccarat.v1.SetClientSeedRequest
+	1,  // 36: baccarat.v1.AuthService.Login:output_type -> baccarat.v1.LoginResponse
+	3,  // 37: baccarat.v1.LobbyService.ListTables:output_type -> baccarat.v1.ListTablesResponse
+	6,  // 38: baccarat.v1.LobbyService.CreateTable:output_type -> baccarat.v1.CreateTableResponse
+	8,  // 39: baccarat.v1.TableService.JoinTable:output_type -> baccarat.v1.JoinTableResponse
+	10, // 40: baccarat.v1.TableService.LeaveTable:output_type -> baccarat.v1.LeaveTableResponse
+	12, // 41: baccarat.v1.TableService.GetTableState:output_type -> baccarat.v1.GetTableStateResponse
+	22, // 42: baccarat.v1.TableService.PlaceBet:output_type -> baccarat.v1.PlaceBetResponse
+	25, // 43: baccarat.v1.TableService.SubscribeTable:output_type -> baccarat.v1.TableEvent
+	15, // 44: baccarat.v1.TableService.SetClientSeed:output_type -> baccarat.v1.SetClientSeedResponse
+	36, // [36:45] is the sub-list for method output_type
+	27, // [27:36] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_baccarat_proto_init() }
+func file_baccarat_proto_init() {
+	if File_baccarat_proto != nil {
+		return
+	}
+	file_baccarat_proto_msgTypes[24].OneofWrappers = []any{}
+	file_baccarat_proto_msgTypes[25].OneofWrappers = []any{
+		(*TableEvent_Phase)(nil),
+		(*TableEvent_Countdown)(nil),
+		(*TableEvent_Seat)(nil),
+		(*TableEvent_Bet)(nil),
+		(*TableEvent_Card)(nil),
+		(*TableEvent_Decision)(nil),
+		(*TableEvent_Outcome)(nil),
+		(*TableEvent_Settlement)(nil),
+		(*TableEvent_CutCard)(nil),
+		(*TableEvent_NewShoe)(nil),
+		(*TableEvent_ShoeReveal)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_baccarat_proto_rawDesc), len(file_baccarat_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   39,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_baccarat_proto_goTypes,
+		DependencyIndexes: file_baccarat_proto_depIdxs,
+		MessageInfos:      file_baccarat_proto_msgTypes,
+	}.Build()
+	File_baccarat_proto = out.File
+	file_baccarat_proto_goTypes = nil
+	file_baccarat_proto_depIdxs = nil
+}
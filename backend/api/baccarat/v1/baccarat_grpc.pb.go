@@ -0,0 +1,607 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.29.3
+// source: baccarat.proto
+
+package baccaratv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AuthService_Login_FullMethodName = "/baccarat.v1.AuthService/Login"
+)
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AuthService signs players in. The access token it returns is sent in the
+// "authorization: Bearer <token>" metadata of every other call.
+type AuthServiceClient interface {
+	// Verify a credential from an external identity provider (e.g. a Google
+	// access token) and issue a session token. The first login of an identity
+	// creates its player profile.
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility.
+//
+// AuthService signs players in. The access token it returns is sent in the
+// "authorization: Bearer <token>" metadata of every other call.
+type AuthServiceServer interface {
+	// Verify a credential from an external identity provider (e.g. a Google
+	// access token) and issue a session token. The first login of an identity
+	// creates its player profile.
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthServiceServer struct{}
+
+func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call panics, it indicates UnimplementedAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "baccarat.v1.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "baccarat.proto",
+}
+
+const (
+	LobbyService_ListTables_FullMethodName  = "/baccarat.v1.LobbyService/ListTables"
+	LobbyService_CreateTable_FullMethodName = "/baccarat.v1.LobbyService/CreateTable"
+)
+
+// LobbyServiceClient is the client API for LobbyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LobbyService manages the global casino floor.
+type LobbyServiceClient interface {
+	// List all currently active tables
+	ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error)
+	// Create a new Baccarat table (8 decks)
+	CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*CreateTableResponse, error)
+}
+
+type lobbyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLobbyServiceClient(cc grpc.ClientConnInterface) LobbyServiceClient {
+	return &lobbyServiceClient{cc}
+}
+
+func (c *lobbyServiceClient) ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTablesResponse)
+	err := c.cc.Invoke(ctx, LobbyService_ListTables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*CreateTableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTableResponse)
+	err := c.cc.Invoke(ctx, LobbyService_CreateTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LobbyServiceServer is the server API for LobbyService service.
+// All implementations must embed UnimplementedLobbyServiceServer
+// for forward compatibility.
+//
+// LobbyService manages the global casino floor.
+type LobbyServiceServer interface {
+	// List all currently active tables
+	ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error)
+	// Create a new Baccarat table (8 decks)
+	CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error)
+	mustEmbedUnimplementedLobbyServiceServer()
+}
+
+// UnimplementedLobbyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLobbyServiceServer struct{}
+
+func (UnimplementedLobbyServiceServer) ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTables not implemented")
+}
+func (UnimplementedLobbyServiceServer) CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTable not implemented")
+}
+func (UnimplementedLobbyServiceServer) mustEmbedUnimplementedLobbyServiceServer() {}
+func (UnimplementedLobbyServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeLobbyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LobbyServiceServer will
+// result in compilation errors.
+type UnsafeLobbyServiceServer interface {
+	mustEmbedUnimplementedLobbyServiceServer()
+}
+
+func RegisterLobbyServiceServer(s grpc.ServiceRegistrar, srv LobbyServiceServer) {
+	// If the following call panics, it indicates UnimplementedLobbyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LobbyService_ServiceDesc, srv)
+}
+
+func _LobbyService_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LobbyService_ListTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).ListTables(ctx, req.(*ListTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_CreateTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).CreateTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LobbyService_CreateTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).CreateTable(ctx, req.(*CreateTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LobbyService_ServiceDesc is the grpc.ServiceDesc for LobbyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LobbyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "baccarat.v1.LobbyService",
+	HandlerType: (*LobbyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTables",
+			Handler:    _LobbyService_ListTables_Handler,
+		},
+		{
+			MethodName: "CreateTable",
+			Handler:    _LobbyService_CreateTable_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "baccarat.proto",
+}
+
+const (
+	TableService_JoinTable_FullMethodName      = "/baccarat.v1.TableService/JoinTable"
+	TableService_LeaveTable_FullMethodName     = "/baccarat.v1.TableService/LeaveTable"
+	TableService_GetTableState_FullMethodName  = "/baccarat.v1.TableService/GetTableState"
+	TableService_PlaceBet_FullMethodName       = "/baccarat.v1.TableService/PlaceBet"
+	TableService_SubscribeTable_FullMethodName = "/baccarat.v1.TableService/SubscribeTable"
+	TableService_SetClientSeed_FullMethodName  = "/baccarat.v1.TableService/SetClientSeed"
+)
+
+// TableServiceClient is the client API for TableService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TableService manages interactions within a specific table.
+type TableServiceClient interface {
+	// Join a table and take a seat
+	JoinTable(ctx context.Context, in *JoinTableRequest, opts ...grpc.CallOption) (*JoinTableResponse, error)
+	// Leave a table and free up a seat
+	LeaveTable(ctx context.Context, in *LeaveTableRequest, opts ...grpc.CallOption) (*LeaveTableResponse, error)
+	// Get the real-time state of the table (Polling endpoint)
+	GetTableState(ctx context.Context, in *GetTableStateRequest, opts ...grpc.CallOption) (*GetTableStateResponse, error)
+	// Place bets in the table's open betting window, replacing any placed earlier
+	// in the same round. Returns once the window has closed and the table's hand
+	// has been dealt and settled, with the entire hand sequence for client animation.
+	PlaceBet(ctx context.Context, in *PlaceBetRequest, opts ...grpc.CallOption) (*PlaceBetResponse, error)
+	// Stream the table's events as they happen: phase changes, countdown ticks,
+	// seats and bets of other players, every card with the reason it was drawn,
+	// outcomes, settlements and shoe changes. A client that reconnects passes the
+	// seq of the last event it received to pick up where it left off.
+	SubscribeTable(ctx context.Context, in *SubscribeTableRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TableEvent], error)
+	// Set the client seed mixed into the shuffle of the table's next shoe on a
+	// provably-fair server. Only seated players may set it; the last one set
+	// before the shoe is brought out is used.
+	SetClientSeed(ctx context.Context, in *SetClientSeedRequest, opts ...grpc.CallOption) (*SetClientSeedResponse, error)
+}
+
+type tableServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTableServiceClient(cc grpc.ClientConnInterface) TableServiceClient {
+	return &tableServiceClient{cc}
+}
+
+func (c *tableServiceClient) JoinTable(ctx context.Context, in *JoinTableRequest, opts ...grpc.CallOption) (*JoinTableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinTableResponse)
+	err := c.cc.Invoke(ctx, TableService_JoinTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableServiceClient) LeaveTable(ctx context.Context, in *LeaveTableRequest, opts ...grpc.CallOption) (*LeaveTableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveTableResponse)
+	err := c.cc.Invoke(ctx, TableService_LeaveTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableServiceClient) GetTableState(ctx context.Context, in *GetTableStateRequest, opts ...grpc.CallOption) (*GetTableStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTableStateResponse)
+	err := c.cc.Invoke(ctx, TableService_GetTableState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableServiceClient) PlaceBet(ctx context.Context, in *PlaceBetRequest, opts ...grpc.CallOption) (*PlaceBetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaceBetResponse)
+	err := c.cc.Invoke(ctx, TableService_PlaceBet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableServiceClient) SubscribeTable(ctx context.Context, in *SubscribeTableRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TableEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TableService_ServiceDesc.Streams[0], TableService_SubscribeTable_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeTableRequest, TableEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TableService_SubscribeTableClient = grpc.ServerStreamingClient[TableEvent]
+
+func (c *tableServiceClient) SetClientSeed(ctx context.Context, in *SetClientSeedRequest, opts ...grpc.CallOption) (*SetClientSeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetClientSeedResponse)
+	err := c.cc.Invoke(ctx, TableService_SetClientSeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TableServiceServer is the server API for TableService service.
+// All implementations must embed UnimplementedTableServiceServer
+// for forward compatibility.
+//
+// TableService manages interactions within a specific table.
+type TableServiceServer interface {
+	// Join a table and take a seat
+	JoinTable(context.Context, *JoinTableRequest) (*JoinTableResponse, error)
+	// Leave a table and free up a seat
+	LeaveTable(context.Context, *LeaveTableRequest) (*LeaveTableResponse, error)
+	// Get the real-time state of the table (Polling endpoint)
+	GetTableState(context.Context, *GetTableStateRequest) (*GetTableStateResponse, error)
+	// Place bets in the table's open betting window, replacing any placed earlier
+	// in the same round. Returns once the window has closed and the table's hand
+	// has been dealt and settled, with the entire hand sequence for client animation.
+	PlaceBet(context.Context, *PlaceBetRequest) (*PlaceBetResponse, error)
+	// Stream the table's events as they happen: phase changes, countdown ticks,
+	// seats and bets of other players, every card with the reason it was drawn,
+	// outcomes, settlements and shoe changes. A client that reconnects passes the
+	// seq of the last event it received to pick up where it left off.
+	SubscribeTable(*SubscribeTableRequest, grpc.ServerStreamingServer[TableEvent]) error
+	// Set the client seed mixed into the shuffle of the table's next shoe on a
+	// provably-fair server. Only seated players may set it; the last one set
+	// before the shoe is brought out is used.
+	SetClientSeed(context.Context, *SetClientSeedRequest) (*SetClientSeedResponse, error)
+	mustEmbedUnimplementedTableServiceServer()
+}
+
+// UnimplementedTableServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTableServiceServer struct{}
+
+func (UnimplementedTableServiceServer) JoinTable(context.Context, *JoinTableRequest) (*JoinTableResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinTable not implemented")
+}
+func (UnimplementedTableServiceServer) LeaveTable(context.Context, *LeaveTableRequest) (*LeaveTableResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaveTable not implemented")
+}
+func (UnimplementedTableServiceServer) GetTableState(context.Context, *GetTableStateRequest) (*GetTableStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTableState not implemented")
+}
+func (UnimplementedTableServiceServer) PlaceBet(context.Context, *PlaceBetRequest) (*PlaceBetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlaceBet not implemented")
+}
+func (UnimplementedTableServiceServer) SubscribeTable(*SubscribeTableRequest, grpc.ServerStreamingServer[TableEvent]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeTable not implemented")
+}
+func (UnimplementedTableServiceServer) SetClientSeed(context.Context, *SetClientSeedRequest) (*SetClientSeedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetClientSeed not implemented")
+}
+func (UnimplementedTableServiceServer) mustEmbedUnimplementedTableServiceServer() {}
+func (UnimplementedTableServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeTableServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TableServiceServer will
+// result in compilation errors.
+type UnsafeTableServiceServer interface {
+	mustEmbedUnimplementedTableServiceServer()
+}
+
+func RegisterTableServiceServer(s grpc.ServiceRegistrar, srv TableServiceServer) {
+	// If the following call panics, it indicates UnimplementedTableServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TableService_ServiceDesc, srv)
+}
+
+func _TableService_JoinTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableServiceServer).JoinTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableService_JoinTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableServiceServer).JoinTable(ctx, req.(*JoinTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableService_LeaveTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableServiceServer).LeaveTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableService_LeaveTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableServiceServer).LeaveTable(ctx, req.(*LeaveTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableService_GetTableState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTableStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableServiceServer).GetTableState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableService_GetTableState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableServiceServer).GetTableState(ctx, req.(*GetTableStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableService_PlaceBet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceBetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableServiceServer).PlaceBet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableService_PlaceBet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableServiceServer).PlaceBet(ctx, req.(*PlaceBetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableService_SubscribeTable_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTableRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TableServiceServer).SubscribeTable(m, &grpc.GenericServerStream[SubscribeTableRequest, TableEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TableService_SubscribeTableServer = grpc.ServerStreamingServer[TableEvent]
+
+func _TableService_SetClientSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetClientSeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableServiceServer).SetClientSeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableService_SetClientSeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableServiceServer).SetClientSeed(ctx, req.(*SetClientSeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TableService_ServiceDesc is the grpc.ServiceDesc for TableService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TableService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "baccarat.v1.TableService",
+	HandlerType: (*TableServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "JoinTable",
+			Handler:    _TableService_JoinTable_Handler,
+		},
+		{
+			MethodName: "LeaveTable",
+			Handler:    _TableService_LeaveTable_Handler,
+		},
+		{
+			MethodName: "GetTableState",
+			Handler:    _TableService_GetTableState_Handler,
+		},
+		{
+			MethodName: "PlaceBet",
+			Handler:    _TableService_PlaceBet_Handler,
+		},
+		{
+			MethodName: "SetClientSeed",
+			Handler:    _TableService_SetClientSeed_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTable",
+			Handler:       _TableService_SubscribeTable_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "baccarat.proto",
+}
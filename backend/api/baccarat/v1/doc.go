@@ -0,0 +1,7 @@
+// Package baccaratv1 contains the Go bindings generated from api/proto/baccarat.proto.
+//
+// Regenerate after editing the proto file (run from the backend directory):
+//
+//	protoc -I ../api/proto --go_out=. --go_opt=module=github.com/niubaoshu/es-Baccarat/backend \
+//		--go-grpc_out=. --go-grpc_opt=module=github.com/niubaoshu/es-Baccarat/backend baccarat.proto
+package baccaratv1
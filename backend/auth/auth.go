@@ -0,0 +1,61 @@
+// Package auth signs players in through an external OAuth provider and issues
+// the server's own JWTs for the session.
+//
+// A client logs in with a credential from a Provider (for example a Google
+// access token). The provider verifies it and returns the Identity behind it,
+// which is linked to a player profile the first time it is seen. The client
+// then sends the JWT issued for that player in the "authorization" metadata
+// header of every call, and the interceptors put the player into the request
+// context.
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidCredential = errors.New("invalid credential")
+	ErrInvalidToken      = errors.New("invalid or expired token")
+	ErrIdentityNotFound  = errors.New("identity is not linked to a player")
+	ErrIdentityLinked    = errors.New("identity is already linked to a player")
+)
+
+// Identity is a user as an external provider knows them.
+type Identity struct {
+	Provider string
+	Subject  string // the provider's stable ID of the user
+	Name     string // suggested player name, e.g. the user's preferred username
+	Email    string
+}
+
+// Provider verifies credentials issued by an external identity provider.
+type Provider interface {
+	// Name identifies the provider in login requests, e.g. "google".
+	Name() string
+	// Verify returns the identity behind credential, or ErrInvalidCredential.
+	Verify(ctx context.Context, credential string) (Identity, error)
+}
+
+// Identities links external identities to player usernames.
+type Identities interface {
+	// LookupIdentity returns the username linked to the provider's subject,
+	// or ErrIdentityNotFound.
+	LookupIdentity(provider, subject string) (string, error)
+	// LinkIdentity links the provider's subject to username. It fails with
+	// ErrIdentityLinked if the subject is already linked.
+	LinkIdentity(provider, subject, username string) error
+}
+
+type playerKey struct{}
+
+// WithPlayer returns a context carrying the authenticated player's username.
+func WithPlayer(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, playerKey{}, username)
+}
+
+// PlayerFrom returns the authenticated player's username carried by ctx.
+func PlayerFrom(ctx context.Context) (string, bool) {
+	username, ok := ctx.Value(playerKey{}).(string)
+	return username, ok
+}
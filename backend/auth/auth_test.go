@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestHS256(t *testing.T) {
+	if _, err := NewHS256([]byte("short"), time.Hour); err == nil {
+		t.Errorf("NewHS256 accepted a 5-byte secret")
+	}
+	i, err := NewHS256(testSecret, time.Hour)
+	if err != nil {
+		t.Fatalf("NewHS256: %v", err)
+	}
+	token, expires, err := i.Issue("alice")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if d := time.Until(expires); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("token expires in %v, want an hour", d)
+	}
+	if name, err := i.Validate(token); err != nil || name != "alice" {
+		t.Errorf("Validate = %q, %v", name, err)
+	}
+
+	other, _ := NewHS256([]byte("another secret of thirty-two byt"), time.Hour)
+	if _, err := other.Validate(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token validated with another secret: %v", err)
+	}
+	if _, err := i.Validate(token[:len(token)-2] + "xx"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("tampered token validated: %v", err)
+	}
+
+	// Tokens expire.
+	i.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := i.Validate(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired token validated: %v", err)
+	}
+}
+
+func TestRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	i := NewRS256(key, time.Hour)
+	token, _, err := i.Issue("bob")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if name, err := i.Validate(token); err != nil || name != "bob" {
+		t.Errorf("Validate = %q, %v", name, err)
+	}
+
+	// An HS256 token signed with the public key must not pass as RS256.
+	forged, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer: TokenIssuer, Subject: "mallory", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString(key.PublicKey.N.Bytes())
+	if _, err := i.Validate(forged); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("HS256 token accepted by an RS256 issuer: %v", err)
+	}
+	unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+		Issuer: TokenIssuer, Subject: "mallory", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if _, err := i.Validate(unsigned); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("unsigned token accepted: %v", err)
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	i, _ := NewHS256(testSecret, time.Hour)
+	token, _, _ := i.Issue("alice")
+	intercept := UnaryInterceptor(i)
+	handler := func(ctx context.Context, _ any) (any, error) {
+		name, _ := PlayerFrom(ctx)
+		return name, nil
+	}
+	call := func(md metadata.MD) (any, error) {
+		return intercept(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{}, handler)
+	}
+
+	if name, err := call(metadata.Pairs(MetadataKey, "Bearer "+token)); err != nil || name != "alice" {
+		t.Errorf("call with a valid token = %v, %v", name, err)
+	}
+	if name, err := call(metadata.MD{}); err != nil || name != "" {
+		t.Errorf("call without a token = %v, %v", name, err)
+	}
+	for _, value := range []string{"Bearer nonsense", token} {
+		if _, err := call(metadata.Pairs(MetadataKey, value)); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("call with authorization %q: %v, want Unauthenticated", value, err)
+		}
+	}
+}
+
+func TestUserInfoProvider(t *testing.T) {
+	// Tokens are named after the client they were issued to.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tokeninfo", func(w http.ResponseWriter, r *http.Request) {
+		switch token := r.PostFormValue("access_token"); token {
+		case "ours", "theirs":
+			fmt.Fprintf(w, `{"sub":"1234","aud":"%s-client","azp":"%s-client","scope":"openid email"}`, token, token)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ours" && r.Header.Get("Authorization") != "Bearer theirs" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"sub":"1234","name":"Alice Liddell","email":"alice@example.com"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	providers, err := ParseProviders("google:web.apps.googleusercontent.com, acme:ours-client=" + srv.URL + "/userinfo|" + srv.URL + "/tokeninfo")
+	if err != nil || len(providers) != 2 {
+		t.Fatalf("ParseProviders = %v, %v", providers, err)
+	}
+	if g := providers[0].(*UserInfoProvider); g.Name() != "google" || g.ClientID != "web.apps.googleusercontent.com" || g.URL != GoogleUserInfoURL || g.TokenInfoURL != GoogleTokenInfoURL {
+		t.Errorf("google provider = %+v", g)
+	}
+	acme := providers[1]
+	id, err := acme.Verify(context.Background(), "ours")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if id != (Identity{Provider: "acme", Subject: "1234", Name: "alice", Email: "alice@example.com"}) {
+		t.Errorf("identity = %+v", id)
+	}
+	if _, err := acme.Verify(context.Background(), "theirs"); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("Verify of a token issued to another client: %v, want ErrInvalidCredential", err)
+	}
+	if _, err := acme.Verify(context.Background(), "bad"); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("Verify of a rejected token: %v, want ErrInvalidCredential", err)
+	}
+
+	for _, spec := range []string{"github:id", "google", "acme:id=", "acme:id=" + srv.URL, "acme=" + srv.URL + "|" + srv.URL, "google:a,google:b"} {
+		if _, err := ParseProviders(spec); err == nil || !strings.Contains(err.Error(), "provider") {
+			t.Errorf("ParseProviders(%q) error = %v", spec, err)
+		}
+	}
+}
+
+func TestFakeProvider(t *testing.T) {
+	p := NewFakeProvider("dev")
+	cred := p.Issue("42", "carol")
+	if id, err := p.Verify(context.Background(), cred); err != nil || id.Subject != "42" || id.Name != "carol" || id.Provider != "dev" {
+		t.Errorf("Verify = %+v, %v", id, err)
+	}
+	p.Revoke(cred)
+	if _, err := p.Verify(context.Background(), cred); err != ErrInvalidCredential {
+		t.Errorf("Verify of a revoked credential: %v", err)
+	}
+}
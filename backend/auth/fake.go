@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeProvider is a local identity provider for tests and development. Each
+// credential it has issued maps to an identity.
+type FakeProvider struct {
+	ProviderName string
+
+	mu          sync.Mutex
+	credentials map[string]Identity
+}
+
+// NewFakeProvider returns an empty FakeProvider called name.
+func NewFakeProvider(name string) *FakeProvider {
+	return &FakeProvider{ProviderName: name, credentials: make(map[string]Identity)}
+}
+
+func (p *FakeProvider) Name() string {
+	return p.ProviderName
+}
+
+// Issue returns a credential for the user with the given subject and
+// suggested player name.
+func (p *FakeProvider) Issue(subject, name string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	credential := p.ProviderName + "-credential-" + subject
+	p.credentials[credential] = Identity{Provider: p.ProviderName, Subject: subject, Name: name}
+	return credential
+}
+
+// Revoke invalidates a credential returned by Issue.
+func (p *FakeProvider) Revoke(credential string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.credentials, credential)
+}
+
+func (p *FakeProvider) Verify(_ context.Context, credential string) (Identity, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	id, ok := p.credentials[credential]
+	if !ok {
+		return Identity{}, ErrInvalidCredential
+	}
+	return id, nil
+}
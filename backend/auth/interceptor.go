@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey is the gRPC metadata header that carries "Bearer <token>".
+const MetadataKey = "authorization"
+
+// authenticate validates the bearer token of an incoming call, if it has one,
+// and returns ctx carrying its player. Calls without a token pass through
+// unauthenticated; the handlers that need a player reject them.
+func authenticate(ctx context.Context, i *Issuer) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "%s metadata must be \"Bearer <token>\"", MetadataKey)
+	}
+	username, err := i.Validate(strings.TrimSpace(token))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return WithPlayer(ctx, username), nil
+}
+
+// UnaryInterceptor authenticates unary calls with tokens from i.
+func UnaryInterceptor(i *Issuer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates streaming calls with tokens from i.
+func StreamInterceptor(i *Issuer) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), i)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authedStream is a ServerStream whose context carries the player.
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authedStream) Context() context.Context {
+	return s.ctx
+}
+
+// BearerToken returns the outgoing metadata a client sends token in.
+func BearerToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, "Bearer "+token)
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Google's OpenID Connect userinfo endpoint, and its tokeninfo endpoint, which
+// reports the client an access token was issued to.
+const (
+	GoogleUserInfoURL  = "https://openidconnect.googleapis.com/v1/userinfo"
+	GoogleTokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+)
+
+// UserInfoProvider verifies OAuth 2.0 access tokens by fetching the user they
+// were issued to from the provider's OpenID Connect userinfo endpoint. The
+// token must first pass the tokeninfo endpoint, which has to report ClientID
+// as its audience (aud) or authorized party (azp): a token the user granted
+// another application is not accepted.
+type UserInfoProvider struct {
+	ProviderName string
+	ClientID     string // OAuth client ID of this server
+	URL          string
+	TokenInfoURL string       // answers like Google's tokeninfo
+	Client       *http.Client // http.DefaultClient with a 10s timeout if nil
+}
+
+func (p *UserInfoProvider) Name() string {
+	return p.ProviderName
+}
+
+// Verify checks the access token's audience with the tokeninfo endpoint, then
+// asks the userinfo endpoint who it belongs to. A token either endpoint
+// rejects, or that was issued to another client, is an invalid credential;
+// any other failure is returned as is.
+func (p *UserInfoProvider) Verify(ctx context.Context, accessToken string) (Identity, error) {
+	if accessToken == "" {
+		return Identity{}, ErrInvalidCredential
+	}
+
+	var token struct {
+		Subject         string `json:"sub"`
+		Audience        string `json:"aud"`
+		AuthorizedParty string `json:"azp"`
+	}
+	form := url.Values{"access_token": {accessToken}}
+	if err := p.fetch(ctx, "tokeninfo", p.TokenInfoURL, form, "", &token); err != nil {
+		return Identity{}, err
+	}
+	if p.ClientID == "" || (token.Audience != p.ClientID && token.AuthorizedParty != p.ClientID) {
+		return Identity{}, ErrInvalidCredential
+	}
+
+	var info struct {
+		Subject           string `json:"sub"`
+		PreferredUsername string `json:"preferred_username"`
+		Name              string `json:"name"`
+		Email             string `json:"email"`
+	}
+	if err := p.fetch(ctx, "userinfo", p.URL, nil, accessToken, &info); err != nil {
+		return Identity{}, err
+	}
+	if info.Subject == "" {
+		return Identity{}, fmt.Errorf("%s userinfo: response has no subject", p.ProviderName)
+	}
+	if token.Subject != "" && token.Subject != info.Subject {
+		return Identity{}, fmt.Errorf("%s: tokeninfo and userinfo disagree on the subject", p.ProviderName)
+	}
+	id := Identity{Provider: p.ProviderName, Subject: info.Subject, Name: info.PreferredUsername, Email: info.Email}
+	if id.Name == "" {
+		id.Name, _, _ = strings.Cut(info.Email, "@")
+	}
+	return id, nil
+}
+
+// fetch decodes the JSON answer of one of the provider's endpoints into out.
+// It posts form if set, or sends a GET with accessToken as the bearer token.
+// A 400, 401 or 403 answer makes the token an invalid credential.
+func (p *UserInfoProvider) fetch(ctx context.Context, endpoint, target string, form url.Values, accessToken string, out any) error {
+	var req *http.Request
+	var err error
+	if form != nil {
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(form.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err == nil {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", p.ProviderName, endpoint, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return ErrInvalidCredential
+	default:
+		return fmt.Errorf("%s %s: %s", p.ProviderName, endpoint, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: %w", p.ProviderName, endpoint, err)
+	}
+	return nil
+}
+
+// ParseProviders parses a comma separated list of providers, each with the
+// OAuth client ID of this server: "google:<client ID>", or
+// "<name>:<client ID>=<userinfo URL>|<tokeninfo URL>" for any other OpenID
+// Connect provider with a tokeninfo endpoint that answers like Google's.
+func ParseProviders(spec string) ([]Provider, error) {
+	var out []Provider
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		head, urls, custom := strings.Cut(item, "=")
+		name, clientID, _ := strings.Cut(head, ":")
+		p := &UserInfoProvider{ProviderName: strings.TrimSpace(name), ClientID: strings.TrimSpace(clientID)}
+		if custom {
+			userInfo, tokenInfo, _ := strings.Cut(urls, "|")
+			p.URL, p.TokenInfoURL = strings.TrimSpace(userInfo), strings.TrimSpace(tokenInfo)
+		} else if p.ProviderName == "google" {
+			p.URL, p.TokenInfoURL = GoogleUserInfoURL, GoogleTokenInfoURL
+		}
+		if p.ProviderName == "" || p.ClientID == "" || p.URL == "" || p.TokenInfoURL == "" {
+			return nil, fmt.Errorf("invalid provider %q: use google:<client ID> or <name>:<client ID>=<userinfo URL>|<tokeninfo URL>", item)
+		}
+		if seen[p.ProviderName] {
+			return nil, fmt.Errorf("provider %q listed twice", p.ProviderName)
+		}
+		seen[p.ProviderName] = true
+		out = append(out, p)
+	}
+	return out, nil
+}
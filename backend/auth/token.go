@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenIssuer is the "iss" claim of the tokens the server issues.
+const TokenIssuer = "es-baccarat"
+
+// minSecretLen is the shortest HS256 secret accepted, 256 bits.
+const minSecretLen = 32
+
+// Issuer issues and validates the server's session tokens. Its subject is the
+// player's username.
+type Issuer struct {
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewHS256 returns an Issuer signing tokens valid for ttl with a shared secret
+// of at least 32 bytes.
+func NewHS256(secret []byte, ttl time.Duration) (*Issuer, error) {
+	if len(secret) < minSecretLen {
+		return nil, fmt.Errorf("HS256 secret must be at least %d bytes, got %d", minSecretLen, len(secret))
+	}
+	return &Issuer{method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret, ttl: ttl, now: time.Now}, nil
+}
+
+// NewRS256 returns an Issuer signing tokens valid for ttl with an RSA key.
+// Other services can validate them with the public half.
+func NewRS256(key *rsa.PrivateKey, ttl time.Duration) *Issuer {
+	return &Issuer{method: jwt.SigningMethodRS256, signKey: key, verifyKey: &key.PublicKey, ttl: ttl, now: time.Now}
+}
+
+// LoadRS256 returns an RS256 Issuer with the PEM-encoded private key in path.
+func LoadRS256(path string, ttl time.Duration) (*Issuer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return NewRS256(key, ttl), nil
+}
+
+// Issue returns a signed token for username and when it expires.
+func (i *Issuer) Issue(username string) (string, time.Time, error) {
+	now := i.now()
+	expires := now.Add(i.ttl)
+	claims := jwt.RegisteredClaims{
+		Issuer:    TokenIssuer,
+		Subject:   username,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expires),
+	}
+	token, err := jwt.NewWithClaims(i.method, claims).SignedString(i.signKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expires, nil
+}
+
+// Validate checks the token's signature, issuer and expiry, and returns the
+// username it was issued for. Tokens signed with any other algorithm are
+// rejected. Every failure wraps ErrInvalidToken.
+func (i *Issuer) Validate(token string) (string, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) { return i.verifyKey, nil },
+		jwt.WithValidMethods([]string{i.method.Alg()}),
+		jwt.WithIssuer(TokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(i.now),
+	)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+)
+
+func TestValidate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Fatalf("default config: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		edit  func(*GameConfig)
+		valid bool
+	}{
+		{"one deck", func(c *GameConfig) { c.DecksCount = 1 }, true},
+		{"no decks", func(c *GameConfig) { c.DecksCount = 0 }, false},
+		{"nine decks", func(c *GameConfig) { c.DecksCount = 9 }, false},
+		{"cut card range", func(c *GameConfig) { c.CutCardThreshold, c.CutCardMax = 52, 78 }, true},
+		{"reversed range", func(c *GameConfig) { c.CutCardThreshold, c.CutCardMax = 78, 52 }, false},
+		{"negative cut card", func(c *GameConfig) { c.CutCardThreshold = -1 }, false},
+		{"fixed burn", func(c *GameConfig) { c.Burn = model.BurnRule{Mode: model.BurnFixed, Count: 5} }, true},
+		{"negative burn", func(c *GameConfig) { c.Burn = model.BurnRule{Mode: model.BurnFixed, Count: -5} }, false},
+		{"unknown burn", func(c *GameConfig) { c.Burn.Mode = "half" }, false},
+		// 52 cards - 11 burned - 35 behind the cut card leaves exactly one hand.
+		{"one hand left", func(c *GameConfig) { c.DecksCount, c.CutCardThreshold = 1, 35 }, true},
+		{"no hand left", func(c *GameConfig) { c.DecksCount, c.CutCardMax = 1, 36 }, false},
+		{"no burn, deeper cut", func(c *GameConfig) { c.DecksCount, c.CutCardThreshold, c.Burn.Mode = 1, 46, model.BurnNone }, true},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		tt.edit(cfg)
+		err := cfg.Validate()
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() = %v, want valid %v", tt.name, err, tt.valid)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidShoe) {
+			t.Errorf("%s: error %v does not wrap ErrInvalidShoe", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/niubaoshu/es-Baccarat/backend/analysis"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// AuditIssue is a logged round that disagrees with the engine or with the
+// player's balance history.
+type AuditIssue struct {
+	Round   int // 1-based position in the log
+	Player  string
+	Message string
+}
+
+func (i AuditIssue) String() string {
+	return fmt.Sprintf("round %d (%s): %s", i.Round, i.Player, i.Message)
+}
+
+// PlayerSummary totals the logged rounds of one player.
+type PlayerSummary struct {
+	Player   string
+	Hands    int
+	Wager    int
+	Net      int
+	Outcomes map[rules.Outcome]int
+}
+
+// AuditReport is the result of auditing a round log.
+type AuditReport struct {
+	Rules   rules.RuleSet
+	Rounds  int
+	Issues  []AuditIssue
+	Players []*PlayerSummary // sorted by name
+}
+
+// AuditRounds re-scores every logged round under rs and checks it against the
+// log: the cards are dealt again from a shoe stacked in their dealing order,
+// so the drawing rules, points, outcome and net change must all match. It also
+// checks that each round's balances add up and that every player's round
+// starts from the balance their previous round ended with.
+func AuditRounds(rounds []RoundLog, rs rules.RuleSet) *AuditReport {
+	a := &AuditReport{Rules: rs, Rounds: len(rounds)}
+	players := make(map[string]*PlayerSummary)
+	lastBalance := make(map[string]int)
+
+	for i, logged := range rounds {
+		issue := func(format string, args ...any) {
+			a.Issues = append(a.Issues, AuditIssue{Round: i + 1, Player: logged.Player, Message: fmt.Sprintf(format, args...)})
+		}
+
+		p := players[logged.Player]
+		if p == nil {
+			p = &PlayerSummary{Player: logged.Player, Outcomes: make(map[rules.Outcome]int)}
+			players[logged.Player] = p
+		}
+		p.Hands++
+		p.Net += logged.NetChange
+		p.Outcomes[rules.Outcome(logged.Outcome)]++
+
+		if prev, ok := lastBalance[logged.Player]; ok && prev != logged.InitialBalance {
+			issue("balance discontinuity: previous round ended at $%d, this one starts at $%d", prev, logged.InitialBalance)
+		}
+		lastBalance[logged.Player] = logged.FinalBalance
+		if logged.InitialBalance+logged.NetChange != logged.FinalBalance {
+			issue("balance $%d%+d does not equal the final balance $%d", logged.InitialBalance, logged.NetChange, logged.FinalBalance)
+		}
+
+		if logged.SettleError != "" {
+			// The hand was dealt but the bets were not settled: its cards are
+			// checked, its net change is not.
+			issue("bets not settled: %s", logged.SettleError)
+			if msg := rescore(logged, rs, nil); msg != "" {
+				issue("%s", msg)
+			}
+			continue
+		}
+
+		bets := make(map[rules.BetType]int, len(logged.Bets))
+		for name, amt := range logged.Bets {
+			bt, err := rules.ParseBetType(name)
+			if err != nil {
+				issue("%v: %s", err, name)
+				continue
+			}
+			bets[bt] = amt
+			p.Wager += amt
+		}
+
+		if msg := rescore(logged, rs, bets); msg != "" {
+			issue("%s", msg)
+		}
+	}
+
+	for _, p := range players {
+		a.Players = append(a.Players, p)
+	}
+	sort.Slice(a.Players, func(i, j int) bool { return a.Players[i].Player < a.Players[j].Player })
+	return a
+}
+
+// rescore deals a logged round again and describes the first difference from
+// the log, or returns "" if there is none.
+func rescore(logged RoundLog, rs rules.RuleSet, bets map[rules.BetType]int) string {
+	player, err := parseHand(logged.PlayerHand)
+	if err != nil {
+		return fmt.Sprintf("player hand: %v", err)
+	}
+	banker, err := parseHand(logged.BankerHand)
+	if err != nil {
+		return fmt.Sprintf("banker hand: %v", err)
+	}
+
+	// Dealing order: Player, Banker, Player, Banker, then the third cards.
+	stacked := []model.Card{player[0], banker[0], player[1], banker[1]}
+	stacked = append(append(stacked, player[2:]...), banker[2:]...)
+	r, err := ResolveRound(model.NewStackedShoe(stacked), rs, bets)
+	if errors.Is(err, model.ErrShoeEmpty) {
+		return "the drawing rules call for a third card that was not logged"
+	} else if err != nil {
+		return fmt.Sprintf("dealing the logged cards: %v", err)
+	}
+
+	switch {
+	case !slices.Equal(r.PlayerHand.Cards, player) || !slices.Equal(r.BankerHand.Cards, banker):
+		return fmt.Sprintf("drawing rules deal Player %v / Banker %v, logged Player %v / Banker %v",
+			CardStrings(r.PlayerHand), CardStrings(r.BankerHand), logged.PlayerHand, logged.BankerHand)
+	case r.PlayerHand.TotalPoints() != logged.PlayerPoints || r.BankerHand.TotalPoints() != logged.BankerPoints:
+		return fmt.Sprintf("points %d-%d, logged %d-%d", r.PlayerHand.TotalPoints(), r.BankerHand.TotalPoints(), logged.PlayerPoints, logged.BankerPoints)
+	case string(r.Outcome) != logged.Outcome:
+		return fmt.Sprintf("outcome %s, logged %s", r.Outcome, logged.Outcome)
+	case len(bets) == len(logged.Bets) && r.NetChange() != logged.NetChange:
+		return fmt.Sprintf("net change $%d under %s rules, logged $%d", r.NetChange(), rs.Name(), logged.NetChange)
+	}
+	return ""
+}
+
+// parseHand parses a logged hand of two or three cards.
+func parseHand(cards []string) ([]model.Card, error) {
+	if len(cards) < 2 || len(cards) > 3 {
+		return nil, fmt.Errorf("%d cards", len(cards))
+	}
+	hand := make([]model.Card, len(cards))
+	for i, s := range cards {
+		c, err := model.ParseCard(s)
+		if err != nil {
+			return nil, err
+		}
+		hand[i] = c
+	}
+	return hand, nil
+}
+
+// PrintAuditReport writes the issues found and a summary per player, comparing
+// each player's outcome frequencies with the exact probabilities for a shoe of
+// decksCount decks.
+func PrintAuditReport(w io.Writer, a *AuditReport, decksCount int) {
+	fmt.Fprintf(w, "\n=== Round Log Audit (%s rules) ===\n", a.Rules.Name())
+	fmt.Fprintf(w, "Rounds:  %d\n", a.Rounds)
+	fmt.Fprintf(w, "Players: %d\n", len(a.Players))
+	fmt.Fprintf(w, "Issues:  %d\n", len(a.Issues))
+	for _, issue := range a.Issues {
+		fmt.Fprintf(w, "  [FAIL] %s\n", issue)
+	}
+
+	exact := analysis.Analyze(analysis.NewComposition(decksCount), a.Rules)
+	outcomes := []rules.Outcome{rules.OutcomePlayer, rules.OutcomePanda8, rules.OutcomeBanker, rules.OutcomeDragon7, rules.OutcomeTie}
+	for _, p := range a.Players {
+		fmt.Fprintf(w, "\n--- %s ---\n", p.Player)
+		fmt.Fprintf(w, "Hands: %d  Wager: $%d  Net: $%d", p.Hands, p.Wager, p.Net)
+		if p.Wager > 0 {
+			fmt.Fprintf(w, " (%+.2f%% of wager)", float64(p.Net)/float64(p.Wager)*100)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%-10s | %8s | %10s | %10s\n", "Outcome", "Count", "Observed %", "Expected %")
+		for _, o := range outcomes {
+			fmt.Fprintf(w, "%-10s | %8d | %9.2f%% | %9.2f%%\n",
+				o, p.Outcomes[o], float64(p.Outcomes[o])/float64(p.Hands)*100, exact.Probability(o)*100)
+		}
+	}
+	fmt.Fprintln(w)
+}
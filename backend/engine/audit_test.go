@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// loggedRound deals a hand from the given cards and logs it for player.
+func loggedRound(t *testing.T, player string, balance int, cards string, bets map[rules.BetType]int) RoundLog {
+	t.Helper()
+	stacked, err := model.ParseCards(cards)
+	if err != nil {
+		t.Fatalf("ParseCards: %v", err)
+	}
+	r, err := ResolveRound(model.NewStackedShoe(stacked), rules.EZ, bets)
+	if err != nil {
+		t.Fatalf("ResolveRound: %v", err)
+	}
+	return NewRoundLog(player, balance, balance+r.NetChange(), bets, r)
+}
+
+func TestAuditRoundsClean(t *testing.T) {
+	alice1 := loggedRound(t, "alice", 1000, "10S 2H 6D KC 5H", map[rules.BetType]int{rules.Banker: 100, rules.Dragon: 10}) // Dragon 7
+	bob := loggedRound(t, "bob", 500, "9S 2H KD 3C", map[rules.BetType]int{rules.Player: 50})                              // natural 9
+	alice2 := loggedRound(t, "alice", alice1.FinalBalance, "AS KH 4D 6C 3H", map[rules.BetType]int{rules.Panda: 10})       // Panda 8
+
+	a := AuditRounds([]RoundLog{alice1, bob, alice2}, rules.EZ)
+	if len(a.Issues) != 0 {
+		t.Fatalf("unexpected issues: %v", a.Issues)
+	}
+	if a.Rounds != 3 || len(a.Players) != 2 || a.Players[0].Player != "alice" {
+		t.Fatalf("unexpected report: %+v", a)
+	}
+	alice := a.Players[0]
+	if alice.Hands != 2 || alice.Wager != 120 || alice.Net != 400+250 {
+		t.Errorf("alice: %+v", alice)
+	}
+	if alice.Outcomes[rules.OutcomeDragon7] != 1 || alice.Outcomes[rules.OutcomePanda8] != 1 {
+		t.Errorf("alice outcomes: %v", alice.Outcomes)
+	}
+
+	var buf bytes.Buffer
+	PrintAuditReport(&buf, a, 8)
+	for _, want := range []string{"Issues:  0", "--- alice ---", "Net: $650", "Dragon 7"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestAuditRoundsIssues(t *testing.T) {
+	bets := map[rules.BetType]int{rules.Player: 100}
+	tests := []struct {
+		name   string
+		tamper func(r *RoundLog)
+		want   string
+	}{
+		{"points", func(r *RoundLog) { r.PlayerPoints = 8 }, "points"},
+		{"outcome", func(r *RoundLog) { r.Outcome = string(rules.OutcomeBanker) }, "outcome"},
+		{"net change", func(r *RoundLog) { r.NetChange, r.FinalBalance = 200, r.InitialBalance+200 }, "net change"},
+		{"final balance", func(r *RoundLog) { r.FinalBalance++ }, "does not equal"},
+		{"extra third card", func(r *RoundLog) { r.PlayerHand = append(r.PlayerHand, "5H") }, "drawing rules"},
+		{"missing third card", func(r *RoundLog) { r.BankerHand = r.BankerHand[:2] }, "third card"},
+		{"bad card", func(r *RoundLog) { r.PlayerHand[0] = "1S" }, "invalid card"},
+		{"bad bet", func(r *RoundLog) { r.Bets["Lucky 6"] = 5 }, "unknown bet type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Player stands on 7 and beats Banker 5 after the Banker draws.
+			r := loggedRound(t, "alice", 1000, "7S 2H KD 3C 10H", bets)
+			tt.tamper(&r)
+			a := AuditRounds([]RoundLog{r}, rules.EZ)
+			if len(a.Issues) == 0 || !strings.Contains(a.Issues[0].Message, tt.want) {
+				t.Errorf("issues = %v, want one mentioning %q", a.Issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditRoundsBalanceDiscontinuity(t *testing.T) {
+	bets := map[rules.BetType]int{rules.Banker: 100}
+	first := loggedRound(t, "alice", 1000, "10S 2H 6D KC 5H", bets)
+	other := loggedRound(t, "bob", 50, "10S 2H 6D KC 5H", bets)
+	second := loggedRound(t, "alice", first.FinalBalance+500, "10S 2H 6D KC 5H", bets)
+
+	a := AuditRounds([]RoundLog{first, other, second}, rules.EZ)
+	if len(a.Issues) != 1 || a.Issues[0].Round != 3 || !strings.Contains(a.Issues[0].Message, "discontinuity") {
+		t.Errorf("issues = %v, want a discontinuity in round 3", a.Issues)
+	}
+}
@@ -10,12 +10,18 @@ import (
 	"github.com/niubaoshu/es-Baccarat/backend/rules"
 )
 
-// PromptBets asks the user to enter their bets via the terminal.
-func PromptBets() map[rules.BetType]int {
+// PromptBets asks the user to enter their bets via the terminal until they are
+// valid under limits for a player with the given balance.
+func PromptBets(rs rules.RuleSet, limits rules.TableLimits, balance int) map[rules.BetType]int {
 	scanner := bufio.NewScanner(os.Stdin)
 
+	available := make([]string, 0, len(rs.BetTypes()))
+	for _, b := range rs.BetTypes() {
+		available = append(available, fmt.Sprintf("%s (%s)", b.Code(), b))
+	}
+
 	fmt.Println("Enter your bets for this round.")
-	fmt.Println("Available types: P (Player), B (Banker), T (Tie), D (Dragon 7), 8 (Panda 8).")
+	fmt.Printf("Available types: %s.\n", strings.Join(available, ", "))
 	fmt.Println("Format: <Type>:<Amount> separate multiple by comma. (e.g. P:100,D:10)")
 	fmt.Println("Leave empty to stop playing (Quit).")
 
@@ -48,30 +54,19 @@ func PromptBets() map[rules.BetType]int {
 				break
 			}
 
-			bTypeStr := strings.ToUpper(strings.TrimSpace(kv[0]))
+			bTypeStr := strings.TrimSpace(kv[0])
 			amtStr := strings.TrimSpace(kv[1])
 
 			amt, err := strconv.Atoi(amtStr)
-			if err != nil || amt <= 0 {
+			if err != nil {
 				fmt.Printf("Invalid amount: %s\n", amtStr)
 				valid = false
 				break
 			}
 
-			var bType rules.BetType
-			switch bTypeStr {
-			case "P", "PLAYER":
-				bType = rules.Player
-			case "B", "BANKER":
-				bType = rules.Banker
-			case "T", "TIE":
-				bType = rules.Tie
-			case "D", "DRAGON", "DRAGON7", "DRAGON 7":
-				bType = rules.Dragon
-			case "8", "PANDA", "PANDA8", "PANDA 8":
-				bType = rules.Panda
-			default:
-				fmt.Printf("Unknown bet type: %s\n", bTypeStr)
+			bType, err := rules.ParseBetType(bTypeStr)
+			if err != nil {
+				fmt.Printf("Unknown bet type: %s\n", strings.ToUpper(bTypeStr))
 				valid = false
 			}
 
@@ -81,16 +76,10 @@ func PromptBets() map[rules.BetType]int {
 		}
 
 		if valid && len(parsedBets) > 0 {
-			// Rule validation: Panda and Dragon requires Player or Banker bet.
-			// Same for Tie if strict, but the new document says Tie can be placed independently.
-			hasBase := parsedBets[rules.Player] > 0 || parsedBets[rules.Banker] > 0
-			hasSpecial := parsedBets[rules.Dragon] > 0 || parsedBets[rules.Panda] > 0
-
-			if hasSpecial && !hasBase {
-				fmt.Println("Rule Error: Dragon 7 and Panda 8 bets require an active Player or Banker base bet.")
+			if err := limits.Validate(rs, parsedBets, balance); err != nil {
+				fmt.Printf("Rule Error: %v.\n", err)
 				continue
 			}
-
 			return parsedBets
 		}
 	}
@@ -0,0 +1,250 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/analysis"
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/strategy"
+)
+
+// CountStats holds the results of a counting simulation. Nets are in units of
+// SimulationBetUnit cents.
+type CountStats struct {
+	System      strategy.CountSystem
+	TotalRounds int
+	Shoes       int
+	// BaseNet is the net result of betting the side bet on every hand.
+	BaseNet int
+	// HandsBet, Net and NetSq cover only the hands the count called for:
+	// the number of bets, their total net and the sum of their squared nets.
+	HandsBet int
+	Net      int
+	NetSq    float64
+	// ShoeNetSq is the sum over shoes of the squared net of the counted bets in
+	// each shoe.
+	ShoeNetSq float64
+	Duration  time.Duration
+}
+
+// RunCountSimulation deals totalRounds hands and bets the count system's side
+// bet only when the true count reaches its trigger. The count starts from the
+// cards left after the burn, so burnt cards stay unseen. Bets are settled
+// directly, without table limits.
+func RunCountSimulation(cfg *config.GameConfig, cs strategy.CountSystem, totalRounds int, numWorkers int) *CountStats {
+	start := time.Now()
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+	if totalRounds < numWorkers {
+		numWorkers = max(totalRounds, 1)
+	}
+
+	var wg sync.WaitGroup
+	resultsCh := make(chan CountStats, numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		rounds := totalRounds / numWorkers
+		if w == 0 {
+			rounds += totalRounds % numWorkers // First worker takes the remainder
+		}
+
+		wg.Add(1)
+		go func(worker, rounds int) {
+			defer wg.Done()
+			rng := newSimulationRandomizer(cfg, worker)
+			var local CountStats
+			var shoe *model.Shoe
+			var seenFrom [model.King + 1]int
+			shoeNet := 0
+			endShoe := func() {
+				local.ShoeNetSq += float64(shoeNet) * float64(shoeNet)
+				shoeNet = 0
+			}
+
+			for local.TotalRounds < rounds {
+				if shoe == nil || shoe.IsPastCutCard() {
+					if shoe != nil {
+						endShoe()
+					}
+					shoe = newSimulationShoe(cfg, rng)
+					seenFrom = shoe.Remaining()
+					local.Shoes++
+				}
+
+				running := cs.RunningCount(seenFrom, shoe.Remaining())
+				bet := cs.ShouldBet(strategy.TrueCount(running, shoe.CardsLeft()))
+
+				result, err := ResolveRound(shoe, cfg.Rules, nil)
+				if err != nil {
+					// The shoe ran out mid-hand: the hand is void and is dealt
+					// again from a new shoe.
+					endShoe()
+					shoe = nil
+					continue
+				}
+				local.TotalRounds++
+				net := cfg.Rules.Payout(result.PlayerHand, result.BankerHand, cs.Bet, SimulationBetUnit).NetChange(SimulationBetUnit)
+				local.BaseNet += net
+				if bet {
+					local.HandsBet++
+					local.Net += net
+					local.NetSq += float64(net) * float64(net)
+					shoeNet += net
+				}
+			}
+			if shoe != nil {
+				endShoe()
+			}
+			resultsCh <- local
+		}(w, rounds)
+	}
+	wg.Wait()
+	close(resultsCh)
+
+	s := &CountStats{System: cs}
+	for r := range resultsCh {
+		s.TotalRounds += r.TotalRounds
+		s.Shoes += r.Shoes
+		s.BaseNet += r.BaseNet
+		s.HandsBet += r.HandsBet
+		s.Net += r.Net
+		s.NetSq += r.NetSq
+		s.ShoeNetSq += r.ShoeNetSq
+	}
+	s.Duration = time.Since(start)
+	return s
+}
+
+// BaseEV returns the return per unit of betting the side bet on every hand.
+func (s *CountStats) BaseEV() float64 {
+	if s.TotalRounds == 0 {
+		return 0
+	}
+	return float64(s.BaseNet) / SimulationBetUnit / float64(s.TotalRounds)
+}
+
+// EV returns the return per unit of the hands the count bet on.
+func (s *CountStats) EV() float64 {
+	if s.HandsBet == 0 {
+		return 0
+	}
+	return float64(s.Net) / SimulationBetUnit / float64(s.HandsBet)
+}
+
+// Advantage returns the improvement in return per unit wagered over betting
+// every hand.
+func (s *CountStats) Advantage() float64 {
+	return s.EV() - s.BaseEV()
+}
+
+// HandsPerShoe returns the average number of hands bet per shoe.
+func (s *CountStats) HandsPerShoe() float64 {
+	if s.Shoes == 0 {
+		return 0
+	}
+	return float64(s.HandsBet) / float64(s.Shoes)
+}
+
+// WinPerShoe returns the average result, in units, of the counted bets over a
+// shoe.
+func (s *CountStats) WinPerShoe() float64 {
+	if s.Shoes == 0 {
+		return 0
+	}
+	return float64(s.Net) / SimulationBetUnit / float64(s.Shoes)
+}
+
+// Variance returns the variance, in units squared, of a counted bet.
+func (s *CountStats) Variance() float64 {
+	if s.HandsBet == 0 {
+		return 0
+	}
+	mean := s.EV()
+	return s.NetSq/SimulationBetUnit/SimulationBetUnit/float64(s.HandsBet) - mean*mean
+}
+
+// ShoeStdDev returns the standard deviation, in units, of the counting
+// strategy's result over a shoe.
+func (s *CountStats) ShoeStdDev() float64 {
+	if s.Shoes == 0 {
+		return 0
+	}
+	mean := s.WinPerShoe()
+	return math.Sqrt(max(s.ShoeNetSq/SimulationBetUnit/SimulationBetUnit/float64(s.Shoes)-mean*mean, 0))
+}
+
+// PrintCountReport writes the results of a counting simulation.
+func (s *CountStats) PrintCountReport(w io.Writer) {
+	cs := s.System
+	fmt.Fprintf(w, "\n=== Count Simulation: %s on %s ===\n", cs.Name, cs.Bet)
+	fmt.Fprintf(w, "Tags:         %s\n", formatTags(cs.Tags))
+	fmt.Fprintf(w, "Trigger:      true count >= %g\n", cs.Trigger)
+	fmt.Fprintf(w, "Rounds:       %d in %d shoes (%s)\n\n", s.TotalRounds, s.Shoes, s.Duration)
+
+	fmt.Fprintf(w, "Every hand EV:      %+9.4f%%\n", s.BaseEV()*100)
+	fmt.Fprintf(w, "Counted EV:         %+9.4f%% per hand bet\n", s.EV()*100)
+	fmt.Fprintf(w, "Advantage gained:   %+9.4f%%\n", s.Advantage()*100)
+	share := 0.0
+	if s.TotalRounds > 0 {
+		share = float64(s.HandsBet) / float64(s.TotalRounds)
+	}
+	fmt.Fprintf(w, "Hands bet:          %d (%.2f%% of hands, %.2f per shoe)\n", s.HandsBet, share*100, s.HandsPerShoe())
+	fmt.Fprintf(w, "Win per shoe:       %+.4f units\n", s.WinPerShoe())
+	fmt.Fprintf(w, "Variance:           %.4f per hand bet (SD %.4f), SD per shoe %.4f units\n",
+		s.Variance(), math.Sqrt(s.Variance()), s.ShoeStdDev())
+	fmt.Fprintln(w)
+}
+
+// formatTags lists the non-zero tags of a count system.
+func formatTags(tags [model.King + 1]int) string {
+	out := ""
+	for r := model.Ace; r <= model.King; r++ {
+		if tags[r] == 0 {
+			continue
+		}
+		if out != "" {
+			out += " "
+		}
+		out += fmt.Sprintf("%s:%+d", rankLabel(r), tags[r])
+	}
+	return out
+}
+
+// rankLabel returns the short name of a rank: A, 2-10, J, Q or K.
+func rankLabel(r model.Rank) string {
+	return [...]string{"", "A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}[r]
+}
+
+// PrintRemovalEffects writes the effect of removing one card of each rank on
+// every bet, in percent of the amount wagered.
+func PrintRemovalEffects(w io.Writer, eor *analysis.RemovalEffects) {
+	bets := eor.Base.Rules.BetTypes()
+	fmt.Fprintf(w, "\n=== Effect of Removal (%s, %d cards) ===\n", eor.Base.Rules.Name(), eor.Base.Composition.Total())
+	fmt.Fprintf(w, "%-6s", "Rank")
+	for _, bet := range bets {
+		fmt.Fprintf(w, " | %9s", bet.Code())
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%-6s", "Base")
+	for _, bet := range bets {
+		fmt.Fprintf(w, " | %+8.4f%%", eor.Base.EV(bet)*100)
+	}
+	fmt.Fprintln(w)
+	for r := model.Ace; r <= model.King; r++ {
+		if eor.Effects[r] == nil {
+			continue
+		}
+		fmt.Fprintf(w, "%-6s", rankLabel(r))
+		for _, bet := range bets {
+			fmt.Fprintf(w, " | %+8.4f%%", eor.Effects[r][bet]*100)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "Each row is the change in EV when one card of that rank is removed from the shoe.")
+	fmt.Fprintln(w)
+}
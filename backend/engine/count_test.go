@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/analysis"
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/strategy"
+)
+
+func TestRunCountSimulation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 3
+
+	s := RunCountSimulation(cfg, strategy.Dragon7Count, 200000, 2)
+	if s.TotalRounds != 200000 || s.Shoes == 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+	if s.HandsBet == 0 || s.HandsBet > s.TotalRounds/4 {
+		t.Errorf("the count bet %d of %d hands", s.HandsBet, s.TotalRounds)
+	}
+	if s.Advantage() <= 0.05 {
+		t.Errorf("Dragon 7 count advantage = %.4f, want a clear gain over every hand", s.Advantage())
+	}
+	if v := s.Variance(); v < 30 || v > 50 {
+		t.Errorf("variance per Dragon 7 bet = %.2f, want about 40", v)
+	}
+
+	again := RunCountSimulation(cfg, strategy.Dragon7Count, 200000, 2)
+	if again.Net != s.Net || again.HandsBet != s.HandsBet {
+		t.Errorf("seeded count simulations should be reproducible")
+	}
+
+	var buf bytes.Buffer
+	s.PrintCountReport(&buf)
+	for _, want := range []string{"dragon7 on Dragon 7", "8:+2", "Advantage gained", "per shoe"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestRunCountSimulationNeverTriggered(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 3
+	cs := strategy.Dragon7Count
+	cs.Trigger = 1000
+
+	const rounds = 50000
+	s := RunCountSimulation(cfg, cs, rounds, 1)
+	if s.HandsBet != 0 || s.Net != 0 || s.EV() != 0 || s.Variance() != 0 || s.WinPerShoe() != 0 {
+		t.Errorf("a count that never triggers should not bet: %+v", s)
+	}
+	// Betting every hand plays the full-shoe Dragon 7 bet, within sampling error.
+	exact := analysis.Analyze(analysis.NewComposition(cfg.DecksCount), cfg.Rules)
+	if tol := 4 * math.Sqrt(exact.Variance(cs.Bet)/rounds); math.Abs(s.BaseEV()-exact.EV(cs.Bet)) > tol {
+		t.Errorf("EV of betting Dragon 7 every hand = %.4f, want %.4f ± %.4f", s.BaseEV(), exact.EV(cs.Bet), tol)
+	}
+}
+
+func TestRunCountSimulationWithoutCutCard(t *testing.T) {
+	// Dealing to the last card leaves hands the shoe runs out in the middle
+	// of; they are dealt again from a new shoe.
+	cfg := config.DefaultConfig()
+	cfg.Seed, cfg.DecksCount, cfg.CutCardThreshold = 3, 1, 0
+	s := RunCountSimulation(cfg, strategy.Dragon7Count, 20000, 2)
+	if s.TotalRounds != 20000 {
+		t.Errorf("TotalRounds = %d, want 20000", s.TotalRounds)
+	}
+}
+
+func TestCountStatsEmpty(t *testing.T) {
+	s := RunCountSimulation(config.DefaultConfig(), strategy.Dragon7Count, 0, 1)
+	for name, v := range map[string]float64{"BaseEV": s.BaseEV(), "HandsPerShoe": s.HandsPerShoe(), "WinPerShoe": s.WinPerShoe(), "ShoeStdDev": s.ShoeStdDev()} {
+		if v != 0 {
+			t.Errorf("%s of an empty run = %v, want 0", name, v)
+		}
+	}
+	var buf bytes.Buffer
+	s.PrintCountReport(&buf)
+	if strings.Contains(buf.String(), "NaN") {
+		t.Errorf("report of an empty run shows NaN:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/fair"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/roadmap"
+)
+
+// ShoeDealer brings out, shuffles and burns the shoes of one game or table.
+// In provably-fair mode it commits to every shoe before the first hand and
+// reveals (and logs) the server seed once the shoe is retired.
+type ShoeDealer struct {
+	cfg *config.GameConfig
+	rng model.Randomizer
+
+	Shoe       *model.Shoe
+	ShoeID     string
+	Commitment *fair.Commitment // nil unless cfg.ProvablyFair
+	// ClientSeed, if set, replaces cfg.ClientSeed for the shoes brought out
+	// from now on.
+	ClientSeed string
+	// Road is the scoreboard of the current shoe. It is cleared by NextShoe.
+	Road *roadmap.Roadmap
+
+	serverSeed string
+	created    time.Time
+	shoeCount  int
+	roundCount int // rounds dealt from the current shoe
+}
+
+// NewShoeDealer creates a dealer shuffling from the given randomness stream (see NewRandomizer).
+// No shoe is prepared until NextShoe is called.
+func NewShoeDealer(cfg *config.GameConfig, stream int) *ShoeDealer {
+	return &ShoeDealer{
+		cfg:     cfg,
+		rng:     NewRandomizer(cfg, stream),
+		Road:    roadmap.New(),
+		created: time.Now(),
+	}
+}
+
+// NextShoe retires the current shoe, if any, then shuffles a new one, places
+// its cut card and burns it. With a preset shoe configured, the preset order is
+// dealt again instead, burnt only if the config asks for it. It returns the reveal of the retired shoe in provably-fair mode, nil otherwise.
+func (d *ShoeDealer) NextShoe() (*fair.Reveal, error) {
+	reveal, err := d.Retire()
+	if err != nil {
+		return nil, err
+	}
+
+	d.shoeCount++
+	d.roundCount = 0
+	d.Road.Reset()
+	if len(d.cfg.PresetShoe) > 0 {
+		d.Shoe = model.NewStackedShoe(d.cfg.PresetShoe)
+		d.ShoeID = fmt.Sprintf("preset-%d", d.shoeCount)
+		if !d.cfg.BurnPresetShoe {
+			return reveal, nil
+		}
+		d.Shoe.BurnRule = d.cfg.Burn
+		return reveal, d.Shoe.Burn()
+	}
+
+	if d.cfg.ProvablyFair {
+		serverSeed, err := fair.NewSeed()
+		if err != nil {
+			return reveal, err
+		}
+		clientSeed := d.ClientSeed
+		if clientSeed == "" {
+			clientSeed = d.cfg.ClientSeed
+		}
+		if clientSeed == "" {
+			if clientSeed, err = fair.NewSeed(); err != nil {
+				return reveal, err
+			}
+			clientSeed = clientSeed[:16]
+		}
+		shoe, c := fair.Commit(serverSeed, clientSeed, d.cfg.DecksCount, d.cfg.CutCardThreshold)
+		// The cut card is placed from the seeded stream too, and published
+		// with the burn rule before the first hand.
+		err = prepareShoe(d.cfg, shoe)
+		c.CutCardThreshold, c.BurnRule = shoe.CutCardThreshold, shoe.BurnRule.String()
+		d.Shoe, d.ShoeID, d.Commitment, d.serverSeed = shoe, c.ShoeID, &c, serverSeed
+		return reveal, err
+	}
+
+	d.Shoe = model.NewShoe(d.cfg.DecksCount, d.cfg.CutCardThreshold)
+	d.Shoe.SetRandomizer(d.rng)
+	d.Shoe.Shuffle()
+	d.ShoeID = fmt.Sprintf("%x-%d", d.created.UnixNano(), d.shoeCount)
+	return reveal, prepareShoe(d.cfg, d.Shoe)
+}
+
+// prepareShoe places the cut card of a freshly shuffled shoe and burns it, by
+// the rules of cfg.
+func prepareShoe(cfg *config.GameConfig, shoe *model.Shoe) error {
+	shoe.BurnRule = cfg.Burn
+	shoe.PlaceCutCard(cfg.CutCardRange())
+	return shoe.Burn()
+}
+
+// NextRoundID returns the ID of the next round dealt from the current shoe:
+// the shoe ID and the round's number within the shoe.
+func (d *ShoeDealer) NextRoundID() string {
+	d.roundCount++
+	return fmt.Sprintf("%s/%d", d.ShoeID, d.roundCount)
+}
+
+// Retire takes the current shoe out of play. In provably-fair mode its server seed
+// is revealed and appended to the shoe log; the reveal is returned.
+func (d *ShoeDealer) Retire() (*fair.Reveal, error) {
+	if d.Shoe == nil || d.Commitment == nil {
+		d.Shoe = nil
+		return nil, nil
+	}
+	reveal := &fair.Reveal{Commitment: *d.Commitment, ServerSeed: d.serverSeed}
+	d.Shoe, d.Commitment, d.serverSeed = nil, nil, ""
+	return reveal, LogShoe(ShoeLog{Timestamp: time.Now(), Reveal: *reveal})
+}
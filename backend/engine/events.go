@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"errors"
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/fair"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// TableEventType identifies what a TableEvent reports.
+type TableEventType string
+
+const (
+	// EventPhase: the table entered Phase. Deadline is set when betting opens.
+	EventPhase TableEventType = "PHASE"
+	// EventCountdown: Remaining is left of the betting window that closes at Deadline.
+	EventCountdown TableEventType = "COUNTDOWN"
+	// EventSeat: Player sat down at Seat (Joined) or left it.
+	EventSeat TableEventType = "SEAT"
+	// EventBet: Player set their Bets for the round.
+	EventBet TableEventType = "BET"
+	// EventCard: Draw was dealt; PlayerPoints and BankerPoints are the totals after it.
+	EventCard TableEventType = "CARD"
+	// EventDecision: a side hit or stood; Decision.Reason explains why.
+	EventDecision TableEventType = "DECISION"
+	// EventOutcome: the hand resolved to Outcome with the final points.
+	EventOutcome TableEventType = "OUTCOME"
+	// EventSettlement: Player's Bets were settled, returning Payout and leaving Balance.
+	EventSettlement TableEventType = "SETTLEMENT"
+	// EventCutCard: the cut card came out; the hand just dealt was the shoe's last.
+	EventCutCard TableEventType = "CUT_CARD"
+	// EventNewShoe: the shoe ShoeID was brought out with CardsLeft after the
+	// burn. Commitment is set in provably-fair mode.
+	EventNewShoe TableEventType = "NEW_SHOE"
+	// EventShoeReveal: the provably-fair shoe Reveal.ShoeID was retired and
+	// Reveal discloses its server seed.
+	EventShoeReveal TableEventType = "SHOE_REVEAL"
+)
+
+// TableEvent is one entry of a table's event stream. Only the fields named in
+// the comment of its Type are set.
+type TableEvent struct {
+	Seq   uint64 // increases by one with every event of the table
+	Time  time.Time
+	Type  TableEventType
+	Round int // number of the round the event belongs to
+
+	Phase     TablePhase
+	Deadline  time.Time
+	Remaining time.Duration
+
+	Player string
+	Seat   int
+	Joined bool
+	Bets   map[rules.BetType]int
+
+	Draw                       Draw
+	Decision                   Decision
+	Outcome                    rules.Outcome
+	PlayerPoints, BankerPoints int
+
+	Payout  int
+	Net     int
+	Balance int
+
+	ShoeID     string
+	CardsLeft  int
+	Commitment *fair.Commitment
+	Reveal     *fair.Reveal
+}
+
+// eventHistory is how many recent events a table keeps for subscribers that
+// resume after a reconnect.
+const eventHistory = 1024
+
+// ErrEventsExpired is returned when the events a subscriber asks to resume
+// after are no longer held. It should resync from the table's State.
+var ErrEventsExpired = errors.New("table events are no longer available")
+
+// publish stamps e and appends it to the event history, waking every
+// subscriber. Caller must hold t.mu.
+func (t *Table) publish(e TableEvent) {
+	t.lastSeq++
+	e.Seq, e.Time = t.lastSeq, time.Now()
+	if e.Round == 0 && t.round != nil {
+		e.Round = t.round.Number
+	}
+	t.events = append(t.events, e)
+	// Drop the oldest half once the history is twice its size, so trimming
+	// costs one copy per eventHistory events.
+	if len(t.events) >= 2*eventHistory {
+		t.events = append(t.events[:0], t.events[len(t.events)-eventHistory:]...)
+	}
+	close(t.published)
+	t.published = make(chan struct{})
+}
+
+// LastEventSeq returns the Seq of the table's latest event.
+func (t *Table) LastEventSeq() uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lastSeq
+}
+
+// Events returns the table's events after Seq after, oldest first, and a
+// channel that is closed when the next event is published. A subscriber
+// passes the Seq of the last event it received, then waits on the channel and
+// asks again. An after of 0 asks for every event still held. It fails with
+// ErrEventsExpired if some of the requested events are no longer held, or
+// after is ahead of the table's stream.
+func (t *Table) Events(after uint64) ([]TableEvent, <-chan struct{}, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if after == 0 && len(t.events) > 0 {
+		after = t.events[0].Seq - 1
+	}
+	if after > t.lastSeq {
+		return nil, nil, ErrEventsExpired
+	}
+	if after == t.lastSeq {
+		return nil, t.published, nil
+	}
+	first := t.events[0].Seq
+	if after+1 < first {
+		return nil, nil, ErrEventsExpired
+	}
+	held := t.events[after+1-first:]
+	return append([]TableEvent(nil), held...), t.published, nil
+}
+
+// publishHand announces the cards of a dealt hand in dealing order, with each
+// side's decision to hit or stand before its third card, then the outcome.
+// Caller must hold t.mu.
+func (t *Table) publishHand(hand *RoundResult) {
+	var points [2]int
+	next := 0
+	card := func() {
+		d := hand.Draws[next]
+		next++
+		points[d.Side] = (points[d.Side] + d.Card.PointValue()) % 10
+		t.publish(TableEvent{Type: EventCard, Draw: d, PlayerPoints: points[SidePlayer], BankerPoints: points[SideBanker]})
+	}
+
+	for next < 4 {
+		card()
+	}
+	for _, dec := range []Decision{hand.PlayerDecision, hand.BankerDecision} {
+		t.publish(TableEvent{Type: EventDecision, Decision: dec})
+		if dec.Hit {
+			card()
+		}
+	}
+	t.publish(TableEvent{
+		Type:         EventOutcome,
+		Outcome:      hand.Outcome,
+		PlayerPoints: hand.PlayerHand.TotalPoints(),
+		BankerPoints: hand.BankerHand.TotalPoints(),
+	})
+}
+
+// copyBets returns a copy of bets, or nil if there are none.
+func copyBets(bets map[rules.BetType]int) map[rules.BetType]int {
+	if len(bets) == 0 {
+		return nil
+	}
+	out := make(map[rules.BetType]int, len(bets))
+	for k, v := range bets {
+		out[k] = v
+	}
+	return out
+}
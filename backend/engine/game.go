@@ -1,171 +1,196 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
-	"time"
+	"io"
+	"os"
+	"sort"
 
 	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/fair"
 	"github.com/niubaoshu/es-Baccarat/backend/model"
 	"github.com/niubaoshu/es-Baccarat/backend/player"
+	"github.com/niubaoshu/es-Baccarat/backend/roadmap"
 	"github.com/niubaoshu/es-Baccarat/backend/rules"
 )
 
+// roadmapColumns is the number of road columns shown after each round.
+const roadmapColumns = 30
+
 // Game orchestrates the physical simulation of the shoe and hands.
 type Game struct {
 	Config  *config.GameConfig
 	Shoe    *model.Shoe
 	Profile *player.Profile
+	// Road is the scoreboard of the current shoe.
+	Road *roadmap.Roadmap
+
+	dealer *ShoeDealer
+	rounds RoundStore
 }
 
-// NewGame initializes a game session.
-func NewGame(cfg *config.GameConfig, p *player.Profile) *Game {
+// NewGame initializes a game session that records its rounds in rounds.
+func NewGame(cfg *config.GameConfig, p *player.Profile, rounds RoundStore) *Game {
 	g := &Game{
 		Config:  cfg,
 		Profile: p,
+		dealer:  NewShoeDealer(cfg, 0),
+		rounds:  rounds,
 	}
+	g.Road = g.dealer.Road
 	g.initShoe()
 	return g
 }
 
 func (g *Game) initShoe() {
-	fmt.Printf("\n[Dealer] Bringing out a new shoe with %d decks...\n", g.Config.DecksCount)
-	g.Shoe = model.NewShoe(g.Config.DecksCount, g.Config.CutCardThreshold)
-	g.Shoe.Shuffle()
-	fmt.Println("[Dealer] Shuffling cards...")
+	preset := len(g.Config.PresetShoe) > 0
+	if preset {
+		fmt.Printf("\n[Dealer] Bringing out the preset shoe with %d cards...\n", len(g.Config.PresetShoe))
+	} else {
+		fmt.Printf("\n[Dealer] Bringing out a new shoe with %d decks...\n", g.Config.DecksCount)
+	}
+	reveal, err := g.dealer.NextShoe()
+	printReveal(reveal)
+	g.Shoe = g.dealer.Shoe
+	if !preset {
+		fmt.Println("[Dealer] Shuffling cards...")
+	}
+	if c := g.dealer.Commitment; c != nil {
+		fmt.Printf("[Dealer] Shoe %s commitment: server seed SHA-256 %s, client seed %q\n", c.ShoeID, c.ServerSeedHash, c.ClientSeed)
+		fmt.Printf("[Dealer] Shoe order SHA-256: %s\n", c.OrderHash)
+	}
 
-	err := g.Shoe.Burn()
-	if err != nil {
+	switch {
+	case err != nil:
 		fmt.Printf("[Error] Failed to burn cards: %v\n", err)
-	} else {
+	case preset && !g.Config.BurnPresetShoe:
+		fmt.Println("[Dealer] Dealing the preset order without a burn.")
+	case preset:
 		fmt.Println("[Dealer] Burn procedure complete.")
+	default:
+		fmt.Printf("[Dealer] Burn procedure complete. The cut card is %d cards from the end.\n", g.Shoe.CutCardThreshold)
+	}
+}
+
+// Close retires the current shoe, revealing its server seed in provably-fair mode.
+func (g *Game) Close() {
+	reveal, err := g.dealer.Retire()
+	if err != nil {
+		fmt.Printf("[Error] Failed to log shoe reveal: %v\n", err)
 	}
+	printReveal(reveal)
+}
+
+func printReveal(reveal *fair.Reveal) {
+	if reveal == nil {
+		return
+	}
+	fmt.Printf("[Dealer] Shoe %s retired. Server seed: %s\n", reveal.ShoeID, reveal.ServerSeed)
+	fmt.Printf("[Dealer] Verify it with: ez_baccarat verify --shoe=%s\n", reveal.ShoeID)
 }
 
 // PlayRound handles the end-to-end logic for a single round of Baccarat given user bets.
-func (g *Game) PlayRound(bets map[rules.BetType]int) {
+// Bets that break the table limits are rejected before any card is dealt.
+func (g *Game) PlayRound(bets map[rules.BetType]int) error {
+	if err := g.Config.Limits.Validate(g.Config.Rules, bets, g.Profile.Balance); err != nil {
+		return err
+	}
+
 	if g.Shoe.IsPastCutCard() {
 		fmt.Println("\n[Dealer] Cut card reached. Preparing new shoe...")
 		g.initShoe()
 	}
 
 	initialBalance := g.Profile.Balance
-	totalBetAmount := 0
-	for _, amt := range bets {
-		totalBetAmount += amt
-	}
-
-	// 1. Deduct bets
-	g.Profile.Balance -= totalBetAmount
-	g.Profile.TotalWager += totalBetAmount
-	g.Profile.HandsPlayed++
-
-	// 2. Deal initial cards
-	pHand := &model.Hand{}
-	bHand := &model.Hand{}
-
-	c1, _ := g.Shoe.Draw() // Player 1
-	c2, _ := g.Shoe.Draw() // Banker 1
-	c3, _ := g.Shoe.Draw() // Player 2
-	c4, _ := g.Shoe.Draw() // Banker 2
-
-	pHand.AddCard(c1)
-	bHand.AddCard(c2)
-	pHand.AddCard(c3)
-	bHand.AddCard(c4)
-
-	fmt.Printf("\n--- [Deal Completed] ---\n")
-	fmt.Printf("Player Hand: %s  (Total: %d)\n", pHand.String(), pHand.TotalPoints())
-	fmt.Printf("Banker Hand: %s  (Total: %d)\n", bHand.String(), bHand.TotalPoints())
-
-	// 3. Process Third Card Rules
-	var pThirdCard *model.Card
-	playerHit := rules.DeterminePlayerHit(pHand, bHand)
-
-	if playerHit {
-		c, _ := g.Shoe.Draw()
-		fmt.Printf("[Action] Player hits and draws: %s\n", c.String())
-		pHand.AddCard(c)
-		pThirdCard = &c
-		fmt.Printf("Player Final Hand: %s  (Total: %d)\n", pHand.String(), pHand.TotalPoints())
-	} else if pHand.IsNatural() || bHand.IsNatural() {
-		fmt.Println("[Action] Natural 8 or 9 detected. No hits.")
-	} else {
-		fmt.Println("[Action] Player stands.")
+
+	result, err := ResolveRound(g.Shoe, g.Config.Rules, bets)
+	if errors.Is(err, model.ErrShoeEmpty) {
+		// The hand is void and is dealt again from a new shoe.
+		fmt.Println("\n[Dealer] The shoe ran out mid-hand. Preparing new shoe...")
+		g.initShoe()
+		result, err = ResolveRound(g.Shoe, g.Config.Rules, bets)
+	}
+	if err != nil {
+		return fmt.Errorf("dealing round: %w", err)
+	}
+
+	// Settle the profile: bets are debited and every payout credited in the ledger.
+	roundID := g.dealer.NextRoundID()
+	if err := g.Profile.Settle(roundID, result.Wagers()); err != nil {
+		return fmt.Errorf("settling round: %w", err)
 	}
 
-	bankerHit := rules.DetermineBankerHit(bHand, pHand, playerHit, pThirdCard)
+	g.Road.Add(result.Outcome)
+	RenderRound(os.Stdout, result)
 
-	if bankerHit {
-		c, _ := g.Shoe.Draw()
-		fmt.Printf("[Action] Banker hits and draws: %s\n", c.String())
-		bHand.AddCard(c)
-		fmt.Printf("Banker Final Hand: %s  (Total: %d)\n", bHand.String(), bHand.TotalPoints())
-	} else if !pHand.IsNatural() && !bHand.IsNatural() {
-		fmt.Println("[Action] Banker stands.")
+	log := NewRoundLog(g.Profile.Username, initialBalance, g.Profile.Balance, bets, result)
+	log.ShoeID, log.RoundID = g.dealer.ShoeID, roundID
+	if err := g.rounds.LogRound(log); err != nil {
+		fmt.Printf("[Error] Failed to log round: %v\n", err)
 	}
 
-	// 4. Outcomes and Payouts
-	outcome := rules.DetermineOutcome(pHand, bHand)
-	fmt.Printf("\n>>> [Outcome]: %s Wins! <<<\n", outcome)
+	// Round Summary Print
+	fmt.Printf("\n=== Round Summary ===\n")
+	fmt.Printf("Cards Left: %d\n", g.Shoe.CardsLeft())
+	fmt.Printf("Net Change: $%d\n", result.NetChange())
+	fmt.Printf("New Balance: $%d\n", g.Profile.Balance)
+	fmt.Printf("=====================\n\n")
+
+	fmt.Println("=== Roadmap ===")
+	g.Road.Render(os.Stdout, roadmapColumns)
+	fmt.Println()
+	return nil
+}
 
-	totalWin := 0
-	totalReturned := 0
+// RenderRound writes the dealing sequence, outcome and per-bet results of a round.
+func RenderRound(w io.Writer, r *RoundResult) {
+	initialPlayer := &model.Hand{Cards: r.PlayerHand.Cards[:2]}
+	initialBanker := &model.Hand{Cards: r.BankerHand.Cards[:2]}
 
-	for bType, amt := range bets {
-		result := rules.CalculatePayout(outcome, bType, amt)
-		totalWin += result.WinAmount
-		totalReturned += result.Returned
+	fmt.Fprintf(w, "\n--- [Deal Completed] ---\n")
+	fmt.Fprintf(w, "Player Hand: %s  (Total: %d)\n", initialPlayer.String(), initialPlayer.TotalPoints())
+	fmt.Fprintf(w, "Banker Hand: %s  (Total: %d)\n", initialBanker.String(), initialBanker.TotalPoints())
 
+	if r.IsNatural() {
+		fmt.Fprintf(w, "[Action] %s.\n", r.PlayerDecision.Reason())
+	} else {
+		renderDecision(w, r.PlayerDecision, r.PlayerHand)
+		renderDecision(w, r.BankerDecision, r.BankerHand)
+	}
+
+	fmt.Fprintf(w, "\n>>> [Outcome]: %s Wins! <<<\n", r.Outcome)
+
+	for _, bType := range sortedBetTypes(r.Bets) {
+		amt := r.Bets[bType]
+		result := r.Payouts[bType]
 		change := result.NetChange(amt)
 		if change > 0 {
-			fmt.Printf("  - %s Bet ($%d): WIN (+%d)\n", bType, amt, result.WinAmount)
+			fmt.Fprintf(w, "  - %s Bet ($%d): WIN (+%d)\n", bType, amt, result.WinAmount)
 		} else if change == 0 {
-			fmt.Printf("  - %s Bet ($%d): PUSH\n", bType, amt)
+			fmt.Fprintf(w, "  - %s Bet ($%d): PUSH\n", bType, amt)
 		} else {
-			fmt.Printf("  - %s Bet ($%d): LOSE\n", bType, amt)
+			fmt.Fprintf(w, "  - %s Bet ($%d): LOSE\n", bType, amt)
 		}
 	}
+}
 
-	g.Profile.Balance += totalWin + totalReturned
-	netChange := (totalWin + totalReturned) - totalBetAmount
-
-	// 5. Save State and Log
-	_ = g.Profile.Save()
-
-	pStringCards := make([]string, len(pHand.Cards))
-	for i, c := range pHand.Cards {
-		pStringCards[i] = c.String()
-	}
-
-	bStringCards := make([]string, len(bHand.Cards))
-	for i, c := range bHand.Cards {
-		bStringCards[i] = c.String()
-	}
-
-	strBets := make(map[string]int)
-	for k, v := range bets {
-		strBets[string(k)] = v
+func renderDecision(w io.Writer, d Decision, hand *model.Hand) {
+	if !d.Hit {
+		fmt.Fprintf(w, "[Action] %s.\n", d.Reason())
+		return
 	}
+	third := hand.Cards[len(hand.Cards)-1]
+	fmt.Fprintf(w, "[Action] %s and draws: %s\n", d.Reason(), third.String())
+	fmt.Fprintf(w, "%s Final Hand: %s  (Total: %d)\n", d.Side, hand.String(), hand.TotalPoints())
+}
 
-	log := RoundLog{
-		Timestamp:      time.Now(),
-		Player:         g.Profile.Username,
-		InitialBalance: initialBalance,
-		FinalBalance:   g.Profile.Balance,
-		Bets:           strBets,
-		PlayerHand:     pStringCards,
-		BankerHand:     bStringCards,
-		PlayerPoints:   pHand.TotalPoints(),
-		BankerPoints:   bHand.TotalPoints(),
-		Outcome:        string(outcome),
-		NetChange:      netChange,
+// sortedBetTypes returns the bet types of a bet map in a stable display order.
+func sortedBetTypes(bets map[rules.BetType]int) []rules.BetType {
+	types := make([]rules.BetType, 0, len(bets))
+	for bType := range bets {
+		types = append(types, bType)
 	}
-	_ = LogRound(log)
-
-	// 6. Round Summary Print
-	fmt.Printf("\n=== Round Summary ===\n")
-	fmt.Printf("Cards Left: %d\n", g.Shoe.CardsLeft())
-	fmt.Printf("Net Change: $%d\n", netChange)
-	fmt.Printf("New Balance: $%d\n", g.Profile.Balance)
-	fmt.Printf("=====================\n\n")
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
 }
@@ -1,10 +1,17 @@
 package engine
 
 import (
+	"bufio"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/fair"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
 )
 
 // RoundLog defines what gets written to the logging file for every hand played.
@@ -20,17 +27,101 @@ type RoundLog struct {
 	BankerPoints   int            `json:"banker_points"`
 	Outcome        string         `json:"outcome"`
 	NetChange      int            `json:"net_change"`
+	ShoeID         string         `json:"shoe_id,omitempty"`
+	RoundID        string         `json:"round_id,omitempty"` // matches the player's ledger entries
+	// SettleError is set when the hand was dealt but the bets could not be
+	// settled; the balances are the player's before and after the attempt.
+	SettleError string `json:"settle_error,omitempty"`
+}
+
+// ShoeLog records a retired provably-fair shoe together with its revealed server seed.
+type ShoeLog struct {
+	Timestamp time.Time `json:"timestamp"`
+	fair.Reveal
 }
 
 var logDir = "data/logs"
 
+const (
+	roundLogFile = "game_history.jsonl"
+	shoeLogFile  = "shoe_history.jsonl"
+)
+
+// NewRoundLog builds the log entry for a resolved round.
+func NewRoundLog(playerName string, initialBalance, finalBalance int, bets map[rules.BetType]int, r *RoundResult) RoundLog {
+	strBets := make(map[string]int, len(bets))
+	for k, v := range bets {
+		strBets[string(k)] = v
+	}
+
+	return RoundLog{
+		Timestamp:      time.Now(),
+		Player:         playerName,
+		InitialBalance: initialBalance,
+		FinalBalance:   finalBalance,
+		Bets:           strBets,
+		PlayerHand:     CardStrings(r.PlayerHand),
+		BankerHand:     CardStrings(r.BankerHand),
+		PlayerPoints:   r.PlayerHand.TotalPoints(),
+		BankerPoints:   r.BankerHand.TotalPoints(),
+		Outcome:        string(r.Outcome),
+		NetChange:      r.NetChange(),
+	}
+}
+
+// CardStrings returns the display strings of a hand's cards in dealing order.
+func CardStrings(h *model.Hand) []string {
+	out := make([]string, len(h.Cards))
+	for i, c := range h.Cards {
+		out[i] = c.String()
+	}
+	return out
+}
+
+// RoundStore keeps the history of played rounds.
+type RoundStore interface {
+	// LogRound records a resolved round.
+	LogRound(RoundLog) error
+	// LoadRounds returns every recorded round in the order it was logged.
+	LoadRounds() ([]RoundLog, error)
+}
+
+// FileRoundStore keeps the round history as a JSONL file in Dir.
+type FileRoundStore struct {
+	Dir string
+}
+
+// DefaultRoundStore returns the file round store behind LogRound and LoadRoundLogs.
+func DefaultRoundStore() FileRoundStore {
+	return FileRoundStore{Dir: logDir}
+}
+
+// LogRound appends a round summary to the store's JSONL log file.
+func (s FileRoundStore) LogRound(logEntry RoundLog) error {
+	return appendJSONL(s.Dir, roundLogFile, logEntry)
+}
+
+// LoadRounds reads every entry of the store's round history log.
+func (s FileRoundStore) LoadRounds() ([]RoundLog, error) {
+	return loadJSONL[RoundLog](s.Dir, roundLogFile)
+}
+
 // LogRound appends a round summary to the JSONL log file.
 func LogRound(logEntry RoundLog) error {
-	if err := os.MkdirAll(logDir, 0755); err != nil {
+	return DefaultRoundStore().LogRound(logEntry)
+}
+
+// LogShoe appends a revealed shoe to the shoe history log.
+func LogShoe(logEntry ShoeLog) error {
+	return appendJSONL(logDir, shoeLogFile, logEntry)
+}
+
+func appendJSONL(dir, name string, logEntry any) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
-	logFile := filepath.Join(logDir, "game_history.jsonl")
+	logFile := filepath.Join(dir, name)
 	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return err
@@ -47,3 +138,54 @@ func LogRound(logEntry RoundLog) error {
 	}
 	return nil
 }
+
+// ReadRoundLogs parses a JSONL stream of RoundLog entries.
+func ReadRoundLogs(r io.Reader) ([]RoundLog, error) {
+	return readJSONL[RoundLog](r)
+}
+
+// ReadShoeLogs parses a JSONL stream of ShoeLog entries.
+func ReadShoeLogs(r io.Reader) ([]ShoeLog, error) {
+	return readJSONL[ShoeLog](r)
+}
+
+// LoadRoundLogs reads every entry of the round history log.
+func LoadRoundLogs() ([]RoundLog, error) {
+	return DefaultRoundStore().LoadRounds()
+}
+
+// LoadShoeLogs reads every entry of the shoe history log.
+func LoadShoeLogs() ([]ShoeLog, error) {
+	return loadJSONL[ShoeLog](logDir, shoeLogFile)
+}
+
+func loadJSONL[T any](dir, name string) ([]T, error) {
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return readJSONL[T](f)
+}
+
+func readJSONL[T any](r io.Reader) ([]T, error) {
+	var out []T
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry T
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		out = append(out, entry)
+	}
+	return out, scanner.Err()
+}
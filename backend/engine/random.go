@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+)
+
+// NewRandomizer returns the shuffle source for one independent stream of a game
+// (a server table, the shuffle self-test, ...). In crypto mode every stream reads
+// from crypto/rand. Otherwise streams derived from the same cfg.Seed are
+// reproducible, and nil (clock seeding) is returned when no seed is set.
+func NewRandomizer(cfg *config.GameConfig, stream int) model.Randomizer {
+	if cfg.ShuffleMode == config.ShuffleCrypto {
+		return model.NewCryptoRandomizer()
+	}
+	if cfg.Seed == 0 {
+		return nil
+	}
+	return model.NewSeededRandomizer(model.DeriveSeed(cfg.Seed, stream))
+}
+
+// newSimulationRandomizer is NewRandomizer for simulation workers, which shuffle
+// often enough for the generator to matter: seeded and clock-seeded streams use
+// the faster PCG generator, so a seed reproduces a different run than it does
+// at a table.
+func newSimulationRandomizer(cfg *config.GameConfig, stream int) model.Randomizer {
+	if cfg.ShuffleMode == config.ShuffleCrypto {
+		return model.NewCryptoRandomizer()
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return model.NewFastRandomizer(model.DeriveSeed(seed, stream))
+}
@@ -0,0 +1,407 @@
+package engine
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/niubaoshu/es-Baccarat/backend/analysis"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/stats"
+)
+
+// reportOutcomes are the outcomes a simulation report lists, in order.
+var reportOutcomes = [...]rules.Outcome{rules.OutcomePlayer, rules.OutcomePanda8, rules.OutcomeBanker, rules.OutcomeTie, rules.OutcomeDragon7}
+
+// Estimate is a simulated quantity with its standard error, its 95%
+// confidence interval and the exact value it estimates.
+type Estimate struct {
+	Value    float64 `json:"value"`
+	StdErr   float64 `json:"std_err"`
+	CI95Low  float64 `json:"ci95_low"`
+	CI95High float64 `json:"ci95_high"`
+	Expected float64 `json:"expected"`
+}
+
+func newEstimate(value, stdErr, expected float64) Estimate {
+	low, high := stats.Interval95(value, stdErr)
+	return Estimate{Value: value, StdErr: stdErr, CI95Low: low, CI95High: high, Expected: expected}
+}
+
+// Covers reports whether the confidence interval contains the expected value.
+func (e Estimate) Covers() bool {
+	return e.CI95Low <= e.Expected && e.Expected <= e.CI95High
+}
+
+// OutcomeEstimate is the simulated probability of an outcome.
+type OutcomeEstimate struct {
+	Outcome rules.Outcome `json:"outcome"`
+	Count   int           `json:"count"`
+	Estimate
+}
+
+// BetEstimate is the simulated EV, per unit wagered, of a bet type.
+type BetEstimate struct {
+	Bet rules.BetType `json:"bet"`
+	Net float64       `json:"net"` // total net result in units of SimulationBetUnit
+	Estimate
+}
+
+// GoodnessOfFit is a chi-square test of the outcome counts against the exact
+// outcome probabilities. A small PValue means the simulated outcomes are
+// unlikely under the exact distribution.
+type GoodnessOfFit struct {
+	Statistic float64 `json:"statistic"`
+	DF        int     `json:"df"`
+	PValue    float64 `json:"p_value"`
+}
+
+// SimulationReport compares a simulation with the exact probabilities and EVs
+// of a full shoe of the same size.
+type SimulationReport struct {
+	Variant         string            `json:"variant"`
+	Decks           int               `json:"decks"`
+	Rounds          int               `json:"rounds"`
+	Interrupted     bool              `json:"interrupted"`
+	DurationSeconds float64           `json:"duration_seconds"`
+	RoundsPerSecond float64           `json:"rounds_per_second"`
+	Outcomes        []OutcomeEstimate `json:"outcomes"`
+	Bets            []BetEstimate     `json:"bets"`
+	ChiSquare       GoodnessOfFit     `json:"chi_square"`
+	Shoe            *ShoeStats        `json:"shoe,omitempty"`
+}
+
+// Report computes the standard error and 95% confidence interval of every
+// outcome probability and bet EV, and the chi-square test of the outcomes.
+func (s *SimulationStats) Report() *SimulationReport {
+	exact := analysis.Analyze(analysis.NewComposition(s.DecksCount), s.Rules)
+	n := s.TotalRounds
+	r := &SimulationReport{
+		Variant:         s.Rules.Name(),
+		Decks:           s.DecksCount,
+		Rounds:          n,
+		Interrupted:     s.Interrupted,
+		DurationSeconds: s.Duration.Seconds(),
+		RoundsPerSecond: float64(n) / s.Duration.Seconds(),
+		Shoe:            s.Shoe,
+	}
+
+	var observed []int
+	var expected []float64
+	for _, o := range reportOutcomes {
+		count, p := s.OutcomeCount[o], exact.Probability(o)
+		if count == 0 && p == 0 {
+			continue // the variant has no such outcome
+		}
+		r.Outcomes = append(r.Outcomes, OutcomeEstimate{
+			Outcome:  o,
+			Count:    count,
+			Estimate: newEstimate(float64(count)/float64(n), stats.ProportionStdErr(count, n), p),
+		})
+		observed = append(observed, count)
+		expected = append(expected, p*float64(n))
+	}
+	r.ChiSquare = chiSquareFit(observed, expected)
+
+	for _, bet := range s.Rules.BetTypes() {
+		sum, sumSq := float64(s.BetNet[bet])/SimulationBetUnit, float64(s.BetNetSq[bet])/(SimulationBetUnit*SimulationBetUnit)
+		r.Bets = append(r.Bets, BetEstimate{
+			Bet:      bet,
+			Net:      sum,
+			Estimate: newEstimate(sum/float64(n), stats.MeanStdErr(sum, sumSq, n), exact.EV(bet)),
+		})
+	}
+	return r
+}
+
+// chiSquareFit tests observed counts against expected ones. A count in a cell
+// that cannot occur makes the fit impossible: an infinite statistic with a
+// p-value of 0.
+func chiSquareFit(observed []int, expected []float64) GoodnessOfFit {
+	fit := GoodnessOfFit{DF: -1}
+	for i, e := range expected {
+		if e > 0 {
+			fit.DF++
+		} else if observed[i] > 0 {
+			fit.Statistic = math.Inf(1)
+		}
+	}
+	if math.IsInf(fit.Statistic, 1) {
+		return fit
+	}
+	fit.Statistic, _ = stats.ChiSquare(observed, expected)
+	fit.PValue = stats.ChiSquarePValue(fit.Statistic, fit.DF)
+	return fit
+}
+
+// Report formats accepted by WriteReport.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// ReportFormats lists the formats WriteReport accepts.
+func ReportFormats() []string {
+	return []string{FormatText, FormatJSON, FormatCSV}
+}
+
+// WriteReport writes the simulation report to w in the given format.
+func (s *SimulationStats) WriteReport(w io.Writer, format string) error {
+	r := s.Report()
+	switch format {
+	case FormatText:
+		r.WriteText(w)
+		return nil
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatCSV:
+		return r.WriteCSV(w)
+	}
+	return fmt.Errorf("unknown report format %q (use text, json or csv)", format)
+}
+
+// WriteJSON writes the report as an indented JSON object. Values that are
+// not finite numbers, such as the standard error of a single round, are
+// written as null.
+func (r *SimulationReport) WriteJSON(w io.Writer) error {
+	type estimate struct {
+		Value    *float64 `json:"value"`
+		StdErr   *float64 `json:"std_err"`
+		CI95Low  *float64 `json:"ci95_low"`
+		CI95High *float64 `json:"ci95_high"`
+		Expected *float64 `json:"expected"`
+	}
+	type outcome struct {
+		Outcome rules.Outcome `json:"outcome"`
+		Count   int           `json:"count"`
+		estimate
+	}
+	type bet struct {
+		Bet rules.BetType `json:"bet"`
+		Net float64       `json:"net"`
+		estimate
+	}
+	type fit struct {
+		Statistic *float64 `json:"statistic"`
+		DF        int      `json:"df"`
+		PValue    *float64 `json:"p_value"`
+	}
+	est := func(e Estimate) estimate {
+		return estimate{finite(e.Value), finite(e.StdErr), finite(e.CI95Low), finite(e.CI95High), finite(e.Expected)}
+	}
+
+	out := struct {
+		Variant         string     `json:"variant"`
+		Decks           int        `json:"decks"`
+		Rounds          int        `json:"rounds"`
+		Interrupted     bool       `json:"interrupted"`
+		DurationSeconds float64    `json:"duration_seconds"`
+		RoundsPerSecond *float64   `json:"rounds_per_second"`
+		Outcomes        []outcome  `json:"outcomes"`
+		Bets            []bet      `json:"bets"`
+		ChiSquare       fit        `json:"chi_square"`
+		Shoe            *ShoeStats `json:"shoe,omitempty"`
+	}{
+		Variant:         r.Variant,
+		Decks:           r.Decks,
+		Rounds:          r.Rounds,
+		Interrupted:     r.Interrupted,
+		DurationSeconds: r.DurationSeconds,
+		RoundsPerSecond: finite(r.RoundsPerSecond),
+		ChiSquare:       fit{finite(r.ChiSquare.Statistic), r.ChiSquare.DF, finite(r.ChiSquare.PValue)},
+		Shoe:            r.Shoe,
+	}
+	for _, o := range r.Outcomes {
+		out.Outcomes = append(out.Outcomes, outcome{o.Outcome, o.Count, est(o.Estimate)})
+	}
+	for _, b := range r.Bets {
+		out.Bets = append(out.Bets, bet{b.Bet, b.Net, est(b.Estimate)})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// finite returns &f, or nil if f is NaN or infinite.
+func finite(f float64) *float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return &f
+}
+
+// csvHeader is the header row of WriteCSV.
+var csvHeader = []string{"kind", "name", "count", "net", "value", "std_err", "ci95_low", "ci95_high", "expected", "df", "p_value"}
+
+// WriteCSV writes the report as CSV with the columns of csvHeader: one row
+// per outcome (kind "outcome") and bet ("bet"), then the chi-square test
+// ("chi_square", with the statistic as its value). Shoe statistics follow as
+// one row per histogram bin ("hands_per_shoe", "burn_count", "banker_streak"
+// and "player_streak", named by the bin) and per outcome of each cards-left
+// bucket ("cards_left", named "<min>-<max> <outcome>"), with the count and its
+// share as the value. Cells that do not apply are empty.
+func (r *SimulationReport) WriteCSV(w io.Writer) error {
+	num := func(f float64) string {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return ""
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	est := func(e Estimate) []string {
+		return []string{num(e.Value), num(e.StdErr), num(e.CI95Low), num(e.CI95High), num(e.Expected)}
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write(csvHeader)
+	for _, o := range r.Outcomes {
+		row := append([]string{"outcome", string(o.Outcome), strconv.Itoa(o.Count), ""}, est(o.Estimate)...)
+		cw.Write(append(row, "", ""))
+	}
+	for _, b := range r.Bets {
+		row := append([]string{"bet", string(b.Bet), "", num(b.Net)}, est(b.Estimate)...)
+		cw.Write(append(row, "", ""))
+	}
+	cw.Write([]string{"chi_square", "outcomes", "", "", num(r.ChiSquare.Statistic), "", "", "", "", strconv.Itoa(r.ChiSquare.DF), num(r.ChiSquare.PValue)})
+	if sh := r.Shoe; sh != nil {
+		hist := func(kind string, h stats.Histogram) {
+			total := h.Total()
+			lo, hi := h.Range()
+			for v := lo; v <= hi; v++ {
+				cw.Write([]string{kind, strconv.Itoa(v), strconv.Itoa(h[v]), "", num(float64(h[v]) / float64(total)), "", "", "", "", "", ""})
+			}
+		}
+		hist("hands_per_shoe", sh.HandsPerShoe)
+		hist("burn_count", sh.BurnCounts)
+		for _, b := range sh.ByCardsLeft {
+			for _, o := range reportOutcomes {
+				if n, ok := b.OutcomeCount[o]; ok {
+					name := fmt.Sprintf("%d-%d %s", b.CardsLeftMin, b.CardsLeftMax, o)
+					cw.Write([]string{"cards_left", name, strconv.Itoa(n), "", num(b.Share(o)), "", "", "", "", "", ""})
+				}
+			}
+		}
+		hist("banker_streak", sh.BankerStreaks)
+		hist("player_streak", sh.PlayerStreaks)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteText prints the report as tables, with percentages. Panda 8 is a
+// Player win and is counted in the Player total as well as on its own row.
+func (r *SimulationReport) WriteText(w io.Writer) {
+	if r.Interrupted {
+		fmt.Fprintf(w, "\n=== Simulation Interrupted ===\n")
+	} else {
+		fmt.Fprintf(w, "\n=== Simulation Complete ===\n")
+	}
+	fmt.Fprintf(w, "Variant:      %s\n", r.Variant)
+	fmt.Fprintf(w, "Total Rounds: %d\n", r.Rounds)
+	fmt.Fprintf(w, "Time Taken:   %s (%.0f rounds/sec)\n", time.Duration(r.DurationSeconds*float64(time.Second)), r.RoundsPerSecond)
+	fmt.Fprintln(w)
+
+	ci := func(e Estimate) string {
+		if math.IsNaN(e.StdErr) {
+			return "n/a"
+		}
+		return fmt.Sprintf("±%.4f%%", (e.CI95High-e.Value)*100)
+	}
+	row := func(label string, count int, e Estimate) {
+		fmt.Fprintf(w, "%-20s | %12d | %11.4f%% | %11s | %11.4f%%\n", label, count, e.Value*100, ci(e), e.Expected*100)
+	}
+	labels := map[rules.Outcome]string{
+		rules.OutcomePanda8: "  ↳ Panda 8",
+		rules.OutcomeBanker: "Banker (Non-Dragon)",
+	}
+
+	fmt.Fprintf(w, "%-20s | %-12s | %-12s | %-11s | %-12s\n", "Outcome", "Count", "Simulated %", "95% CI", "Expected %")
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+	playerWins, pPlayer := 0, 0.0
+	for _, o := range r.Outcomes {
+		if o.Outcome == rules.OutcomePlayer || o.Outcome == rules.OutcomePanda8 {
+			playerWins += o.Count
+			pPlayer += o.Expected
+		}
+	}
+	player := newEstimate(float64(playerWins)/float64(r.Rounds), stats.ProportionStdErr(playerWins, r.Rounds), pPlayer)
+	row("Player (Total)", playerWins, player)
+	total := 0
+	for _, o := range r.Outcomes {
+		total += o.Count
+		if o.Outcome == rules.OutcomePlayer {
+			continue
+		}
+		label, ok := labels[o.Outcome]
+		if !ok {
+			label = string(o.Outcome)
+		}
+		row(label, o.Count, o.Estimate)
+	}
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+	fmt.Fprintf(w, "%-20s | %12d | %11.4f%% | %11s | %11.4f%%\n", "Total", total, float64(total)/float64(r.Rounds)*100, "", 100.0)
+	fmt.Fprintf(w, "Chi-square vs expected: %.2f (df=%d), p-value: %.4f\n", r.ChiSquare.Statistic, r.ChiSquare.DF, r.ChiSquare.PValue)
+	fmt.Fprintf(w, "================================================================================\n")
+
+	fmt.Fprintf(w, "\n%-20s | %-16s | %-15s | %-11s | %-15s\n", "Bet Type ($1/hand)", "Net Profit ($)", "Simulated EV", "95% CI", "Expected EV")
+	fmt.Fprintln(w, "-------------------------------------------------------------------------------------")
+	for _, b := range r.Bets {
+		fmt.Fprintf(w, "%-20s | %16.2f | %14.4f%% | %11s | %14.4f%%\n", b.Bet, b.Net, b.Value*100, ci(b.Estimate), b.Expected*100)
+	}
+	fmt.Fprintf(w, "=====================================================================================\n\n")
+	if r.Shoe != nil {
+		r.writeShoeText(w)
+	}
+}
+
+// histogramBarWidth is the length of the longest bar of a text histogram.
+const histogramBarWidth = 40
+
+// writeShoeText prints the shoe statistics: histograms as bar charts, and the
+// outcome shares of every cards-left bucket next to those of a full shoe.
+func (r *SimulationReport) writeShoeText(w io.Writer) {
+	sh := r.Shoe
+	hist := func(title string, h stats.Histogram) {
+		total := h.Total()
+		fmt.Fprintf(w, "%s (mean %.2f)\n", title, h.Mean())
+		lo, hi := h.Range()
+		peak := slices.Max(append([]int{0}, h...))
+		for v := lo; v <= hi; v++ {
+			bar := strings.Repeat("█", h[v]*histogramBarWidth/peak)
+			fmt.Fprintf(w, "%6d | %s%s %10d %8.3f%%\n", v, bar, strings.Repeat(" ", histogramBarWidth-utf8.RuneCountInString(bar)), h[v], float64(h[v])*100/float64(total))
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "=== Shoe Statistics (%d shoes dealt to the cut card) ===\n\n", sh.Shoes)
+	hist("Hands per shoe", sh.HandsPerShoe)
+	hist("Cards burned per shoe", sh.BurnCounts)
+
+	fmt.Fprintf(w, "%-11s | %10s", "Cards Left", "Rounds")
+	for _, o := range r.Outcomes {
+		fmt.Fprintf(w, " | %9s", o.Outcome)
+	}
+	fmt.Fprintln(w)
+	for _, b := range sh.ByCardsLeft {
+		fmt.Fprintf(w, "%4d - %-4d | %10d", b.CardsLeftMin, b.CardsLeftMax, b.Rounds)
+		for _, o := range r.Outcomes {
+			fmt.Fprintf(w, " | %8.4f%%", b.Share(o.Outcome)*100)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "%-11s | %10s", "Full shoe", "")
+	for _, o := range r.Outcomes {
+		fmt.Fprintf(w, " | %8.4f%%", o.Expected*100)
+	}
+	fmt.Fprintf(w, "\n\n")
+
+	hist("Banker streak length (ties do not break a streak)", sh.BankerStreaks)
+	hist("Player streak length (ties do not break a streak)", sh.PlayerStreaks)
+}
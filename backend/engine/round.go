@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// Side identifies which hand a card is dealt to.
+type Side uint8
+
+const (
+	SidePlayer Side = iota
+	SideBanker
+)
+
+func (s Side) String() string {
+	if s == SideBanker {
+		return "Banker"
+	}
+	return "Player"
+}
+
+// Draw records a single card taken from the shoe, in dealing order.
+// ThirdCard is false for the four cards of the initial deal.
+type Draw struct {
+	Side      Side
+	Card      model.Card
+	ThirdCard bool
+}
+
+// Decision records whether a side took a third card, together with the facts the
+// drawing rules were applied to. Reason renders them as text.
+type Decision struct {
+	Side    Side
+	Hit     bool
+	Points  int  // Two-card total of the deciding side
+	Natural bool // Either side had a Natural 8 or 9
+
+	// PlayerThirdCard is the Player's third card when the Banker decides after a
+	// Player hit; nil otherwise.
+	PlayerThirdCard *model.Card
+}
+
+// Reason explains the decision, e.g. "Banker hits on 4 against Player third card 5".
+func (d Decision) Reason() string {
+	if d.Natural {
+		return "Natural 8 or 9 detected. No hits"
+	}
+	verb := "stands"
+	if d.Hit {
+		verb = "hits"
+	}
+	switch {
+	case d.Side == SidePlayer:
+		return fmt.Sprintf("Player %s on %d", verb, d.Points)
+	case d.PlayerThirdCard == nil:
+		return fmt.Sprintf("Banker %s on %d (Player stood)", verb, d.Points)
+	default:
+		return fmt.Sprintf("Banker %s on %d against Player third card %d", verb, d.Points, d.PlayerThirdCard.PointValue())
+	}
+}
+
+// RoundResult is the complete, render-independent result of one Baccarat hand.
+type RoundResult struct {
+	Draws          []Draw
+	PlayerHand     *model.Hand
+	BankerHand     *model.Hand
+	PlayerDecision Decision
+	BankerDecision Decision
+	Outcome        rules.Outcome
+	Bets           map[rules.BetType]int
+	Payouts        map[rules.BetType]rules.PayoutResult
+
+	TotalBet    int // Sum of all wagers
+	TotalPayout int // Sum of WinAmount + Returned across all bets
+
+	// Inline backing storage so resolving a round costs a single allocation.
+	hands   [2]model.Hand
+	cards   [6]model.Card
+	drawBuf [6]Draw
+}
+
+// NetChange returns the change to the bettor's balance caused by this round.
+func (r *RoundResult) NetChange() int {
+	return r.TotalPayout - r.TotalBet
+}
+
+// Wagers returns the bets of the round and what each returned, for the
+// player's ledger.
+func (r *RoundResult) Wagers() []player.Wager {
+	wagers := make([]player.Wager, 0, len(r.Bets))
+	for _, bType := range sortedBetTypes(r.Bets) {
+		p := r.Payouts[bType]
+		wagers = append(wagers, player.Wager{BetType: string(bType), Amount: r.Bets[bType], Returned: p.WinAmount + p.Returned})
+	}
+	return wagers
+}
+
+// IsNatural reports whether either side was dealt a Natural 8 or 9.
+func (r *RoundResult) IsNatural() bool {
+	return r.PlayerHand.IsNatural() || r.BankerHand.IsNatural()
+}
+
+// ResolveRound deals one hand from the shoe, applies the third-card rules and
+// settles every bet under the given rule set. It performs no I/O and does not
+// check the cut card; callers decide when a new shoe is needed. bets may be nil
+// when only the outcome matters.
+func ResolveRound(shoe *model.Shoe, rs rules.RuleSet, bets map[rules.BetType]int) (*RoundResult, error) {
+	r := &RoundResult{Bets: bets}
+	r.hands[0].Cards = r.cards[0:0:3]
+	r.hands[1].Cards = r.cards[3:3:6]
+	r.PlayerHand, r.BankerHand = &r.hands[0], &r.hands[1]
+	r.Draws = r.drawBuf[:0]
+
+	deal := func(side Side, third bool) (model.Card, error) {
+		c, err := shoe.Draw()
+		if err != nil {
+			return c, err
+		}
+		if side == SidePlayer {
+			r.PlayerHand.AddCard(c)
+		} else {
+			r.BankerHand.AddCard(c)
+		}
+		r.Draws = append(r.Draws, Draw{Side: side, Card: c, ThirdCard: third})
+		return c, nil
+	}
+
+	// 1. Initial deal: Player, Banker, Player, Banker
+	for _, side := range [...]Side{SidePlayer, SideBanker, SidePlayer, SideBanker} {
+		if _, err := deal(side, false); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Third card rules
+	natural := r.IsNatural()
+	var pThird *model.Card
+	playerHit := rules.DeterminePlayerHit(r.PlayerHand, r.BankerHand)
+	r.PlayerDecision = Decision{Side: SidePlayer, Hit: playerHit, Points: r.PlayerHand.TotalPoints(), Natural: natural}
+	if playerHit {
+		c, err := deal(SidePlayer, true)
+		if err != nil {
+			return nil, err
+		}
+		pThird = &c
+	}
+
+	bankerHit := rules.DetermineBankerHit(r.BankerHand, r.PlayerHand, playerHit, pThird)
+	r.BankerDecision = Decision{Side: SideBanker, Hit: bankerHit, Points: r.BankerHand.TotalPoints(), Natural: natural, PlayerThirdCard: pThird}
+	if bankerHit {
+		if _, err := deal(SideBanker, true); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Outcome and payouts
+	r.Outcome = rules.DetermineOutcome(r.PlayerHand, r.BankerHand)
+	r.settle(rs)
+	return r, nil
+}
+
+// Settle returns the same hand with another bettor's bets settled under rs,
+// for tables where several players bet on one deal.
+func (r *RoundResult) Settle(rs rules.RuleSet, bets map[rules.BetType]int) *RoundResult {
+	s := &RoundResult{
+		Draws:          r.Draws,
+		PlayerHand:     r.PlayerHand,
+		BankerHand:     r.BankerHand,
+		PlayerDecision: r.PlayerDecision,
+		BankerDecision: r.BankerDecision,
+		Outcome:        r.Outcome,
+		Bets:           bets,
+	}
+	s.settle(rs)
+	return s
+}
+
+// settle pays out r.Bets against the dealt hands.
+func (r *RoundResult) settle(rs rules.RuleSet) {
+	if len(r.Bets) > 0 {
+		r.Payouts = make(map[rules.BetType]rules.PayoutResult, len(r.Bets))
+	}
+	for bType, amt := range r.Bets {
+		result := rs.Payout(r.PlayerHand, r.BankerHand, bType, amt)
+		r.Payouts[bType] = result
+		r.TotalBet += amt
+		r.TotalPayout += result.WinAmount + result.Returned
+	}
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// stackedShoe returns a shoe whose first cards are the given ranks (all Spades).
+func stackedShoe(ranks ...model.Rank) *model.Shoe {
+	shoe := model.NewShoe(1, 0)
+	for i, r := range ranks {
+		shoe.Cards[i] = model.Card{Suit: model.Spades, Rank: r}
+	}
+	return shoe
+}
+
+func TestResolveRoundNatural(t *testing.T) {
+	// Player: 9, K (9). Banker: 2, 3 (5).
+	shoe := stackedShoe(model.Nine, model.Two, model.King, model.Three)
+	r, err := ResolveRound(shoe, rules.EZ, map[rules.BetType]int{rules.Player: 100, rules.Tie: 10})
+	if err != nil {
+		t.Fatalf("ResolveRound: %v", err)
+	}
+
+	if len(r.Draws) != 4 {
+		t.Fatalf("expected 4 draws on a natural, got %d", len(r.Draws))
+	}
+	if r.Draws[3].ThirdCard || r.PlayerDecision.Hit || r.BankerDecision.Hit {
+		t.Errorf("nobody should hit on a natural")
+	}
+	if r.PlayerDecision.Reason() != "Natural 8 or 9 detected. No hits" {
+		t.Errorf("unexpected reason: %q", r.PlayerDecision.Reason())
+	}
+	if r.Outcome != rules.OutcomePlayer {
+		t.Errorf("Outcome = %v, want Player", r.Outcome)
+	}
+	if r.TotalBet != 110 || r.TotalPayout != 200 || r.NetChange() != 90 {
+		t.Errorf("TotalBet=%d TotalPayout=%d NetChange=%d", r.TotalBet, r.TotalPayout, r.NetChange())
+	}
+}
+
+func TestResolveRoundDragon7(t *testing.T) {
+	// Player: 4, 2 (6) stands. Banker: 2, 3 (5) hits and draws 2 for a 3-card 7.
+	shoe := stackedShoe(model.Four, model.Two, model.Two, model.Three, model.Two)
+	r, err := ResolveRound(shoe, rules.EZ, map[rules.BetType]int{rules.Banker: 100, rules.Dragon: 10})
+	if err != nil {
+		t.Fatalf("ResolveRound: %v", err)
+	}
+
+	wantSides := []Side{SidePlayer, SideBanker, SidePlayer, SideBanker, SideBanker}
+	if len(r.Draws) != len(wantSides) {
+		t.Fatalf("expected %d draws, got %d", len(wantSides), len(r.Draws))
+	}
+	for i, side := range wantSides {
+		if r.Draws[i].Side != side {
+			t.Errorf("draw %d went to %s, want %s", i, r.Draws[i].Side, side)
+		}
+	}
+	if r.PlayerDecision.Reason() != "Player stands on 6" {
+		t.Errorf("unexpected player reason: %q", r.PlayerDecision.Reason())
+	}
+	if r.BankerDecision.Reason() != "Banker hits on 5 (Player stood)" {
+		t.Errorf("unexpected banker reason: %q", r.BankerDecision.Reason())
+	}
+	if r.Outcome != rules.OutcomeDragon7 {
+		t.Fatalf("Outcome = %v, want Dragon 7", r.Outcome)
+	}
+	if got := r.Payouts[rules.Banker].NetChange(100); got != 0 {
+		t.Errorf("Banker bet should push on Dragon 7, net %d", got)
+	}
+	if got := r.Payouts[rules.Dragon].NetChange(10); got != 400 {
+		t.Errorf("Dragon bet should win 400, net %d", got)
+	}
+}
+
+func TestResolveRoundEmptyShoe(t *testing.T) {
+	shoe := model.NewShoe(1, 0)
+	for shoe.CardsLeft() > 3 {
+		_, _ = shoe.Draw()
+	}
+	if _, err := ResolveRound(shoe, rules.EZ, nil); err != model.ErrShoeEmpty {
+		t.Errorf("expected ErrShoeEmpty, got %v", err)
+	}
+}
+
+func TestRenderRound(t *testing.T) {
+	shoe := stackedShoe(model.Four, model.Two, model.Two, model.Three, model.Two)
+	r, _ := ResolveRound(shoe, rules.EZ, map[rules.BetType]int{rules.Banker: 100})
+
+	var buf bytes.Buffer
+	RenderRound(&buf, r)
+	out := buf.String()
+	for _, want := range []string{
+		"[Action] Player stands on 6.",
+		"[Action] Banker hits on 5 (Player stood) and draws: 2♠",
+		">>> [Outcome]: Dragon 7 Wins! <<<",
+		"Banker Bet ($100): PUSH",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestResolveRoundPairBets(t *testing.T) {
+	// Player: 3, 3 (6) stands. Banker: 4, 3 (7) stands. Player has a pair.
+	shoe := stackedShoe(model.Three, model.Four, model.Three, model.Three)
+	shoe.Cards[2].Suit = model.Hearts
+	bets := map[rules.BetType]int{rules.Banker: 100, rules.PlayerPair: 10, rules.BankerPair: 10, rules.PerfectPair: 10}
+	r, err := ResolveRound(shoe, rules.EZ, bets)
+	if err != nil {
+		t.Fatalf("ResolveRound: %v", err)
+	}
+
+	want := map[rules.BetType]int{rules.Banker: 100, rules.PlayerPair: 110, rules.BankerPair: -10, rules.PerfectPair: -10}
+	for bType, net := range want {
+		if got := r.Payouts[bType].NetChange(bets[bType]); got != net {
+			t.Errorf("%s net = %d, want %d", bType, got, net)
+		}
+	}
+	if r.NetChange() != 190 {
+		t.Errorf("NetChange = %d, want 190", r.NetChange())
+	}
+}
@@ -0,0 +1,217 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/stats"
+	"github.com/niubaoshu/es-Baccarat/backend/strategy"
+)
+
+// SessionConfig describes the sessions played by RunSessions.
+type SessionConfig struct {
+	Bankroll int // starting bankroll of every session
+	MaxHands int // a session that survives this many hands ends
+}
+
+// SessionStats holds the results of many independent sessions of one strategy.
+// The per-session slices are sorted in ascending order.
+type SessionStats struct {
+	Strategy string
+	Config   SessionConfig
+	Sessions int
+	// Ruined counts the sessions that ended because the bankroll could no
+	// longer cover a valid bet.
+	Ruined         int
+	Hands          []int
+	FinalBankrolls []int
+	MaxDrawdowns   []int // largest fall from a bankroll peak, per session
+	Duration       time.Duration
+}
+
+// sessionResult is the outcome of a single session.
+type sessionResult struct {
+	hands, bankroll, maxDrawdown int
+	ruined                       bool
+}
+
+// RunSessions plays the given number of independent sessions of a strategy, each
+// from a freshly shuffled shoe, and collects the results. Bets proposed by the
+// strategy are capped to the table limits and the bankroll. It fails if the
+// shoe settings are invalid or the strategy's opening bets are not valid at the
+// table.
+func RunSessions(cfg *config.GameConfig, newStrategy strategy.Factory, sc SessionConfig, sessions int, numWorkers int) (*SessionStats, error) {
+	start := time.Now()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	opening := newStrategy()
+	if err := cfg.Limits.Validate(cfg.Rules, opening.NextBets(strategy.State{Bankroll: sc.Bankroll}), sc.Bankroll); err != nil {
+		return nil, fmt.Errorf("%s strategy: %w", opening.Name(), err)
+	}
+
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+	if sessions < numWorkers {
+		numWorkers = max(sessions, 1)
+	}
+
+	var wg sync.WaitGroup
+	resultsCh := make(chan []sessionResult, numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		n := sessions / numWorkers
+		if w == 0 {
+			n += sessions % numWorkers // First worker takes the remainder
+		}
+
+		wg.Add(1)
+		go func(worker, n int) {
+			defer wg.Done()
+			rng := newSimulationRandomizer(cfg, worker)
+			strat := newStrategy()
+			results := make([]sessionResult, 0, n)
+			var history []strategy.Hand
+			for i := 0; i < n; i++ {
+				var res sessionResult
+				res, history = playSession(cfg, rng, strat, sc, history[:0])
+				results = append(results, res)
+			}
+			resultsCh <- results
+		}(w, n)
+	}
+	wg.Wait()
+	close(resultsCh)
+
+	s := &SessionStats{Strategy: opening.Name(), Config: sc, Sessions: sessions}
+	for results := range resultsCh {
+		for _, r := range results {
+			if r.ruined {
+				s.Ruined++
+			}
+			s.Hands = append(s.Hands, r.hands)
+			s.FinalBankrolls = append(s.FinalBankrolls, r.bankroll)
+			s.MaxDrawdowns = append(s.MaxDrawdowns, r.maxDrawdown)
+		}
+	}
+	slices.Sort(s.Hands)
+	slices.Sort(s.FinalBankrolls)
+	slices.Sort(s.MaxDrawdowns)
+	s.Duration = time.Since(start)
+	return s, nil
+}
+
+// playSession plays one session and returns its result along with the hand
+// history, whose backing array the caller may reuse.
+func playSession(cfg *config.GameConfig, rng model.Randomizer, strat strategy.Strategy, sc SessionConfig, history []strategy.Hand) (sessionResult, []strategy.Hand) {
+	shoe := newSimulationShoe(cfg, rng)
+	strat.Reset()
+
+	bankroll, peak := sc.Bankroll, sc.Bankroll
+	res := sessionResult{}
+	for res.hands < sc.MaxHands {
+		proposed := strat.NextBets(strategy.State{Bankroll: bankroll, History: history})
+		if proposed == nil {
+			break
+		}
+		// The opening bets were valid, so a rejection after capping means the
+		// bankroll can no longer reach the table minimum.
+		bets := capBets(cfg.Limits, proposed, bankroll)
+		if err := cfg.Limits.Validate(cfg.Rules, bets, bankroll); err != nil {
+			res.ruined = true
+			break
+		}
+
+		if shoe.IsPastCutCard() {
+			shoe = newSimulationShoe(cfg, rng)
+		}
+		round, err := ResolveRound(shoe, cfg.Rules, bets)
+		if err != nil {
+			// The shoe ran out mid-hand: the hand is void and the same bets
+			// are dealt again from a new shoe, without asking the strategy,
+			// which would count the previous hand a second time.
+			shoe = newSimulationShoe(cfg, rng)
+			if round, err = ResolveRound(shoe, cfg.Rules, bets); err != nil {
+				break // cannot happen: a valid shoe deals a hand after its burn
+			}
+		}
+
+		bankroll += round.NetChange()
+		peak = max(peak, bankroll)
+		res.maxDrawdown = max(res.maxDrawdown, peak-bankroll)
+		res.hands++
+		history = append(history, strategy.Hand{Bets: bets, Outcome: round.Outcome, Net: round.NetChange()})
+	}
+	res.bankroll = bankroll
+	return res, history
+}
+
+// newSimulationShoe shuffles a fresh shoe, places its cut card and burns it.
+func newSimulationShoe(cfg *config.GameConfig, rng model.Randomizer) *model.Shoe {
+	shoe := model.NewShoe(cfg.DecksCount, cfg.CutCardThreshold)
+	shoe.SetRandomizer(rng)
+	shoe.Shuffle()
+	_ = prepareShoe(cfg, shoe)
+	return shoe
+}
+
+// capBets lowers each bet to its table maximum, rounded down to the betting unit,
+// while keeping the total within the table maximum and the bankroll. Bets that
+// drop to zero are removed.
+func capBets(limits rules.TableLimits, bets map[rules.BetType]int, bankroll int) map[rules.BetType]int {
+	available := bankroll
+	if limits.TableMax > 0 {
+		available = min(available, limits.TableMax)
+	}
+
+	capped := make(map[rules.BetType]int, len(bets))
+	for _, bType := range sortedBetTypes(bets) {
+		amt := min(bets[bType], available)
+		if m := limits.MaxBet[bType]; m > 0 {
+			amt = min(amt, m)
+		}
+		if limits.BetUnit > 1 {
+			amt -= amt % limits.BetUnit
+		}
+		if amt > 0 {
+			capped[bType] = amt
+			available -= amt
+		}
+	}
+	return capped
+}
+
+// RiskOfRuin returns the fraction of sessions that went broke.
+func (s *SessionStats) RiskOfRuin() float64 {
+	return float64(s.Ruined) / float64(s.Sessions)
+}
+
+// PrintSessionReport writes a comparison table of strategy session results.
+func PrintSessionReport(w io.Writer, results []*SessionStats) {
+	if len(results) == 0 {
+		return
+	}
+	sc := results[0].Config
+	fmt.Fprintf(w, "\n=== Strategy Sessions ===\n")
+	fmt.Fprintf(w, "Sessions:     %d per strategy\n", results[0].Sessions)
+	fmt.Fprintf(w, "Bankroll:     $%d, at most %d hands per session\n\n", sc.Bankroll, sc.MaxHands)
+
+	fmt.Fprintf(w, "%-12s | %8s | %8s | %10s | %10s | %10s | %10s | %10s\n",
+		"Strategy", "Ruin %", "Hands", "Final P10", "Final P50", "Final P90", "DD P50", "DD Max")
+	fmt.Fprintln(w, "-----------------------------------------------------------------------------------------------")
+	for _, s := range results {
+		fmt.Fprintf(w, "%-12s | %7.2f%% | %8d | %10d | %10d | %10d | %10d | %10d\n",
+			s.Strategy, s.RiskOfRuin()*100, stats.Percentile(s.Hands, 50),
+			stats.Percentile(s.FinalBankrolls, 10), stats.Percentile(s.FinalBankrolls, 50), stats.Percentile(s.FinalBankrolls, 90),
+			stats.Percentile(s.MaxDrawdowns, 50), stats.Percentile(s.MaxDrawdowns, 100))
+	}
+	fmt.Fprintln(w, "===============================================================================================")
+	fmt.Fprintln(w, "Hands is the median session length. Final and DD (max drawdown) are bankroll percentiles in $.")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/strategy"
+)
+
+func TestRunSessionsDeterministic(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 7
+	factory, _ := strategy.Builtin("martingale", rules.Banker, 100)
+	sc := SessionConfig{Bankroll: 2000, MaxHands: 200}
+
+	a, err := RunSessions(cfg, factory, sc, 40, 3)
+	if err != nil {
+		t.Fatalf("RunSessions: %v", err)
+	}
+	b, _ := RunSessions(cfg, factory, sc, 40, 3)
+
+	if a.Strategy != "martingale" || a.Sessions != 40 || len(a.Hands) != 40 {
+		t.Fatalf("unexpected stats: %+v", a)
+	}
+	if a.Ruined != b.Ruined || !slices.Equal(a.FinalBankrolls, b.FinalBankrolls) || !slices.Equal(a.MaxDrawdowns, b.MaxDrawdowns) {
+		t.Errorf("seeded sessions should be reproducible")
+	}
+	for i, hands := range a.Hands {
+		if hands > sc.MaxHands {
+			t.Errorf("session %d played %d hands, limit %d", i, hands, sc.MaxHands)
+		}
+	}
+	if a.Ruined == 0 || a.FinalBankrolls[0] != 0 {
+		t.Errorf("a $2000 Martingale at $100 should go broke in some of 40 sessions")
+	}
+}
+
+func TestRunSessionsRuinAtTableMinimum(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 1
+	cfg.Limits.MinBet = map[rules.BetType]int{rules.Banker: 100}
+	factory, _ := strategy.Builtin("flat", rules.Banker, 100)
+
+	// Every session ends once the bankroll falls below the $100 minimum.
+	s, err := RunSessions(cfg, factory, SessionConfig{Bankroll: 300, MaxHands: 100000}, 20, 2)
+	if err != nil {
+		t.Fatalf("RunSessions: %v", err)
+	}
+	if s.Ruined != 20 || s.RiskOfRuin() != 1 {
+		t.Errorf("Ruined = %d, want all 20", s.Ruined)
+	}
+	for _, b := range s.FinalBankrolls {
+		if b >= 100 {
+			t.Errorf("ruined session ended with $%d", b)
+		}
+	}
+}
+
+func TestRunSessionsWithoutCutCard(t *testing.T) {
+	// Dealing to the last card leaves hands the shoe runs out in the middle
+	// of; they are dealt again from a new shoe and the session carries on.
+	cfg := config.DefaultConfig()
+	cfg.Seed, cfg.DecksCount, cfg.CutCardThreshold = 3, 1, 0
+	sc := SessionConfig{Bankroll: 1000000, MaxHands: 2000}
+
+	for _, name := range []string{"flat", "martingale"} {
+		factory, _ := strategy.Builtin(name, rules.Player, 10)
+		s, err := RunSessions(cfg, factory, sc, 20, 2)
+		if err != nil {
+			t.Fatalf("%s: RunSessions: %v", name, err)
+		}
+		if s.Ruined != 0 {
+			t.Errorf("%s: Ruined = %d, want 0", name, s.Ruined)
+		}
+		for i, hands := range s.Hands {
+			if hands != sc.MaxHands {
+				t.Errorf("%s: session %d played %d hands, want %d", name, i, hands, sc.MaxHands)
+			}
+		}
+
+		// The strategy is asked once per hand played: a void hand is dealt
+		// again with the same bets rather than counting the last hand twice.
+		counted := &countingStrategy{Strategy: factory()}
+		res, history := playSession(cfg, newSimulationRandomizer(cfg, 0), counted, sc, nil)
+		if counted.calls != res.hands || len(history) != res.hands {
+			t.Errorf("%s: NextBets called %d times for %d hands", name, counted.calls, res.hands)
+		}
+		if name == "martingale" {
+			for i := 1; i < len(history); i++ {
+				want := 10
+				if history[i-1].Net < 0 {
+					want = history[i-1].Bets[rules.Player] * 2
+				} else if history[i-1].Net == 0 {
+					want = history[i-1].Bets[rules.Player]
+				}
+				if got := history[i].Bets[rules.Player]; got != want {
+					t.Fatalf("hand %d staked %d after %+v, want %d", i, got, history[i-1], want)
+				}
+			}
+		}
+	}
+}
+
+// countingStrategy counts the calls to NextBets.
+type countingStrategy struct {
+	strategy.Strategy
+	calls int
+}
+
+func (c *countingStrategy) NextBets(s strategy.State) map[rules.BetType]int {
+	c.calls++
+	return c.Strategy.NextBets(s)
+}
+
+func TestRunSessionsInvalidStrategy(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Rules = rules.Classic
+	factory, _ := strategy.Builtin("flat", rules.Panda, 10)
+	if _, err := RunSessions(cfg, factory, SessionConfig{Bankroll: 1000, MaxHands: 10}, 5, 1); !errors.Is(err, rules.ErrBetNotOffered) {
+		t.Errorf("expected ErrBetNotOffered, got %v", err)
+	}
+}
+
+func TestCapBets(t *testing.T) {
+	limits := rules.TableLimits{
+		MaxBet:   map[rules.BetType]int{rules.Banker: 1000},
+		TableMax: 1200,
+		BetUnit:  25,
+	}
+	tests := []struct {
+		name     string
+		bets     map[rules.BetType]int
+		bankroll int
+		want     map[rules.BetType]int
+	}{
+		{"Within limits", map[rules.BetType]int{rules.Banker: 500}, 5000, map[rules.BetType]int{rules.Banker: 500}},
+		{"Bet maximum", map[rules.BetType]int{rules.Banker: 6400}, 50000, map[rules.BetType]int{rules.Banker: 1000}},
+		{"Bankroll and unit", map[rules.BetType]int{rules.Banker: 800}, 640, map[rules.BetType]int{rules.Banker: 625}},
+		{"Table maximum", map[rules.BetType]int{rules.Banker: 1000, rules.Tie: 500}, 50000, map[rules.BetType]int{rules.Banker: 1000, rules.Tie: 200}},
+		{"Broke", map[rules.BetType]int{rules.Banker: 100}, 10, map[rules.BetType]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := capBets(limits, tt.bets, tt.bankroll); !maps.Equal(got, tt.want) {
+				t.Errorf("capBets = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
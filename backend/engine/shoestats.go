@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"github.com/niubaoshu/es-Baccarat/backend/roadmap"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/stats"
+)
+
+// ShoeStatsBucketCards is the width, in cards, of the cards-left buckets of
+// ShoeStats: half a deck.
+const ShoeStatsBucketCards = 26
+
+// ShoeStats are the per-shoe and positional histograms of a simulation. Only
+// shoes dealt to the cut card count towards HandsPerShoe and the streaks; the
+// shoe a worker was dealing when the run ended is left out of them.
+type ShoeStats struct {
+	Shoes int `json:"shoes"`
+	// HandsPerShoe[n] is the number of shoes that yielded n hands.
+	HandsPerShoe stats.Histogram `json:"hands_per_shoe"`
+	// BurnCounts[n] is the number of shoes whose burn discarded n cards, not
+	// counting the card turned face up under the face value rule.
+	BurnCounts stats.Histogram `json:"burn_counts"`
+	// ByCardsLeft buckets the rounds by the cards left in the shoe when they
+	// were dealt, from a full shoe down to the cut card.
+	ByCardsLeft []PositionBucket `json:"by_cards_left"`
+	// BankerStreaks[n] and PlayerStreaks[n] count runs of n consecutive wins of
+	// one side within a shoe. As on the Big Road, ties do not break a run, and
+	// Dragon 7 and Panda 8 are Banker and Player wins.
+	BankerStreaks stats.Histogram `json:"banker_streaks"`
+	PlayerStreaks stats.Histogram `json:"player_streaks"`
+}
+
+// PositionBucket counts the outcomes of the rounds dealt with between
+// CardsLeftMin and CardsLeftMax cards left in the shoe.
+type PositionBucket struct {
+	CardsLeftMin int                   `json:"cards_left_min"`
+	CardsLeftMax int                   `json:"cards_left_max"`
+	Rounds       int                   `json:"rounds"`
+	OutcomeCount map[rules.Outcome]int `json:"outcomes"`
+}
+
+// Share returns the fraction of the bucket's rounds that ended in o.
+func (b PositionBucket) Share(o rules.Outcome) float64 {
+	if b.Rounds == 0 {
+		return 0
+	}
+	return float64(b.OutcomeCount[o]) / float64(b.Rounds)
+}
+
+// Streak sides, indexed by position in reportOutcomes.
+const (
+	streakNone int8 = iota // a tie, which neither extends nor breaks a run
+	streakBanker
+	streakPlayer
+)
+
+var streakSides = func() (sides [len(reportOutcomes)]int8) {
+	for i, o := range reportOutcomes {
+		switch {
+		case o == rules.OutcomeTie:
+			sides[i] = streakNone
+		case roadmap.Mark{Outcome: o}.Banker():
+			sides[i] = streakBanker
+		default:
+			sides[i] = streakPlayer
+		}
+	}
+	return sides
+}()
+
+// shoeTally collects ShoeStats for a simCore.
+type shoeTally struct {
+	shoeSize     int
+	started      bool // a shoe is being dealt
+	shoes        int
+	hands        int // dealt from the current shoe
+	handsPerShoe stats.Histogram
+	burns        stats.Histogram
+	byCardsLeft  [][len(reportOutcomes)]int
+
+	side                         int8 // of the current run
+	run                          int
+	bankerStreaks, playerStreaks stats.Histogram
+}
+
+func newShoeTally(shoeSize int) *shoeTally {
+	return &shoeTally{shoeSize: shoeSize, byCardsLeft: make([][len(reportOutcomes)]int, shoeSize/ShoeStatsBucketCards+1)}
+}
+
+// round records a round with the given outcome, dealt with cardsLeft cards left.
+func (t *shoeTally) round(outcome uint8, cardsLeft int) {
+	t.hands++
+	t.byCardsLeft[cardsLeft/ShoeStatsBucketCards][outcome]++
+	switch side := streakSides[outcome]; {
+	case side == streakNone:
+	case side == t.side:
+		t.run++
+	default:
+		t.endRun()
+		t.side, t.run = side, 1
+	}
+}
+
+func (t *shoeTally) endRun() {
+	switch t.side {
+	case streakBanker:
+		t.bankerStreaks.Add(t.run, 1)
+	case streakPlayer:
+		t.playerStreaks.Add(t.run, 1)
+	}
+	t.side, t.run = streakNone, 0
+}
+
+// endShoe records the shoe just dealt to the cut card, if any.
+func (t *shoeTally) endShoe() {
+	if !t.started {
+		return
+	}
+	t.shoes++
+	t.handsPerShoe.Add(t.hands, 1)
+	t.hands = 0
+	t.endRun()
+}
+
+// newShoe starts a shoe, abandoning the partial counts of any shoe that was
+// not dealt to the cut card.
+func (t *shoeTally) newShoe(burned int) {
+	t.burns.Add(burned, 1)
+	t.started, t.hands = true, 0
+	t.side, t.run = streakNone, 0
+}
+
+func (t *shoeTally) merge(o *shoeTally) {
+	t.shoes += o.shoes
+	t.handsPerShoe.Merge(o.handsPerShoe)
+	t.burns.Merge(o.burns)
+	for i := range o.byCardsLeft {
+		for j, n := range o.byCardsLeft[i] {
+			t.byCardsLeft[i][j] += n
+		}
+	}
+	t.bankerStreaks.Merge(o.bankerStreaks)
+	t.playerStreaks.Merge(o.playerStreaks)
+}
+
+// stats exports the tally, with the buckets ordered from a full shoe down and
+// the empty ones left out.
+func (t *shoeTally) stats() *ShoeStats {
+	s := &ShoeStats{
+		Shoes:         t.shoes,
+		HandsPerShoe:  t.handsPerShoe,
+		BurnCounts:    t.burns,
+		BankerStreaks: t.bankerStreaks,
+		PlayerStreaks: t.playerStreaks,
+	}
+	for i := len(t.byCardsLeft) - 1; i >= 0; i-- {
+		b := PositionBucket{
+			CardsLeftMin: i * ShoeStatsBucketCards,
+			CardsLeftMax: min((i+1)*ShoeStatsBucketCards-1, t.shoeSize),
+			OutcomeCount: make(map[rules.Outcome]int),
+		}
+		for j, n := range t.byCardsLeft[i] {
+			if n > 0 {
+				b.Rounds += n
+				b.OutcomeCount[reportOutcomes[j]] += n
+			}
+		}
+		if b.Rounds > 0 {
+			s.ByCardsLeft = append(s.ByCardsLeft, b)
+		}
+	}
+	return s
+}
@@ -0,0 +1,316 @@
+package engine
+
+import (
+	"slices"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// simCard is a card packed into a byte as rank<<2 | suit, so that a whole shoe
+// fits in a few cache lines and pairs compare as plain integers.
+type simCard uint8
+
+func packCard(c model.Card) simCard {
+	return simCard(c.Rank)<<2 | simCard(c.Suit)
+}
+
+func (c simCard) card() model.Card {
+	return model.Card{Suit: model.Suit(c & 3), Rank: model.Rank(c >> 2)}
+}
+
+// numHandClasses is the number of hand classes: both totals, whether each side
+// drew a third card, and whether each side opened with no pair, a pair or a
+// suited pair. That is everything the rule sets settle a bet on, so every
+// round of a class pays the same.
+const numHandClasses = 10 * 10 * 2 * 2 * 3 * 3
+
+// playerStood indexes simTables.bankerDraws when the Player took no third card.
+const playerStood = 10
+
+// simTables holds the drawing rules and card values as lookup tables. They are
+// derived from the rules package rather than restated.
+type simTables struct {
+	points [model.King<<2 + 4]uint8
+	burn   [model.King<<2 + 4]uint8
+
+	// playerDraws is indexed by the two-card Player and Banker totals.
+	playerDraws [10][10]bool
+	// bankerDraws is indexed by the two-card totals and the point value of the
+	// Player's third card, or playerStood.
+	bankerDraws [10][10][11]bool
+
+	// outcome is the index in reportOutcomes of the outcome of each hand class.
+	outcome [numHandClasses]uint8
+}
+
+var simTab = newSimTables()
+
+func newSimTables() *simTables {
+	t := &simTables{}
+	for r := model.Ace; r <= model.King; r++ {
+		for s := model.Spades; s <= model.Clubs; s++ {
+			c := model.Card{Suit: s, Rank: r}
+			t.points[packCard(c)] = uint8(c.PointValue())
+			t.burn[packCard(c)] = uint8(model.BurnCount(c))
+		}
+	}
+
+	// pointCard returns a card worth p points.
+	pointCard := func(p int) model.Card {
+		if p == 0 {
+			return model.Card{Rank: model.Ten}
+		}
+		return model.Card{Rank: model.Rank(p)}
+	}
+	hand := func(cards ...model.Card) *model.Hand {
+		return &model.Hand{Cards: cards}
+	}
+	for pp := 0; pp < 10; pp++ {
+		for bp := 0; bp < 10; bp++ {
+			player, banker := hand(pointCard(0), pointCard(pp)), hand(pointCard(0), pointCard(bp))
+			t.playerDraws[pp][bp] = rules.DeterminePlayerHit(player, banker)
+			t.bankerDraws[pp][bp][playerStood] = rules.DetermineBankerHit(banker, player, false, nil)
+			for third := 0; third < 10; third++ {
+				c := pointCard(third)
+				drawn := hand(pointCard(0), pointCard(pp), c)
+				t.bankerDraws[pp][bp][third] = rules.DetermineBankerHit(banker, drawn, true, &c)
+			}
+		}
+	}
+
+	// A class fixes both totals and the number of cards of each side, which is
+	// all the outcome depends on.
+	for class := range t.outcome {
+		pp, bp := class/9/2/2/10, class/9/2/2%10
+		player, banker := hand(pointCard(0), pointCard(pp)), hand(pointCard(0), pointCard(bp))
+		if class/9/2%2 == 1 {
+			player.AddCard(pointCard(0))
+		}
+		if class/9%2 == 1 {
+			banker.AddCard(pointCard(0))
+		}
+		t.outcome[class] = uint8(slices.Index(reportOutcomes[:], rules.DetermineOutcome(player, banker)))
+	}
+	return t
+}
+
+// simCore deals simulation rounds from a packed shoe that is reshuffled in
+// place, and tallies them by hand class. Dealing a round neither allocates nor
+// settles bets: every class is settled once, from the first hand dealt in it,
+// when the tally is read.
+type simCore struct {
+	cards        []simCard
+	pos          int
+	cut          int // cards left behind the cut card of the current shoe
+	cutLo, cutHi int
+	burn         model.BurnRule
+	rng          model.Randomizer
+
+	// shoe, if not nil, collects per-shoe and positional histograms.
+	shoe *shoeTally
+
+	counts [numHandClasses]int
+	// first holds the Player and Banker cards of the first round of each class.
+	first [numHandClasses][6]simCard
+}
+
+// newSimCore returns a core dealing shoes of cfg shuffled with rng, with the
+// cut card and burn of cfg. The first shoe is not shuffled yet.
+func newSimCore(cfg *config.GameConfig, rng model.Randomizer) *simCore {
+	c := &simCore{cards: make([]simCard, 0, cfg.DecksCount*52), burn: cfg.Burn, rng: rng}
+	c.cutLo, c.cutHi = cfg.CutCardRange()
+	for _, card := range model.NewShoe(cfg.DecksCount, 0).Cards {
+		c.cards = append(c.cards, packCard(card))
+	}
+	c.pos = len(c.cards)
+	return c
+}
+
+// load replaces the shoe with cards, dealing from index pos onwards until cut
+// cards are left.
+func (c *simCore) load(cards []model.Card, pos, cut int) {
+	c.cards = c.cards[:0]
+	for _, card := range cards {
+		c.cards = append(c.cards, packCard(card))
+	}
+	c.pos, c.cut = pos, cut
+}
+
+// pastCutCard mirrors model.Shoe.IsPastCutCard.
+func (c *simCore) pastCutCard() bool {
+	return len(c.cards)-c.pos <= c.cut
+}
+
+// shuffle reshuffles the shoe in place, places the cut card and burns,
+// drawing from rng exactly as model.Shoe.Shuffle, PlaceCutCard and Burn do.
+func (c *simCore) shuffle() {
+	cards := c.cards
+	for i := len(cards) - 1; i > 0; i-- {
+		j := c.rng.Intn(i + 1)
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+
+	c.cut = c.cutLo
+	if c.cutHi > c.cutLo {
+		c.cut += c.rng.Intn(c.cutHi - c.cutLo + 1)
+	}
+
+	burned := 0
+	switch c.burn.Mode {
+	case model.BurnNone:
+		c.pos = 0
+	case model.BurnFixed:
+		burned = c.burn.Count
+		c.pos = min(burned, len(cards))
+	default:
+		if len(cards) > 0 {
+			burned = int(simTab.burn[cards[0]])
+			c.pos = min(1+burned, len(cards))
+		}
+	}
+	if c.shoe != nil {
+		c.shoe.newShoe(burned)
+	}
+}
+
+// play deals rounds complete rounds, reshuffling at the cut card. A hand the
+// shoe runs out in the middle of, which a cut card close to the end allows, is
+// void: it is not counted and the next hand is dealt from a new shoe.
+func (c *simCore) play(rounds int) {
+	for i := 0; i < rounds; {
+		if c.pastCutCard() {
+			if c.shoe != nil {
+				c.shoe.endShoe()
+			}
+			c.shuffle()
+		}
+		if c.round() {
+			i++
+		}
+	}
+}
+
+// round deals one hand and counts its class. Like ResolveRound, it reports
+// false and counts nothing when the shoe runs out mid-hand.
+func (c *simCore) round() bool {
+	t, cards, pos := simTab, c.cards, c.pos
+	if len(cards)-pos < 4 {
+		c.pos = len(cards)
+		return false
+	}
+	cardsLeft := len(cards) - pos
+	p0, b0, p1, b1 := cards[pos], cards[pos+1], cards[pos+2], cards[pos+3]
+	pos += 4
+	pp := (t.points[p0] + t.points[p1]) % 10
+	bp := (t.points[b0] + t.points[b1]) % 10
+
+	var p2, b2 simCard
+	third, pDrew, bDrew := playerStood, 0, 0
+	if t.playerDraws[pp][bp] {
+		if pos == len(cards) {
+			c.pos = pos
+			return false
+		}
+		p2, pos, pDrew = cards[pos], pos+1, 1
+		third = int(t.points[p2])
+	}
+	if t.bankerDraws[pp][bp][third] {
+		if pos == len(cards) {
+			c.pos = pos
+			return false
+		}
+		b2, pos, bDrew = cards[pos], pos+1, 1
+		bp = (bp + t.points[b2]) % 10
+	}
+	if pDrew == 1 {
+		pp = (pp + uint8(third)) % 10
+	}
+	c.pos = pos
+
+	class := ((((int(pp)*10+int(bp))*2+pDrew)*2+bDrew)*3+pairKind(p0, p1))*3 + pairKind(b0, b1)
+	if c.counts[class] == 0 {
+		c.first[class] = [6]simCard{p0, p1, p2, b0, b1, b2}
+	}
+	c.counts[class]++
+	if c.shoe != nil {
+		c.shoe.round(simTab.outcome[class], cardsLeft)
+	}
+	return true
+}
+
+// pairKind is 0 for no pair, 1 for a pair and 2 for a suited pair.
+func pairKind(a, b simCard) int {
+	switch {
+	case a == b:
+		return 2
+	case a>>2 == b>>2:
+		return 1
+	}
+	return 0
+}
+
+// merge adds the tally of o to c.
+func (c *simCore) merge(o *simCore) {
+	switch {
+	case o.shoe == nil:
+	case c.shoe == nil:
+		c.shoe = o.shoe
+	default:
+		c.shoe.merge(o.shoe)
+	}
+	for class, n := range o.counts {
+		if n == 0 {
+			continue
+		}
+		if c.counts[class] == 0 {
+			c.first[class] = o.first[class]
+		}
+		c.counts[class] += n
+	}
+}
+
+// outcomes returns the number of rounds of each of reportOutcomes dealt so far.
+func (c *simCore) outcomes() (n [len(reportOutcomes)]int) {
+	for class, count := range c.counts {
+		n[simTab.outcome[class]] += count
+	}
+	return n
+}
+
+// hands rebuilds the first Player and Banker hands dealt in a class.
+func (c *simCore) hands(class int) (player, banker *model.Hand) {
+	first := &c.first[class]
+	pCards, bCards := 2+class/9/2%2, 2+class/9%2
+	player, banker = &model.Hand{}, &model.Hand{}
+	for _, sc := range first[:pCards] {
+		player.AddCard(sc.card())
+	}
+	for _, sc := range first[3 : 3+bCards] {
+		banker.AddCard(sc.card())
+	}
+	return player, banker
+}
+
+// tally settles every dealt class under rs, with a SimulationBetUnit stake on
+// each bet type, and returns the outcome counts and per-bet net results.
+func (c *simCore) tally(rs rules.RuleSet) (counts map[rules.Outcome]int, net, netSq map[rules.BetType]int) {
+	counts = make(map[rules.Outcome]int)
+	net = make(map[rules.BetType]int)
+	netSq = make(map[rules.BetType]int)
+	betTypes := rs.BetTypes()
+	for class, n := range c.counts {
+		if n == 0 {
+			continue
+		}
+		player, banker := c.hands(class)
+		counts[rules.DetermineOutcome(player, banker)] += n
+		for _, bet := range betTypes {
+			v := rs.Payout(player, banker, bet, SimulationBetUnit).NetChange(SimulationBetUnit)
+			net[bet] += n * v
+			netSq[bet] += n * v * v
+		}
+	}
+	return counts, net, netSq
+}
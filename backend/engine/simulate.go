@@ -1,41 +1,155 @@
 package engine
 
 import (
-	"fmt"
+	"context"
 	"sync"
 	"time"
 
 	"github.com/niubaoshu/es-Baccarat/backend/config"
-	"github.com/niubaoshu/es-Baccarat/backend/model"
 	"github.com/niubaoshu/es-Baccarat/backend/rules"
 )
 
+// SimulationBetUnit is the stake, in cents, placed on every bet type each round
+// so that commissions and fractional payouts are settled exactly.
+const SimulationBetUnit = 100
+
 // SimulationStats holds the aggregated results of a simulation run.
 type SimulationStats struct {
-	TotalRounds  int
+	DecksCount int
+	Rules      rules.RuleSet
+	// TotalRounds is the number of rounds dealt, fewer than requested when
+	// Interrupted.
+	TotalRounds int
+	// Interrupted is set when the run was canceled before dealing every round.
+	Interrupted  bool
+	OutcomeCount map[rules.Outcome]int
+	// BetNet is the total net result, in cents, of a SimulationBetUnit bet on each
+	// bet type offered by Rules in every round.
+	BetNet map[rules.BetType]int
+	// BetNetSq is the sum over every round of the squared net result, in
+	// cents², of each bet, from which the spread of BetNet is estimated.
+	BetNetSq map[rules.BetType]int
+	// Shoe holds the per-shoe and positional histograms, when collected.
+	Shoe     *ShoeStats
+	Duration time.Duration
+}
+
+// SimulationProgressInterval is how often RunSimulation reports progress.
+const SimulationProgressInterval = 500 * time.Millisecond
+
+// simulationChunk is how many rounds a worker deals between progress updates
+// and cancellation checks: about a millisecond of work.
+const simulationChunk = 1 << 14
+
+// SimulationProgress is a snapshot of a running simulation.
+type SimulationProgress struct {
+	Rounds       int // rounds dealt so far
+	TotalRounds  int // rounds requested
+	Elapsed      time.Duration
 	OutcomeCount map[rules.Outcome]int
-	Duration     time.Duration
+}
+
+// RoundsPerSecond returns the average dealing rate so far.
+func (p SimulationProgress) RoundsPerSecond() float64 {
+	return float64(p.Rounds) / p.Elapsed.Seconds()
+}
+
+// ETA estimates the time left at the average rate so far, or 0 before the
+// first round.
+func (p SimulationProgress) ETA() time.Duration {
+	if p.Rounds == 0 {
+		return 0
+	}
+	return time.Duration(float64(p.Elapsed) * float64(p.TotalRounds-p.Rounds) / float64(p.Rounds))
+}
+
+// Share returns the fraction of the rounds dealt so far that ended in o.
+func (p SimulationProgress) Share(o rules.Outcome) float64 {
+	if p.Rounds == 0 {
+		return 0
+	}
+	return float64(p.OutcomeCount[o]) / float64(p.Rounds)
+}
+
+// simulationTracker collects the latest tally of every worker for progress
+// snapshots.
+type simulationTracker struct {
+	mu       sync.Mutex
+	start    time.Time
+	total    int
+	rounds   []int
+	outcomes [][len(reportOutcomes)]int
+}
+
+// update records that worker has dealt rounds rounds, with the given outcomes.
+func (t *simulationTracker) update(worker, rounds int, outcomes [len(reportOutcomes)]int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rounds[worker], t.outcomes[worker] = rounds, outcomes
+}
+
+func (t *simulationTracker) snapshot() SimulationProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p := SimulationProgress{TotalRounds: t.total, Elapsed: time.Since(t.start), OutcomeCount: make(map[rules.Outcome]int)}
+	for w, n := range t.rounds {
+		p.Rounds += n
+		for i, count := range t.outcomes[w] {
+			if count > 0 {
+				p.OutcomeCount[reportOutcomes[i]] += count
+			}
+		}
+	}
+	return p
+}
+
+// SimulationOptions tune RunSimulation.
+type SimulationOptions struct {
+	// Workers is the number of goroutines dealing rounds (at least 1).
+	Workers int
+	// Progress, if not nil, is called every SimulationProgressInterval, and
+	// once more when the run ends, from a single goroutine.
+	Progress func(SimulationProgress)
+	// ShoeStats collects SimulationStats.Shoe, at some cost in speed.
+	ShoeStats bool
 }
 
 // RunSimulation executes a fast, headless Monte Carlo simulation of Baccarat.
-func RunSimulation(cfg *config.GameConfig, totalRounds int, numWorkers int) *SimulationStats {
+// Workers deal from packed shoes reshuffled in place and tally rounds by hand
+// class; bets are settled once per class at the end, so the results are those
+// of settling every round with ResolveRound.
+//
+// When ctx is canceled the workers stop within a millisecond or so, and the
+// stats of the rounds dealt so far are returned with Interrupted set.
+func RunSimulation(ctx context.Context, cfg *config.GameConfig, totalRounds int, opts SimulationOptions) *SimulationStats {
+	return runSimulation(ctx, cfg, totalRounds, opts, SimulationProgressInterval)
+}
+
+func runSimulation(ctx context.Context, cfg *config.GameConfig, totalRounds int, opts SimulationOptions, interval time.Duration) *SimulationStats {
+	numWorkers, progress := opts.Workers, opts.Progress
 	start := time.Now()
 
 	// Adjust workers if needed
+	totalRounds = max(totalRounds, 0)
 	if numWorkers <= 0 {
 		numWorkers = 1
 	}
 	if totalRounds < numWorkers {
-		numWorkers = totalRounds
+		numWorkers = max(totalRounds, 1)
 	}
 
 	roundsPerWorker := totalRounds / numWorkers
 	remainder := totalRounds % numWorkers
 
 	var wg sync.WaitGroup
-	resultsCh := make(chan map[rules.Outcome]int, numWorkers)
-
-	fmt.Printf("Starting simulation of %d rounds using %d workers...\n", totalRounds, numWorkers)
+	resultsCh := make(chan *simCore, numWorkers)
+	rs := cfg.Rules
+	tracker := &simulationTracker{
+		start:    start,
+		total:    totalRounds,
+		rounds:   make([]int, numWorkers),
+		outcomes: make([][len(reportOutcomes)]int, numWorkers),
+	}
 
 	for w := 0; w < numWorkers; w++ {
 		wg.Add(1)
@@ -45,120 +159,70 @@ func RunSimulation(cfg *config.GameConfig, totalRounds int, numWorkers int) *Sim
 			targetRounds += remainder // First worker takes the remainder
 		}
 
-		go func(rounds int) {
+		go func(worker, rounds int) {
 			defer wg.Done()
 
-			localCounts := make(map[rules.Outcome]int)
-			shoe := model.NewShoe(cfg.DecksCount, cfg.CutCardThreshold)
-			shoe.Shuffle()
-			_ = shoe.Burn()
-
-			for i := 0; i < rounds; i++ {
-				// Re-shoe if needed
-				if shoe.IsPastCutCard() {
-					shoe = model.NewShoe(cfg.DecksCount, cfg.CutCardThreshold)
-					shoe.Shuffle()
-					_ = shoe.Burn()
-				}
-
-				// Deal
-				c1, _ := shoe.Draw()
-				c2, _ := shoe.Draw()
-				c3, _ := shoe.Draw()
-				c4, _ := shoe.Draw()
-
-				pHand := &model.Hand{Cards: []model.Card{c1, c3}}
-				bHand := &model.Hand{Cards: []model.Card{c2, c4}}
-
-				var pThird *model.Card
-				playerHit := rules.DeterminePlayerHit(pHand, bHand)
-				if playerHit {
-					c, _ := shoe.Draw()
-					pHand.AddCard(c)
-					pThird = &c
-				}
+			// Each worker shuffles from its own stream so that a fixed seed and
+			// worker count always reproduce the same results.
+			core := newSimCore(cfg, newSimulationRandomizer(cfg, worker))
+			if opts.ShoeStats {
+				core.shoe = newShoeTally(len(core.cards))
+			}
+			for done := 0; done < rounds && ctx.Err() == nil; {
+				n := min(simulationChunk, rounds-done)
+				core.play(n)
+				done += n
+				tracker.update(worker, done, core.outcomes())
+			}
+			resultsCh <- core
+		}(w, targetRounds)
+	}
 
-				bankerHit := rules.DetermineBankerHit(bHand, pHand, playerHit, pThird)
-				if bankerHit {
-					c, _ := shoe.Draw()
-					bHand.AddCard(c)
+	done := make(chan struct{})
+	var reported sync.WaitGroup
+	if progress != nil {
+		reported.Add(1)
+		go func() {
+			defer reported.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					progress(tracker.snapshot())
+				case <-done:
+					progress(tracker.snapshot())
+					return
 				}
-
-				outcome := rules.DetermineOutcome(pHand, bHand)
-				localCounts[outcome]++
 			}
-
-			resultsCh <- localCounts
-		}(targetRounds)
+		}()
 	}
 
 	wg.Wait()
 	close(resultsCh)
+	close(done)
+	reported.Wait()
 
-	finalCounts := make(map[rules.Outcome]int)
-	for res := range resultsCh {
-		for outcome, count := range res {
-			finalCounts[outcome] += count
-		}
+	var total simCore
+	for core := range resultsCh {
+		total.merge(core)
 	}
+	finalCounts, finalNet, finalNetSq := total.tally(rs)
+	played := tracker.snapshot().Rounds
 
+	var shoe *ShoeStats
+	if total.shoe != nil {
+		shoe = total.shoe.stats()
+	}
 	return &SimulationStats{
-		TotalRounds:  totalRounds,
+		DecksCount:   cfg.DecksCount,
+		Rules:        rs,
+		TotalRounds:  played,
+		Interrupted:  played < totalRounds,
 		OutcomeCount: finalCounts,
+		BetNet:       finalNet,
+		BetNetSq:     finalNetSq,
+		Shoe:         shoe,
 		Duration:     time.Since(start),
 	}
 }
-
-// PrintReport prints the statistical percentages to the console.
-func (s *SimulationStats) PrintReport() {
-	fmt.Printf("\n=== Simulation Complete ===\n")
-	fmt.Printf("Total Rounds: %d\n", s.TotalRounds)
-	fmt.Printf("Time Taken:   %s (%.0f rounds/sec)\n", s.Duration, float64(s.TotalRounds)/s.Duration.Seconds())
-	fmt.Println()
-	totalPlayerWins := s.OutcomeCount[rules.OutcomePlayer] + s.OutcomeCount[rules.OutcomePanda8]
-	totalCount := totalPlayerWins + s.OutcomeCount[rules.OutcomeBanker] + s.OutcomeCount[rules.OutcomeTie] + s.OutcomeCount[rules.OutcomeDragon7]
-
-	pPlayerSim := (float64(totalPlayerWins) / float64(s.TotalRounds)) * 100
-	pPandaSim := (float64(s.OutcomeCount[rules.OutcomePanda8]) / float64(s.TotalRounds)) * 100
-	pBankerSim := (float64(s.OutcomeCount[rules.OutcomeBanker]) / float64(s.TotalRounds)) * 100
-	pTieSim := (float64(s.OutcomeCount[rules.OutcomeTie]) / float64(s.TotalRounds)) * 100
-	pDragonSim := (float64(s.OutcomeCount[rules.OutcomeDragon7]) / float64(s.TotalRounds)) * 100
-	pTotalSim := (float64(totalCount) / float64(s.TotalRounds)) * 100
-
-	fmt.Printf("%-20s | %-12s | %-12s | %-12s\n", "Outcome", "Count", "Simulated %", "Expected %")
-	fmt.Println("------------------------------------------------------------------")
-	fmt.Printf("%-20s | %12d | %11.4f%% | %11.4f%%\n", "Player (Total)", totalPlayerWins, pPlayerSim, 44.6247)
-	fmt.Printf("%-20s | %12d | %11.4f%% | %11.4f%%\n", "  ↳ Panda 8", s.OutcomeCount[rules.OutcomePanda8], pPandaSim, 3.4543)
-	fmt.Printf("%-20s | %12d | %11.4f%% | %11.4f%%\n", "Banker (Non-Dragon)", s.OutcomeCount[rules.OutcomeBanker], pBankerSim, 43.6064)
-	fmt.Printf("%-20s | %12d | %11.4f%% | %11.4f%%\n", "Tie", s.OutcomeCount[rules.OutcomeTie], pTieSim, 9.5156)
-	fmt.Printf("%-20s | %12d | %11.4f%% | %11.4f%%\n", "Dragon 7", s.OutcomeCount[rules.OutcomeDragon7], pDragonSim, 2.2534)
-	fmt.Println("------------------------------------------------------------------")
-	fmt.Printf("%-20s | %12d | %11.4f%% | %11.4f%%\n", "Total", totalCount, pTotalSim, 100.0000)
-	fmt.Printf("==================================================================\n")
-
-	// Calculate simulated EV for $1 bets on each option
-	betProfits := map[rules.BetType]int{
-		rules.Player: 0,
-		rules.Banker: 0,
-		rules.Tie:    0,
-		rules.Dragon: 0,
-		rules.Panda:  0,
-	}
-
-	for outcome, count := range s.OutcomeCount {
-		for bet := range betProfits {
-			payout := rules.CalculatePayout(outcome, bet, 1)
-			netChange := payout.NetChange(1)
-			betProfits[bet] += netChange * count
-		}
-	}
-
-	fmt.Printf("\n%-20s | %-16s | %-15s | %-15s\n", "Bet Type ($1/hand)", "Net Profit ($)", "Simulated EV", "Expected EV")
-	fmt.Println("-----------------------------------------------------------------------")
-	fmt.Printf("%-20s | %16d | %14.4f%% | %14.4f%%\n", "Banker", betProfits[rules.Banker], float64(betProfits[rules.Banker])/float64(s.TotalRounds)*100, -1.0183)
-	fmt.Printf("%-20s | %16d | %14.4f%% | %14.4f%%\n", "Player", betProfits[rules.Player], float64(betProfits[rules.Player])/float64(s.TotalRounds)*100, -1.2351)
-	fmt.Printf("%-20s | %16d | %14.4f%% | %14.4f%%\n", "Tie", betProfits[rules.Tie], float64(betProfits[rules.Tie])/float64(s.TotalRounds)*100, -14.3596)
-	fmt.Printf("%-20s | %16d | %14.4f%% | %14.4f%%\n", "Dragon 7", betProfits[rules.Dragon], float64(betProfits[rules.Dragon])/float64(s.TotalRounds)*100, -7.6106)
-	fmt.Printf("%-20s | %16d | %14.4f%% | %14.4f%%\n", "Panda 8", betProfits[rules.Panda], float64(betProfits[rules.Panda])/float64(s.TotalRounds)*100, -10.1882)
-	fmt.Printf("=======================================================================\n\n")
-}
@@ -0,0 +1,502 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/stats"
+)
+
+func TestRunSimulationSeeded(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 2026
+
+	a := RunSimulation(context.Background(), cfg, 20000, SimulationOptions{Workers: 3})
+	b := RunSimulation(context.Background(), cfg, 20000, SimulationOptions{Workers: 3})
+	if !reflect.DeepEqual(a.OutcomeCount, b.OutcomeCount) {
+		t.Errorf("Same seed and worker count produced different results:\n%v\n%v", a.OutcomeCount, b.OutcomeCount)
+	}
+
+	cfg.Seed = 2027
+	c := RunSimulation(context.Background(), cfg, 20000, SimulationOptions{Workers: 3})
+	if reflect.DeepEqual(a.OutcomeCount, c.OutcomeCount) {
+		t.Errorf("Different seeds produced identical results")
+	}
+}
+
+func TestSimulationReport(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 2027
+	r := RunSimulation(context.Background(), cfg, 50000, SimulationOptions{Workers: 2}).Report()
+
+	if r.Variant != cfg.Rules.Name() || r.Decks != 8 || r.Rounds != 50000 {
+		t.Errorf("report header = %+v", r)
+	}
+	total := 0
+	for _, o := range r.Outcomes {
+		total += o.Count
+		if o.StdErr <= 0 || o.CI95Low >= o.Value || o.CI95High <= o.Value || o.Expected <= 0 {
+			t.Errorf("%s estimate = %+v", o.Outcome, o.Estimate)
+		}
+	}
+	if total != r.Rounds || len(r.Outcomes) != 5 {
+		t.Errorf("%d outcomes counting %d rounds", len(r.Outcomes), total)
+	}
+	if r.ChiSquare.DF != 4 || r.ChiSquare.PValue < 0.001 || r.ChiSquare.PValue > 1 {
+		t.Errorf("chi-square = %+v", r.ChiSquare)
+	}
+	if len(r.Bets) != len(cfg.Rules.BetTypes()) {
+		t.Fatalf("%d bets reported", len(r.Bets))
+	}
+	for _, b := range r.Bets {
+		if !b.Covers() {
+			t.Errorf("%s: expected EV %.4f outside the 95%% CI [%.4f, %.4f]", b.Bet, b.Expected, b.CI95Low, b.CI95High)
+		}
+	}
+}
+
+func TestChiSquareFitImpossibleOutcome(t *testing.T) {
+	fit := chiSquareFit([]int{50, 50, 1}, []float64{50, 51, 0})
+	if !math.IsInf(fit.Statistic, 1) || fit.PValue != 0 || fit.DF != 1 {
+		t.Errorf("fit with an impossible outcome = %+v", fit)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 7
+	s := RunSimulation(context.Background(), cfg, 2000, SimulationOptions{Workers: 1})
+
+	var buf bytes.Buffer
+	if err := s.WriteReport(&buf, FormatJSON); err != nil {
+		t.Fatalf("WriteReport(json): %v", err)
+	}
+	var decoded struct {
+		Rounds    int
+		Outcomes  []map[string]any
+		Bets      []map[string]any
+		ChiSquare struct {
+			DF     int      `json:"df"`
+			PValue *float64 `json:"p_value"`
+		} `json:"chi_square"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding the JSON report: %v\n%s", err, buf.String())
+	}
+	if decoded.Rounds != 2000 || len(decoded.Outcomes) != 5 || decoded.ChiSquare.DF != 4 || decoded.ChiSquare.PValue == nil {
+		t.Errorf("decoded report = %+v", decoded)
+	}
+	if _, ok := decoded.Bets[0]["ci95_low"].(float64); !ok {
+		t.Errorf("bet without a CI: %v", decoded.Bets[0])
+	}
+
+	buf.Reset()
+	if err := s.WriteReport(&buf, FormatCSV); err != nil {
+		t.Fatalf("WriteReport(csv): %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading the CSV report: %v", err)
+	}
+	if want := 1 + 5 + len(cfg.Rules.BetTypes()) + 1; len(rows) != want {
+		t.Errorf("%d CSV rows, want %d", len(rows), want)
+	}
+	if last := rows[len(rows)-1]; last[0] != "chi_square" || last[9] != "4" || last[10] == "" {
+		t.Errorf("chi-square row = %v", last)
+	}
+
+	if err := s.WriteReport(&buf, "xml"); err == nil {
+		t.Errorf("WriteReport accepted an unknown format")
+	}
+
+	// A single round has no standard error; JSON cannot carry NaN.
+	buf.Reset()
+	if err := RunSimulation(context.Background(), cfg, 1, SimulationOptions{Workers: 1}).WriteReport(&buf, FormatJSON); err != nil {
+		t.Fatalf("WriteReport of one round: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"std_err": null`) {
+		t.Errorf("standard error of one round not written as null:\n%s", buf.String())
+	}
+}
+
+// referenceSimulation plays shoes the way RunSimulation did before the
+// simulation core: every round is resolved with ResolveRound and settled with
+// Payout, on a fresh model.Shoe per cut card.
+func referenceSimulation(cfg *config.GameConfig, rounds int, rng model.Randomizer) (counts map[rules.Outcome]int, net, netSq map[rules.BetType]int) {
+	counts = make(map[rules.Outcome]int)
+	net = make(map[rules.BetType]int)
+	netSq = make(map[rules.BetType]int)
+	shoe := newSimulationShoe(cfg, rng)
+	for i := 0; i < rounds; i++ {
+		if shoe.IsPastCutCard() {
+			shoe = newSimulationShoe(cfg, rng)
+		}
+		result, err := ResolveRound(shoe, cfg.Rules, nil)
+		if err != nil {
+			continue
+		}
+		counts[result.Outcome]++
+		for _, bet := range cfg.Rules.BetTypes() {
+			v := cfg.Rules.Payout(result.PlayerHand, result.BankerHand, bet, SimulationBetUnit).NetChange(SimulationBetUnit)
+			net[bet] += v
+			netSq[bet] += v * v
+		}
+	}
+	return counts, net, netSq
+}
+
+// shoeSettings are the shoe variations the simulation core is checked under.
+var shoeSettings = []struct {
+	name string
+	edit func(*config.GameConfig)
+}{
+	{"default", func(*config.GameConfig) {}},
+	{"no cut card", func(c *config.GameConfig) { c.CutCardThreshold = 0 }},
+	{"random cut card", func(c *config.GameConfig) { c.CutCardThreshold, c.CutCardMax = 14, 80 }},
+	{"no burn", func(c *config.GameConfig) { c.Burn = model.BurnRule{Mode: model.BurnNone} }},
+	{"fixed burn", func(c *config.GameConfig) { c.Burn = model.BurnRule{Mode: model.BurnFixed, Count: 7} }},
+	{"one deck", func(c *config.GameConfig) { c.DecksCount = 1 }},
+}
+
+func TestSimCoreMatchesResolveRound(t *testing.T) {
+	variants := []rules.RuleSet{}
+	for _, name := range rules.VariantNames() {
+		rs, err := rules.Variant(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		variants = append(variants, rs, rules.WithPairs(rs, rules.DefaultPairPays))
+	}
+	for _, rs := range variants {
+		for _, settings := range shoeSettings {
+			cfg := config.DefaultConfig()
+			cfg.Rules = rs
+			settings.edit(cfg)
+			name := rs.Name() + ", " + settings.name
+			rng := model.NewSeededRandomizer(7)
+			core := newSimCore(cfg, nil)
+			wantCounts := make(map[rules.Outcome]int)
+			wantNet := make(map[rules.BetType]int)
+			wantNetSq := make(map[rules.BetType]int)
+
+			// Deal the same shoes both ways, up to the cut card.
+			for range 60 {
+				shoe := newSimulationShoe(cfg, rng)
+				core.load(shoe.Cards, len(shoe.Cards)-shoe.CardsLeft(), shoe.CutCardThreshold)
+				for !shoe.IsPastCutCard() {
+					core.round()
+					result, err := ResolveRound(shoe, rs, nil)
+					if err != nil {
+						break
+					}
+					wantCounts[result.Outcome]++
+					for _, bet := range rs.BetTypes() {
+						v := rs.Payout(result.PlayerHand, result.BankerHand, bet, SimulationBetUnit).NetChange(SimulationBetUnit)
+						wantNet[bet] += v
+						wantNetSq[bet] += v * v
+					}
+				}
+				if !core.pastCutCard() || core.pos != len(shoe.Cards)-shoe.CardsLeft() {
+					t.Fatalf("%s: core stopped at card %d, shoe at %d", name, core.pos, len(shoe.Cards)-shoe.CardsLeft())
+				}
+			}
+
+			counts, net, netSq := core.tally(rs)
+			if !reflect.DeepEqual(counts, wantCounts) {
+				t.Errorf("%s: outcomes = %v, want %v", name, counts, wantCounts)
+			}
+			if !reflect.DeepEqual(net, wantNet) || !reflect.DeepEqual(netSq, wantNetSq) {
+				t.Errorf("%s: net = %v %v, want %v %v", name, net, netSq, wantNet, wantNetSq)
+			}
+		}
+	}
+}
+
+func TestSimCoreShuffleMatchesShoe(t *testing.T) {
+	for _, settings := range shoeSettings {
+		cfg := config.DefaultConfig()
+		settings.edit(cfg)
+		// Both start from an ordered shoe, so the first shuffle must agree.
+		core := newSimCore(cfg, model.NewSeededRandomizer(11))
+		core.shuffle()
+		shoe := newSimulationShoe(cfg, model.NewSeededRandomizer(11))
+
+		pos := len(shoe.Cards) - shoe.CardsLeft()
+		if core.pos != pos || core.cut != shoe.CutCardThreshold {
+			t.Errorf("%s: core at card %d with cut %d, shoe at %d with cut %d", settings.name, core.pos, core.cut, pos, shoe.CutCardThreshold)
+		}
+		for i, c := range shoe.Cards {
+			if core.cards[i].card() != c {
+				t.Fatalf("%s: card %d is %v, shoe has %v", settings.name, i, core.cards[i].card(), c)
+			}
+		}
+	}
+}
+
+func TestSimulationMatchesReference(t *testing.T) {
+	const rounds = 300000
+	cfg := config.DefaultConfig()
+	cfg.Rules = rules.WithPairs(cfg.Rules, rules.DefaultPairPays)
+	cfg.Seed = 99
+	fast := RunSimulation(context.Background(), cfg, rounds, SimulationOptions{Workers: 1})
+	refCounts, refNet, refNetSq := referenceSimulation(cfg, rounds, model.NewSeededRandomizer(99))
+
+	// Chi-square test of homogeneity of the two outcome distributions.
+	outcomes := reportOutcomes
+	observed := make([]int, 0, 2*len(outcomes))
+	expected := make([]float64, 0, 2*len(outcomes))
+	for _, counts := range []map[rules.Outcome]int{fast.OutcomeCount, refCounts} {
+		for _, o := range outcomes {
+			observed = append(observed, counts[o])
+			expected = append(expected, float64(fast.OutcomeCount[o]+refCounts[o])/2)
+		}
+	}
+	stat, err := stats.ChiSquare(observed, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := stats.ChiSquarePValue(stat, len(outcomes)-1); p < 0.001 {
+		t.Errorf("outcome distributions differ: chi-square %.2f, p = %.5f\n%v\n%v", stat, p, fast.OutcomeCount, refCounts)
+	}
+
+	// Two-sample z-test of the mean net result of every bet.
+	for _, bet := range cfg.Rules.BetTypes() {
+		se := math.Hypot(
+			stats.MeanStdErr(float64(fast.BetNet[bet]), float64(fast.BetNetSq[bet]), rounds),
+			stats.MeanStdErr(float64(refNet[bet]), float64(refNetSq[bet]), rounds))
+		if z := float64(fast.BetNet[bet]-refNet[bet]) / rounds / se; math.Abs(z) > 4 {
+			t.Errorf("%s: mean net differs by %.1f standard errors", bet, z)
+		}
+	}
+}
+
+// benchRounds is the number of rounds each iteration of a simulation
+// benchmark deals, so ns/op covers a whole run and rounds/s compares them.
+const benchRounds = 100000
+
+func reportRoundsPerSecond(b *testing.B) {
+	b.ReportMetric(float64(b.N)*benchRounds/b.Elapsed().Seconds(), "rounds/s")
+}
+
+func BenchmarkRunSimulation(b *testing.B) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 1
+	for b.Loop() {
+		RunSimulation(context.Background(), cfg, benchRounds, SimulationOptions{Workers: 1})
+	}
+	reportRoundsPerSecond(b)
+}
+
+// BenchmarkReferenceSimulation measures the ResolveRound loop RunSimulation
+// used to run, for comparison with BenchmarkRunSimulation.
+func BenchmarkReferenceSimulation(b *testing.B) {
+	cfg := config.DefaultConfig()
+	rng := model.NewSeededRandomizer(1)
+	for b.Loop() {
+		referenceSimulation(cfg, benchRounds, rng)
+	}
+	reportRoundsPerSecond(b)
+}
+
+func TestRunSimulationProgress(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 5
+	var snapshots []SimulationProgress
+	s := runSimulation(context.Background(), cfg, 500000, SimulationOptions{Workers: 2, Progress: func(p SimulationProgress) {
+		snapshots = append(snapshots, p)
+	}}, time.Millisecond)
+	if s.Interrupted || s.TotalRounds != 500000 {
+		t.Errorf("stats = %d rounds, interrupted %v", s.TotalRounds, s.Interrupted)
+	}
+	if len(snapshots) == 0 {
+		t.Fatal("no progress reported")
+	}
+	for i := 1; i < len(snapshots); i++ {
+		if snapshots[i].Rounds < snapshots[i-1].Rounds {
+			t.Fatalf("progress went backwards: %d after %d", snapshots[i].Rounds, snapshots[i-1].Rounds)
+		}
+	}
+	last := snapshots[len(snapshots)-1]
+	if last.Rounds != 500000 || last.TotalRounds != 500000 || last.ETA() != 0 {
+		t.Errorf("final progress = %+v, ETA %v", last, last.ETA())
+	}
+	if !reflect.DeepEqual(last.OutcomeCount, s.OutcomeCount) {
+		t.Errorf("final progress outcomes %v, stats %v", last.OutcomeCount, s.OutcomeCount)
+	}
+	if share := last.Share(rules.OutcomeTie); math.Abs(share-0.095) > 0.005 {
+		t.Errorf("tie share = %.4f", share)
+	}
+}
+
+func TestRunSimulationCanceled(t *testing.T) {
+	cfg := config.DefaultConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const rounds = 1 << 40 // far more than can be dealt before the cancel
+	s := runSimulation(ctx, cfg, rounds, SimulationOptions{Workers: 2, Progress: func(p SimulationProgress) {
+		if p.Rounds >= 100000 {
+			cancel()
+		}
+	}}, time.Millisecond)
+	if !s.Interrupted || s.TotalRounds < 100000 || s.TotalRounds >= rounds {
+		t.Fatalf("stats = %d rounds, interrupted %v", s.TotalRounds, s.Interrupted)
+	}
+	total := 0
+	for _, n := range s.OutcomeCount {
+		total += n
+	}
+	if total != s.TotalRounds {
+		t.Errorf("%d outcomes counted for %d rounds", total, s.TotalRounds)
+	}
+
+	var buf bytes.Buffer
+	s.Report().WriteText(&buf)
+	if !strings.Contains(buf.String(), "Simulation Interrupted") {
+		t.Errorf("text report does not say it was interrupted:\n%s", buf.String())
+	}
+}
+
+func TestSimulationShoeStats(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 8
+	const rounds, workers = 200000, 2
+	s := RunSimulation(context.Background(), cfg, rounds, SimulationOptions{Workers: workers, ShoeStats: true})
+	sh := s.Shoe
+	if sh == nil {
+		t.Fatal("no shoe stats collected")
+	}
+
+	if sh.HandsPerShoe.Total() != sh.Shoes {
+		t.Errorf("%d shoes in the hands histogram, %d dealt", sh.HandsPerShoe.Total(), sh.Shoes)
+	}
+	// Every worker leaves one shoe unfinished, and burns it like the others.
+	dealt := 0
+	for n, shoes := range sh.HandsPerShoe {
+		dealt += n * shoes
+	}
+	if dealt > rounds || dealt < rounds-workers*90 {
+		t.Errorf("%d hands in finished shoes of %d rounds", dealt, rounds)
+	}
+	if lo, hi := sh.BurnCounts.Range(); sh.BurnCounts.Total() != sh.Shoes+workers || lo < 1 || hi > 10 {
+		t.Errorf("burn counts %d..%d over %d shoes", lo, hi, sh.BurnCounts.Total())
+	}
+
+	total := 0
+	for i, b := range sh.ByCardsLeft {
+		total += b.Rounds
+		if i > 0 && b.CardsLeftMax >= sh.ByCardsLeft[i-1].CardsLeftMin {
+			t.Errorf("bucket %d-%d out of order", b.CardsLeftMin, b.CardsLeftMax)
+		}
+		if b.CardsLeftMax < cfg.CutCardThreshold {
+			t.Errorf("round dealt past the cut card, in bucket %d-%d", b.CardsLeftMin, b.CardsLeftMax)
+		}
+	}
+	if total != rounds {
+		t.Errorf("%d rounds bucketed, want %d", total, rounds)
+	}
+
+	wins := map[rules.Outcome]int{}
+	for o, n := range s.OutcomeCount {
+		wins[o] = n
+	}
+	for _, tt := range []struct {
+		streaks stats.Histogram
+		wins    int
+	}{
+		{sh.BankerStreaks, wins[rules.OutcomeBanker] + wins[rules.OutcomeDragon7]},
+		{sh.PlayerStreaks, wins[rules.OutcomePlayer] + wins[rules.OutcomePanda8]},
+	} {
+		inStreaks := 0
+		for n, count := range tt.streaks {
+			inStreaks += n * count
+		}
+		if inStreaks > tt.wins || inStreaks < tt.wins-workers*90 {
+			t.Errorf("%d wins in streaks, %d in all", inStreaks, tt.wins)
+		}
+	}
+
+	var buf bytes.Buffer
+	s.Report().WriteText(&buf)
+	if !strings.Contains(buf.String(), "Hands per shoe") {
+		t.Errorf("text report has no shoe statistics:\n%s", buf.String())
+	}
+}
+
+func TestShoeTallyStreaks(t *testing.T) {
+	index := func(o rules.Outcome) uint8 {
+		return uint8(slices.Index(reportOutcomes[:], o))
+	}
+	tally := newShoeTally(416)
+	tally.newShoe(3)
+	for _, o := range []rules.Outcome{
+		rules.OutcomeTie, rules.OutcomeBanker, rules.OutcomeTie, rules.OutcomeDragon7, // Banker 2
+		rules.OutcomePlayer, rules.OutcomePanda8, rules.OutcomePlayer, // Player 3
+		rules.OutcomeBanker, // Banker 1, cut short by the cut card
+	} {
+		tally.round(index(o), 100)
+	}
+	tally.endShoe()
+	tally.newShoe(10)
+	tally.round(index(rules.OutcomePlayer), 400) // abandoned with its shoe
+	tally.newShoe(10)
+
+	s := tally.stats()
+	if !reflect.DeepEqual(s.BankerStreaks, stats.Histogram{0, 1, 1}) || !reflect.DeepEqual(s.PlayerStreaks, stats.Histogram{0, 0, 0, 1}) {
+		t.Errorf("streaks = Banker %v, Player %v", s.BankerStreaks, s.PlayerStreaks)
+	}
+	if s.Shoes != 1 || s.HandsPerShoe[8] != 1 || s.HandsPerShoe.Total() != 1 {
+		t.Errorf("%d shoes, hands %v", s.Shoes, s.HandsPerShoe)
+	}
+	if s.BurnCounts[3] != 1 || s.BurnCounts[10] != 2 {
+		t.Errorf("burn counts = %v", s.BurnCounts)
+	}
+	if len(s.ByCardsLeft) != 2 || s.ByCardsLeft[0].CardsLeftMax != 415 || s.ByCardsLeft[1].Rounds != 8 {
+		t.Errorf("buckets = %+v", s.ByCardsLeft)
+	}
+}
+
+func BenchmarkRunSimulationShoeStats(b *testing.B) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 1
+	for b.Loop() {
+		RunSimulation(context.Background(), cfg, benchRounds, SimulationOptions{Workers: 1, ShoeStats: true})
+	}
+	reportRoundsPerSecond(b)
+}
+
+func TestRunSimulationNoRounds(t *testing.T) {
+	s := RunSimulation(context.Background(), config.DefaultConfig(), 0, SimulationOptions{Workers: 4})
+	if s.Interrupted || s.TotalRounds != 0 || len(s.OutcomeCount) != 0 {
+		t.Errorf("stats of an empty run = %d rounds, %v, interrupted %v", s.TotalRounds, s.OutcomeCount, s.Interrupted)
+	}
+	var buf bytes.Buffer
+	s.Report().WriteText(&buf)
+}
+
+func TestRunSimulationWithoutCutCard(t *testing.T) {
+	// Dealing to the last card leaves hands the shoe runs out in the middle
+	// of; they are void and do not count as rounds.
+	cfg := config.DefaultConfig()
+	cfg.Seed, cfg.DecksCount, cfg.CutCardThreshold = 3, 1, 0
+	const rounds = 100000
+	s := RunSimulation(context.Background(), cfg, rounds, SimulationOptions{Workers: 2})
+	total := 0
+	for _, n := range s.OutcomeCount {
+		total += n
+	}
+	if s.TotalRounds != rounds || total != rounds {
+		t.Errorf("%d outcomes counted for %d rounds, want %d", total, s.TotalRounds, rounds)
+	}
+}
@@ -0,0 +1,594 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/fair"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+	"github.com/niubaoshu/es-Baccarat/backend/roadmap"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// TablePhase is the stage a multiplayer table's current round is in.
+type TablePhase string
+
+const (
+	// PhaseBettingOpen accepts bets until the betting window closes.
+	PhaseBettingOpen TablePhase = "BETTING_OPEN"
+	// PhaseDealing has locked the bets and is dealing the hand.
+	PhaseDealing TablePhase = "DEALING"
+	// PhaseResolved has settled the hand; the next round has not opened yet.
+	PhaseResolved TablePhase = "RESOLVED"
+)
+
+var (
+	ErrTableFull     = errors.New("table is full")
+	ErrNotSeated     = errors.New("player is not seated at this table")
+	ErrSeatTaken     = errors.New("seat is taken")
+	ErrBettingClosed = errors.New("betting is closed")
+	// ErrNotProvablyFair is returned by SetClientSeed on a table whose shoes
+	// are not provably fair.
+	ErrNotProvablyFair = errors.New("table is not provably fair")
+)
+
+// Settlement is one player's part of a table round.
+type Settlement struct {
+	Player string
+	// Seat is the player's seat when the hand was settled, or 0 if they left
+	// after their bets were locked. Locked bets are settled either way.
+	Seat int
+	// Result is the hand with this player's bets settled.
+	Result         *RoundResult
+	InitialBalance int
+	FinalBalance   int
+	// Err is set if the player's ledger could not be written.
+	Err error
+}
+
+// TableRound is one betting round of a Table. Its results are filled in when
+// the round resolves, which closes Done.
+type TableRound struct {
+	Number   int
+	Deadline time.Time // when betting closes
+
+	// ID is the round ID recorded in the ledgers; empty if no hand was dealt.
+	ID string
+	// Hand is the dealt hand without any bets, or nil if nobody bet or the
+	// table stopped before dealing.
+	Hand        *RoundResult
+	Settlements []Settlement // in seat order
+	// Err is the error that kept the hand from being dealt, if any. The bets
+	// of such a round are withdrawn.
+	Err error
+
+	done chan struct{}
+}
+
+// Done is closed once the round has resolved.
+func (r *TableRound) Done() <-chan struct{} {
+	return r.done
+}
+
+// Settlement returns the settlement of the named player, if they bet in the round.
+func (r *TableRound) Settlement(username string) (Settlement, bool) {
+	for _, s := range r.Settlements {
+		if s.Player == username {
+			return s, true
+		}
+	}
+	return Settlement{}, false
+}
+
+// SeatState is one occupied seat in a TableState.
+type SeatState struct {
+	Seat    int
+	Player  string
+	Balance int
+	Bets    map[rules.BetType]int // open bets in the current round
+}
+
+// TableState is a consistent copy of a table's state.
+type TableState struct {
+	ID         string
+	MaxPlayers int
+	Phase      TablePhase
+	Round      int       // number of the current round
+	Deadline   time.Time // when betting closes, while it is open
+	Seats      []SeatState
+	CardsLeft  int
+	ShoeID     string
+	// Commitment is the current shoe's commitment, in provably-fair mode.
+	Commitment *fair.Commitment
+	// Road is a copy of the current shoe's scoreboard.
+	Road *roadmap.Roadmap
+}
+
+// Table is a multiplayer Baccarat table. Seated players share one shoe: each
+// round opens a betting window, locks the bets when it closes, deals one hand
+// and settles every player's bets on it. All methods are safe for concurrent
+// use, and State can be read while a hand is being dealt.
+type Table struct {
+	ID         string
+	MaxPlayers int
+	// OnError, if set, is called by Run with the error of every round whose
+	// hand could not be dealt, and after every hand with each round log that
+	// could not be written. It is called without the table's lock held.
+	OnError func(error)
+
+	cfg    *config.GameConfig
+	rounds RoundStore
+
+	mu        sync.RWMutex
+	dealer    *ShoeDealer // the shoe itself is only touched by the dealing round
+	spent     bool        // the shoe failed a hand and must be replaced
+	phase     TablePhase
+	round     *TableRound
+	seats     map[int]*player.Profile          // seat number (1-based) -> player
+	bets      map[string]map[rules.BetType]int // open bets by username
+	cardsLeft int
+
+	// Event stream; see Events.
+	tick      time.Duration // interval of EventCountdown while betting is open
+	lastSeq   uint64
+	events    []TableEvent
+	published chan struct{} // closed and replaced by every publish
+}
+
+// NewTable creates a table dealing from the given randomness stream (see
+// NewRandomizer) and recording its rounds in rounds. Its first shoe is
+// prepared; betting opens with OpenBetting or Run.
+func NewTable(id string, cfg *config.GameConfig, stream, maxPlayers int, rounds RoundStore) (*Table, error) {
+	t := &Table{
+		ID:         id,
+		MaxPlayers: maxPlayers,
+		cfg:        cfg,
+		rounds:     rounds,
+		dealer:     NewShoeDealer(cfg, stream),
+		phase:      PhaseResolved,
+		seats:      make(map[int]*player.Profile),
+		bets:       make(map[string]map[rules.BetType]int),
+		tick:       time.Second,
+		published:  make(chan struct{}),
+	}
+	if err := t.newShoe(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// newShoe brings out the next shoe and announces it, after the reveal of the
+// retired one in provably-fair mode. Caller must hold t.mu.
+func (t *Table) newShoe() error {
+	reveal, err := t.dealer.NextShoe()
+	if reveal != nil {
+		t.publish(TableEvent{Type: EventShoeReveal, Reveal: reveal})
+	}
+	if err != nil {
+		return fmt.Errorf("preparing shoe: %w", err)
+	}
+	t.spent = false
+	t.cardsLeft = t.dealer.Shoe.CardsLeft()
+	t.publish(TableEvent{Type: EventNewShoe, ShoeID: t.dealer.ShoeID, CardsLeft: t.cardsLeft, Commitment: t.dealer.Commitment})
+	return nil
+}
+
+// Retire takes the table's shoe out of play, revealing its server seed in
+// provably-fair mode. The next hand is dealt from a new shoe. Call it once Run
+// has returned, so the last shoe of a table that is shut down can be verified.
+func (t *Table) Retire() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.dealer.Shoe == nil {
+		return nil
+	}
+	t.spent = true
+	reveal, err := t.dealer.Retire()
+	if reveal != nil {
+		t.publish(TableEvent{Type: EventShoeReveal, Reveal: reveal})
+	}
+	return err
+}
+
+// SetClientSeed sets the client seed of the table's next provably-fair shoes
+// on behalf of a seated player. The shoe in play keeps the seed it was
+// committed with.
+func (t *Table) SetClientSeed(username, seed string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.cfg.ProvablyFair || len(t.cfg.PresetShoe) > 0 {
+		return ErrNotProvablyFair
+	}
+	if t.seatOf(username) == 0 {
+		return ErrNotSeated
+	}
+	t.dealer.ClientSeed = seed
+	return nil
+}
+
+// seatOf returns the seat number of the given player, or 0 if not seated.
+// Caller must hold t.mu.
+func (t *Table) seatOf(username string) int {
+	for seat, p := range t.seats {
+		if p.Username == username {
+			return seat
+		}
+	}
+	return 0
+}
+
+// Sit seats p at the lowest free seat and returns it. A player who is already
+// seated keeps their seat.
+func (t *Table) Sit(p *player.Profile) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if seat := t.seatOf(p.Username); seat != 0 {
+		return seat, nil
+	}
+	for seat := 1; seat <= t.MaxPlayers; seat++ {
+		if _, taken := t.seats[seat]; !taken {
+			t.seats[seat] = p
+			t.publish(TableEvent{Type: EventSeat, Player: p.Username, Seat: seat, Joined: true})
+			return seat, nil
+		}
+	}
+	return 0, ErrTableFull
+}
+
+// SitAt seats p at the given seat, e.g. when a stored table is reopened.
+func (t *Table) SitAt(p *player.Profile, seat int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if seat < 1 || seat > t.MaxPlayers {
+		return fmt.Errorf("seat %d is not at a %d-player table", seat, t.MaxPlayers)
+	}
+	if other, taken := t.seats[seat]; taken && other.Username != p.Username {
+		return ErrSeatTaken
+	}
+	if old := t.seatOf(p.Username); old != 0 {
+		if old == seat {
+			return nil
+		}
+		delete(t.seats, old)
+		t.publish(TableEvent{Type: EventSeat, Player: p.Username, Seat: old})
+	}
+	t.seats[seat] = p
+	t.publish(TableEvent{Type: EventSeat, Player: p.Username, Seat: seat, Joined: true})
+	return nil
+}
+
+// Leave frees the player's seat. Bets placed in an open betting window are
+// withdrawn; bets already locked for the hand being dealt are still settled,
+// and their stakes stay held until then.
+func (t *Table) Leave(username string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seat := t.seatOf(username)
+	if seat == 0 {
+		return ErrNotSeated
+	}
+	t.seats[seat].Release(stake(t.bets[username]))
+	delete(t.seats, seat)
+	delete(t.bets, username)
+	t.publish(TableEvent{Type: EventSeat, Player: username, Seat: seat})
+	return nil
+}
+
+// OpenBetting starts the next round with a betting window of
+// cfg.BettingWindow and returns it. If betting is already open it returns the
+// open round.
+func (t *Table) OpenBetting() *TableRound {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase == PhaseBettingOpen {
+		return t.round
+	}
+	number := 1
+	if t.round != nil {
+		number = t.round.Number + 1
+	}
+	t.round = &TableRound{Number: number, Deadline: time.Now().Add(t.cfg.BettingWindow), done: make(chan struct{})}
+	t.phase = PhaseBettingOpen
+	t.publish(TableEvent{Type: EventPhase, Phase: t.phase, Deadline: t.round.Deadline})
+	return t.round
+}
+
+// PlaceBet sets the player's bets for the open round, replacing any placed
+// earlier in the same window, and returns the round. The bets are checked
+// against the table limits and the player's available balance, and their
+// stakes are held (see player.Profile.Hold) so that they cannot be bet again
+// at another table, but nothing is debited until the hand is settled.
+func (t *Table) PlaceBet(username string, bets map[rules.BetType]int) (*TableRound, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seat := t.seatOf(username)
+	if seat == 0 {
+		return nil, ErrNotSeated
+	}
+	if t.phase != PhaseBettingOpen {
+		return nil, ErrBettingClosed
+	}
+	p := t.seats[seat]
+	held, total := stake(t.bets[username]), stake(bets)
+	_, available := p.Funds()
+	if err := t.cfg.Limits.Validate(t.cfg.Rules, bets, available+held); err != nil {
+		return nil, err
+	}
+	if total > held && !p.Hold(total-held) {
+		// Another table held the funds since Funds was read.
+		_, available = p.Funds()
+		return nil, &rules.BetError{Amount: total, Limit: available + held, Err: rules.ErrInsufficientFunds}
+	}
+	p.Release(max(held-total, 0))
+	t.bets[username] = bets
+	t.publish(TableEvent{Type: EventBet, Player: username, Seat: seat, Bets: copyBets(bets)})
+	return t.round, nil
+}
+
+// State returns a copy of the table's state.
+func (t *Table) State() TableState {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	s := TableState{
+		ID:         t.ID,
+		MaxPlayers: t.MaxPlayers,
+		Phase:      t.phase,
+		CardsLeft:  t.cardsLeft,
+		ShoeID:     t.dealer.ShoeID,
+		Commitment: t.dealer.Commitment,
+		Road:       roadmap.New(),
+	}
+	if t.round != nil {
+		s.Round = t.round.Number
+		if t.phase == PhaseBettingOpen {
+			s.Deadline = t.round.Deadline
+		}
+	}
+	for seat, p := range t.seats {
+		balance, _ := p.Funds()
+		s.Seats = append(s.Seats, SeatState{Seat: seat, Player: p.Username, Balance: balance, Bets: copyBets(t.bets[p.Username])})
+	}
+	sort.Slice(s.Seats, func(i, j int) bool { return s.Seats[i].Seat < s.Seats[j].Seat })
+	for _, o := range t.dealer.Road.Outcomes() {
+		s.Road.Add(o)
+	}
+	return s
+}
+
+// Run cycles the table through rounds until ctx is done: betting stays open
+// for cfg.BettingWindow, counting down every second, then the hand is dealt
+// and settled. A round whose betting window is cut short by ctx resolves
+// without a hand. A round whose hand cannot be dealt is reported to OnError
+// and resolves without a hand; the next one is dealt from a new shoe. Run
+// returns ctx's error.
+func (t *Table) Run(ctx context.Context) error {
+	for {
+		r := t.OpenBetting()
+		if err := t.countDown(ctx, r); err != nil {
+			t.closeBetting(r)
+			return err
+		}
+		if _, err := t.Deal(); err != nil && t.OnError != nil {
+			t.OnError(err)
+		}
+	}
+}
+
+// countDown waits for the betting window of r to close, publishing the time
+// remaining every tick.
+func (t *Table) countDown(ctx context.Context, r *TableRound) error {
+	timer := time.NewTimer(time.Until(r.Deadline))
+	defer timer.Stop()
+	ticker := time.NewTicker(t.tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case now := <-ticker.C:
+			if remaining := r.Deadline.Sub(now).Round(t.tick); remaining > 0 {
+				t.mu.Lock()
+				t.publish(TableEvent{Type: EventCountdown, Deadline: r.Deadline, Remaining: remaining})
+				t.mu.Unlock()
+			}
+		}
+	}
+}
+
+// closeBetting resolves the open round r without dealing, withdrawing its bets.
+func (t *Table) closeBetting(r *TableRound) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase != PhaseBettingOpen || t.round != r {
+		return
+	}
+	for username, bets := range t.bets {
+		t.seats[t.seatOf(username)].Release(stake(bets))
+	}
+	t.bets = make(map[string]map[rules.BetType]int)
+	t.phase = PhaseResolved
+	t.publish(TableEvent{Type: EventPhase, Phase: t.phase})
+	close(r.done)
+}
+
+// lockedBets are one player's bets locked for the hand being dealt.
+type lockedBets struct {
+	player *player.Profile
+	bets   map[rules.BetType]int
+}
+
+// stake returns the total amount of bets.
+func stake(bets map[rules.BetType]int) int {
+	total := 0
+	for _, amount := range bets {
+		total += amount
+	}
+	return total
+}
+
+// release returns the held stakes of bets that will not be settled.
+func release(locked []lockedBets) {
+	for _, l := range locked {
+		l.player.Release(stake(l.bets))
+	}
+}
+
+// Deal closes betting on the open round, deals one hand and settles every
+// locked bet on it. If nobody bet, no cards are dealt. The round is resolved
+// even if dealing fails, with its bets withdrawn and the shoe marked for
+// replacement.
+func (t *Table) Deal() (*TableRound, error) {
+	r, locked, err := t.lockBets()
+	if err != nil {
+		return nil, err
+	}
+	return r, t.dealLocked(r, locked)
+}
+
+// lockBets moves the table to PhaseDealing and takes the open round's bets,
+// in seat order.
+func (t *Table) lockBets() (*TableRound, []lockedBets, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase != PhaseBettingOpen {
+		return nil, nil, ErrBettingClosed
+	}
+	t.phase = PhaseDealing
+	t.publish(TableEvent{Type: EventPhase, Phase: t.phase})
+
+	seats := make([]int, 0, len(t.seats))
+	for seat := range t.seats {
+		seats = append(seats, seat)
+	}
+	sort.Ints(seats)
+	var locked []lockedBets
+	for _, seat := range seats {
+		p := t.seats[seat]
+		if bets := t.bets[p.Username]; len(bets) > 0 {
+			locked = append(locked, lockedBets{player: p, bets: bets})
+		}
+	}
+	t.bets = make(map[string]map[rules.BetType]int)
+	return t.round, locked, nil
+}
+
+// nextHand prepares a new shoe if newShoe is set or the cut card has come
+// out, and returns the shoe to deal the next hand from, with its ID and the ID
+// of the round.
+func (t *Table) nextHand(newShoe bool) (shoe *model.Shoe, shoeID, roundID string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if newShoe || t.spent || t.dealer.Shoe.IsPastCutCard() {
+		if err := t.newShoe(); err != nil {
+			return nil, "", "", err
+		}
+	}
+	return t.dealer.Shoe, t.dealer.ShoeID, t.dealer.NextRoundID(), nil
+}
+
+// dealLocked deals the hand of round r and settles the locked bets. Every
+// player's hand is written to the round log, marked with the settlement error
+// if the bets could not be settled, so the shoe can still be replayed.
+func (t *Table) dealLocked(r *TableRound, locked []lockedBets) (err error) {
+	defer func() { t.resolve(r, err) }()
+	if len(locked) == 0 {
+		return nil
+	}
+
+	shoe, shoeID, roundID, err := t.nextHand(false)
+	var hand *RoundResult
+	if err == nil {
+		// Only the dealing round touches the shoe, so the cards are drawn
+		// without holding the lock.
+		hand, err = ResolveRound(shoe, t.cfg.Rules, nil)
+		if errors.Is(err, model.ErrShoeEmpty) {
+			// The shoe ran out mid-hand, e.g. at the end of a preset order:
+			// the hand is void and is dealt again from a new shoe.
+			if shoe, shoeID, roundID, err = t.nextHand(true); err == nil {
+				hand, err = ResolveRound(shoe, t.cfg.Rules, nil)
+			}
+		}
+	}
+	if err != nil {
+		release(locked)
+		return fmt.Errorf("dealing round: %w", err)
+	}
+
+	var logErrs []error
+	defer func() {
+		if t.OnError != nil {
+			for _, err := range logErrs {
+				t.OnError(err)
+			}
+		}
+	}()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cardsLeft = shoe.CardsLeft()
+	t.dealer.Road.Add(hand.Outcome)
+	r.ID, r.Hand = roundID, hand
+	t.publishHand(hand)
+	for _, l := range locked {
+		res := hand.Settle(t.cfg.Rules, l.bets)
+		s := Settlement{
+			Player: l.player.Username,
+			Seat:   t.seatOf(l.player.Username),
+			Result: res,
+		}
+		s.InitialBalance, s.FinalBalance, s.Err = l.player.SettleHeld(roundID, stake(l.bets), res.Wagers())
+		r.Settlements = append(r.Settlements, s)
+		log := NewRoundLog(s.Player, s.InitialBalance, s.FinalBalance, l.bets, res)
+		log.ShoeID, log.RoundID = shoeID, roundID
+		if s.Err != nil {
+			log.NetChange, log.SettleError = s.FinalBalance-s.InitialBalance, s.Err.Error()
+		} else {
+			t.publish(TableEvent{
+				Type:    EventSettlement,
+				Player:  s.Player,
+				Seat:    s.Seat,
+				Bets:    copyBets(l.bets),
+				Payout:  res.TotalPayout,
+				Net:     res.NetChange(),
+				Balance: s.FinalBalance,
+			})
+		}
+		// The ledger already records the settlement; a round log that cannot
+		// be written does not undo it and is only reported.
+		if err := t.rounds.LogRound(log); err != nil {
+			logErrs = append(logErrs, fmt.Errorf("table %s: logging round %s for %s: %w", t.ID, roundID, s.Player, err))
+		}
+	}
+	if shoe.IsPastCutCard() {
+		t.publish(TableEvent{Type: EventCutCard, CardsLeft: t.cardsLeft})
+	}
+	return nil
+}
+
+// resolve moves the table to PhaseResolved and announces that r has resolved,
+// with err if its hand could not be dealt.
+func (t *Table) resolve(r *TableRound, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		r.Err, t.spent = err, true
+	}
+	t.phase = PhaseResolved
+	t.publish(TableEvent{Type: EventPhase, Phase: t.phase})
+	close(r.done)
+}
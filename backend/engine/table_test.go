@@ -0,0 +1,628 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// memRounds is a RoundStore kept in memory.
+type memRounds struct {
+	mu   sync.Mutex
+	logs []RoundLog
+}
+
+func (m *memRounds) LogRound(l RoundLog) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, l)
+	return nil
+}
+
+func (m *memRounds) LoadRounds() ([]RoundLog, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]RoundLog(nil), m.logs...), nil
+}
+
+// newDragon7Table returns a table that deals a Banker three-card 7 against
+// Player 6 every hand, and creates a profile with $1000 for each name.
+func newDragon7Table(t *testing.T, maxPlayers int, names ...string) (*Table, *memRounds, []*player.Profile) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cards, err := model.ParseCards("10S 2H 6D KC 5H")
+	if err != nil {
+		t.Fatalf("ParseCards: %v", err)
+	}
+	cfg.PresetShoe = cards
+	cfg.BettingWindow = 10 * time.Millisecond
+
+	rounds := &memRounds{}
+	tbl, err := NewTable("table-1", cfg, 1, maxPlayers, rounds)
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	accounts := player.NewAccounts(player.NewFileBackend(t.TempDir()))
+	var profiles []*player.Profile
+	for _, name := range names {
+		p, err := accounts.Create(name, 1000)
+		if err != nil {
+			t.Fatalf("Create(%s): %v", name, err)
+		}
+		if _, err := tbl.Sit(p); err != nil {
+			t.Fatalf("Sit(%s): %v", name, err)
+		}
+		profiles = append(profiles, p)
+	}
+	return tbl, rounds, profiles
+}
+
+func TestTableSettlesEveryPlayerOnOneHand(t *testing.T) {
+	tbl, rounds, ps := newDragon7Table(t, 7, "alice", "bob", "carol")
+	alice, bob := ps[0], ps[1]
+
+	r := tbl.OpenBetting()
+	if _, err := tbl.PlaceBet("alice", map[rules.BetType]int{rules.Banker: 100, rules.Dragon: 10}); err != nil {
+		t.Fatalf("PlaceBet alice: %v", err)
+	}
+	if _, err := tbl.PlaceBet("bob", map[rules.BetType]int{rules.Player: 50}); err != nil {
+		t.Fatalf("PlaceBet bob: %v", err)
+	}
+	if _, err := tbl.PlaceBet("dave", map[rules.BetType]int{rules.Player: 50}); err != ErrNotSeated {
+		t.Errorf("PlaceBet by a player who is not seated: %v", err)
+	}
+	if st := tbl.State(); st.Phase != PhaseBettingOpen || st.Deadline.IsZero() || st.Seats[1].Bets[rules.Player] != 50 {
+		t.Errorf("state while betting = %+v", st)
+	}
+
+	dealt, err := tbl.Deal()
+	if err != nil || dealt != r {
+		t.Fatalf("Deal = %v, %v", dealt, err)
+	}
+	select {
+	case <-r.Done():
+	default:
+		t.Fatalf("round not resolved after Deal")
+	}
+	if r.Hand == nil || r.Hand.Outcome != rules.OutcomeDragon7 || r.ID == "" {
+		t.Fatalf("hand = %+v, id %q", r.Hand, r.ID)
+	}
+
+	// carol did not bet and is not settled.
+	if len(r.Settlements) != 2 {
+		t.Fatalf("settlements = %+v", r.Settlements)
+	}
+	if s, _ := r.Settlement("alice"); s.Seat != 1 || s.Result.TotalPayout != 100+410 || s.FinalBalance != 1400 || alice.Balance != 1400 {
+		t.Errorf("alice settlement = %+v, balance %d", s, alice.Balance)
+	}
+	if s, _ := r.Settlement("bob"); s.Result.NetChange() != -50 || bob.Balance != 950 {
+		t.Errorf("bob settlement = %+v, balance %d", s, bob.Balance)
+	}
+	if s, _ := r.Settlement("bob"); s.Result.PlayerHand != r.Hand.PlayerHand {
+		t.Errorf("players were not settled on the same hand")
+	}
+
+	logs, _ := rounds.LoadRounds()
+	if len(logs) != 2 || logs[0].RoundID != r.ID || logs[1].RoundID != r.ID {
+		t.Errorf("round logs = %+v", logs)
+	}
+	st := tbl.State()
+	if st.Phase != PhaseResolved || len(st.Road.Outcomes()) != 1 || st.Seats[0].Bets != nil {
+		t.Errorf("state after the hand = %+v", st)
+	}
+	if _, err := tbl.PlaceBet("alice", map[rules.BetType]int{rules.Player: 10}); err != ErrBettingClosed {
+		t.Errorf("PlaceBet after the hand: %v, want ErrBettingClosed", err)
+	}
+}
+
+// failingCommits is a profile backend that cannot record settlements.
+type failingCommits struct {
+	*player.FileBackend
+}
+
+func (failingCommits) Commit(*player.Profile, []player.LedgerEntry) error {
+	return errors.New("disk full")
+}
+
+// failingRounds is a RoundStore that cannot write round logs.
+type failingRounds struct {
+	memRounds
+}
+
+func (*failingRounds) LogRound(RoundLog) error {
+	return errors.New("disk full")
+}
+
+func TestTableLogsUnsettledHands(t *testing.T) {
+	tbl, rounds, _ := newDragon7Table(t, 7, "alice")
+	// bob's settlement cannot be recorded; his hand is still logged.
+	bob, err := player.NewAccounts(failingCommits{player.NewFileBackend(t.TempDir())}).Create("bob", 1000)
+	if err != nil {
+		t.Fatalf("Create bob: %v", err)
+	}
+	tbl.Sit(bob)
+
+	r := tbl.OpenBetting()
+	tbl.PlaceBet("alice", map[rules.BetType]int{rules.Banker: 100})
+	tbl.PlaceBet("bob", map[rules.BetType]int{rules.Player: 50})
+	if _, err := tbl.Deal(); err != nil {
+		t.Fatalf("Deal: %v", err)
+	}
+	if s, _ := r.Settlement("bob"); s.Err == nil || bob.Balance != 1000 {
+		t.Fatalf("bob settlement = %+v, balance %d", s, bob.Balance)
+	}
+
+	logs, _ := rounds.LoadRounds()
+	if len(logs) != 2 || logs[0].SettleError != "" {
+		t.Fatalf("round logs = %+v", logs)
+	}
+	if l := logs[1]; l.Player != "bob" || l.RoundID != r.ID || l.SettleError == "" || l.NetChange != 0 || l.FinalBalance != 1000 {
+		t.Errorf("unsettled round log = %+v", l)
+	}
+	audit := AuditRounds(logs, tbl.cfg.Rules)
+	if len(audit.Issues) != 1 || !strings.Contains(audit.Issues[0].Message, "not settled") {
+		t.Errorf("audit issues = %v, want only the unsettled bets", audit.Issues)
+	}
+}
+
+func TestTableReportsRoundLogFailures(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.BettingWindow = 10 * time.Millisecond
+	tbl, err := NewTable("table-1", cfg, 1, 7, &failingRounds{})
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	var reported []error
+	tbl.OnError = func(err error) { reported = append(reported, err) }
+	alice, err := player.NewAccounts(player.NewFileBackend(t.TempDir())).Create("alice", 1000)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	tbl.Sit(alice)
+
+	r := tbl.OpenBetting()
+	tbl.PlaceBet("alice", map[rules.BetType]int{rules.Banker: 100})
+	if _, err := tbl.Deal(); err != nil {
+		t.Fatalf("Deal: %v", err)
+	}
+	// The settlement stands; only the round log is missing.
+	if s, _ := r.Settlement("alice"); s.Err != nil {
+		t.Errorf("settlement = %+v", s)
+	}
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "alice") {
+		t.Errorf("OnError got %v, want the failed round log", reported)
+	}
+}
+
+func TestTableRoundWithoutBets(t *testing.T) {
+	tbl, _, _ := newDragon7Table(t, 7, "alice")
+	before := tbl.State().CardsLeft
+
+	r := tbl.OpenBetting()
+	if _, err := tbl.Deal(); err != nil {
+		t.Fatalf("Deal: %v", err)
+	}
+	if r.Hand != nil || r.ID != "" || len(r.Settlements) != 0 {
+		t.Errorf("a round without bets dealt %+v", r)
+	}
+	if st := tbl.State(); st.CardsLeft != before || st.Phase != PhaseResolved {
+		t.Errorf("state after a round without bets = %+v", st)
+	}
+	if next := tbl.OpenBetting(); next.Number != r.Number+1 {
+		t.Errorf("next round number = %d, want %d", next.Number, r.Number+1)
+	}
+}
+
+func TestTableLeave(t *testing.T) {
+	tbl, _, ps := newDragon7Table(t, 2, "alice", "bob")
+	alice, bob := ps[0], ps[1]
+
+	// Bets placed while betting is open are withdrawn by leaving.
+	r := tbl.OpenBetting()
+	tbl.PlaceBet("alice", map[rules.BetType]int{rules.Player: 100})
+	if err := tbl.Leave("alice"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+	if err := tbl.Leave("alice"); err != ErrNotSeated {
+		t.Errorf("second Leave: %v, want ErrNotSeated", err)
+	}
+
+	// Bets locked for the hand are settled even if the player leaves mid-hand.
+	tbl.PlaceBet("bob", map[rules.BetType]int{rules.Player: 100})
+	_, locked, err := tbl.lockBets()
+	if err != nil {
+		t.Fatalf("lockBets: %v", err)
+	}
+	if st := tbl.State(); st.Phase != PhaseDealing {
+		t.Errorf("phase while dealing = %s", st.Phase)
+	}
+	if err := tbl.Leave("bob"); err != nil {
+		t.Fatalf("Leave mid-hand: %v", err)
+	}
+	if err := tbl.dealLocked(r, locked); err != nil {
+		t.Fatalf("dealLocked: %v", err)
+	}
+	if _, ok := r.Settlement("alice"); ok || alice.Balance != 1000 {
+		t.Errorf("alice's withdrawn bet was settled: balance %d", alice.Balance)
+	}
+	if s, ok := r.Settlement("bob"); !ok || s.Seat != 0 || bob.Balance != 900 {
+		t.Errorf("bob's settlement = %+v, balance %d", s, bob.Balance)
+	}
+	if st := tbl.State(); len(st.Seats) != 0 {
+		t.Errorf("seats after both left = %+v", st.Seats)
+	}
+}
+
+func TestTablesShareHeldFunds(t *testing.T) {
+	t1, _, ps := newDragon7Table(t, 7, "alice")
+	t2, _, _ := newDragon7Table(t, 7)
+	alice := ps[0]
+	if _, err := t2.Sit(alice); err != nil {
+		t.Fatalf("Sit: %v", err)
+	}
+	r1, _ := t1.OpenBetting(), t2.OpenBetting()
+
+	// A stake held at one table cannot be bet at another, until it is lowered.
+	if _, err := t1.PlaceBet("alice", map[rules.BetType]int{rules.Player: 800}); err != nil {
+		t.Fatalf("PlaceBet at table 1: %v", err)
+	}
+	if _, err := t2.PlaceBet("alice", map[rules.BetType]int{rules.Player: 800}); !errors.Is(err, rules.ErrInsufficientFunds) {
+		t.Errorf("PlaceBet of held funds at table 2: %v, want ErrInsufficientFunds", err)
+	}
+	t1.PlaceBet("alice", map[rules.BetType]int{rules.Player: 500})
+	if _, err := t2.PlaceBet("alice", map[rules.BetType]int{rules.Player: 500}); err != nil {
+		t.Errorf("PlaceBet of released funds at table 2: %v", err)
+	}
+	t2.closeBetting(t2.round)
+	if _, available := alice.Funds(); available != 500 {
+		t.Errorf("available after table 2 closed without a hand = %d, want 500", available)
+	}
+
+	// Leaving mid-hand keeps the stake held until the hand is settled.
+	_, locked, err := t1.lockBets()
+	if err != nil {
+		t.Fatalf("lockBets: %v", err)
+	}
+	if err := t1.Leave("alice"); err != nil {
+		t.Fatalf("Leave mid-hand: %v", err)
+	}
+	r2 := t2.OpenBetting()
+	if _, err := t2.PlaceBet("alice", map[rules.BetType]int{rules.Player: 1000}); !errors.Is(err, rules.ErrInsufficientFunds) {
+		t.Errorf("PlaceBet at table 2 of a stake locked at table 1: %v, want ErrInsufficientFunds", err)
+	}
+	if _, err := t2.PlaceBet("alice", map[rules.BetType]int{rules.Player: 500}); err != nil {
+		t.Fatalf("PlaceBet at table 2: %v", err)
+	}
+
+	// Both tables settle concurrently; both Player bets lose to the Dragon 7.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := t1.dealLocked(r1, locked); err != nil {
+			t.Errorf("dealLocked at table 1: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if _, err := t2.Deal(); err != nil {
+			t.Errorf("Deal at table 2: %v", err)
+		}
+	}()
+	wg.Wait()
+	<-r2.Done()
+	if balance, available := alice.Funds(); balance != 0 || available != 0 {
+		t.Errorf("balance %d, available %d after losing both bets, want 0", balance, available)
+	}
+	s1, _ := r1.Settlement("alice")
+	s2, _ := r2.Settlement("alice")
+	if s1.InitialBalance-s1.FinalBalance != 500 || s2.InitialBalance-s2.FinalBalance != 500 {
+		t.Errorf("settlements %+v and %+v", s1, s2)
+	}
+}
+
+func TestTableShortPresetShoe(t *testing.T) {
+	// The preset leaves one card after the first hand: the next hand runs out
+	// mid-hand, is void and is dealt again from the top. The Banker bet pushes.
+	cfg := config.DefaultConfig()
+	cards, err := model.ParseCards("10S 2H 6D KC 5H 9S")
+	if err != nil {
+		t.Fatalf("ParseCards: %v", err)
+	}
+	cfg.PresetShoe = cards
+	tbl, err := NewTable("table-1", cfg, 1, 7, &memRounds{})
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	alice, err := player.NewAccounts(player.NewFileBackend(t.TempDir())).Create("alice", 1000)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	tbl.Sit(alice)
+
+	for i := 1; i <= 4; i++ {
+		r := tbl.OpenBetting()
+		if _, err := tbl.PlaceBet("alice", map[rules.BetType]int{rules.Banker: 100, rules.Dragon: 10}); err != nil {
+			t.Fatalf("round %d: PlaceBet: %v", i, err)
+		}
+		if _, err := tbl.Deal(); err != nil {
+			t.Fatalf("round %d: Deal: %v", i, err)
+		}
+		s, ok := r.Settlement("alice")
+		if !ok || r.Hand.Outcome != rules.OutcomeDragon7 || s.Result.NetChange() != 400 {
+			t.Fatalf("round %d: hand %+v, settlement %+v", i, r.Hand, s)
+		}
+	}
+	if balance, _ := alice.Funds(); balance != 1000+4*400 {
+		t.Errorf("balance after four Dragon 7 wins = %d", balance)
+	}
+}
+
+func TestTableSeats(t *testing.T) {
+	tbl, _, ps := newDragon7Table(t, 2, "alice", "bob")
+	if seat, err := tbl.Sit(ps[0]); err != nil || seat != 1 {
+		t.Errorf("Sit of a seated player = %d, %v", seat, err)
+	}
+	extra, _ := player.NewAccounts(player.NewFileBackend(t.TempDir())).Create("carol", 1000)
+	if _, err := tbl.Sit(extra); err != ErrTableFull {
+		t.Errorf("Sit at a full table: %v", err)
+	}
+	if err := tbl.SitAt(extra, 2); err != ErrSeatTaken {
+		t.Errorf("SitAt a taken seat: %v", err)
+	}
+	tbl.Leave("bob")
+	if err := tbl.SitAt(extra, 2); err != nil {
+		t.Errorf("SitAt a free seat: %v", err)
+	}
+	if err := tbl.SitAt(extra, 3); err == nil {
+		t.Errorf("SitAt a seat beyond the table's size succeeded")
+	}
+}
+
+func TestTableRun(t *testing.T) {
+	tbl, _, ps := newDragon7Table(t, 7, "alice")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := tbl.OpenBetting()
+	if _, err := tbl.PlaceBet("alice", map[rules.BetType]int{rules.Banker: 10}); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+	done := make(chan error)
+	go func() { done <- tbl.Run(ctx) }()
+
+	// Reading the state while the table runs is safe.
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				tbl.State()
+			}
+		}
+	}()
+	defer close(stop)
+
+	select {
+	case <-r.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the betting window never closed")
+	}
+	if s, ok := r.Settlement("alice"); !ok || s.FinalBalance != ps[0].Balance {
+		t.Errorf("settlement = %+v", s)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, want context.Canceled", err)
+	}
+	if st := tbl.State(); st.Phase != PhaseResolved {
+		t.Errorf("phase after Run stopped = %s", st.Phase)
+	}
+}
+
+func TestTableRunSurvivesFailedDeal(t *testing.T) {
+	// The preset cannot complete a hand, so every hand runs out of cards, even
+	// when it is dealt again from a new shoe.
+	cfg := config.DefaultConfig()
+	cards, err := model.ParseCards("10S 2H 6D")
+	if err != nil {
+		t.Fatalf("ParseCards: %v", err)
+	}
+	cfg.PresetShoe = cards
+	cfg.BettingWindow = 10 * time.Millisecond
+	tbl, err := NewTable("table-1", cfg, 1, 7, &memRounds{})
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	var mu sync.Mutex
+	var reported []error
+	tbl.OnError = func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, err)
+	}
+	alice, err := player.NewAccounts(player.NewFileBackend(t.TempDir())).Create("alice", 1000)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	tbl.Sit(alice)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := tbl.OpenBetting()
+	done := make(chan error)
+	go func() { done <- tbl.Run(ctx) }()
+
+	for i := 1; i <= 3; i++ {
+		if _, err := tbl.PlaceBet("alice", map[rules.BetType]int{rules.Banker: 1000}); err != nil {
+			t.Fatalf("round %d: PlaceBet: %v", i, err)
+		}
+		select {
+		case <-r.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("round %d never resolved", i)
+		}
+		if !errors.Is(r.Err, model.ErrShoeEmpty) || r.Hand != nil || len(r.Settlements) != 0 {
+			t.Fatalf("round %d: err %v, hand %v, settlements %+v", i, r.Err, r.Hand, r.Settlements)
+		}
+		// The whole balance is available to bet again in the next round.
+		if balance, available := alice.Funds(); balance != 1000 || available != 1000 {
+			t.Fatalf("round %d: balance %d, available %d", i, balance, available)
+		}
+		r = tbl.OpenBetting()
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, want context.Canceled", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) < 3 {
+		t.Errorf("OnError got %d errors, want one per failed round", len(reported))
+	}
+}
+
+func eventTypes(events []TableEvent) []TableEventType {
+	types := make([]TableEventType, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+func TestTableEvents(t *testing.T) {
+	tbl, _, _ := newDragon7Table(t, 7, "alice", "bob")
+	tbl.OpenBetting()
+	tbl.PlaceBet("alice", map[rules.BetType]int{rules.Banker: 100, rules.Dragon: 10})
+	if _, err := tbl.Deal(); err != nil {
+		t.Fatalf("Deal: %v", err)
+	}
+
+	events, _, err := tbl.Events(0)
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+	want := []TableEventType{
+		EventNewShoe, EventSeat, EventSeat,
+		EventPhase, EventBet, EventPhase,
+		EventCard, EventCard, EventCard, EventCard,
+		EventDecision, EventDecision, EventCard, EventOutcome,
+		EventSettlement, EventCutCard, EventPhase,
+	}
+	if got := eventTypes(events); !slices.Equal(got, want) {
+		t.Fatalf("event types = %v\nwant %v", got, want)
+	}
+	for i, e := range events {
+		if e.Seq != uint64(i+1) {
+			t.Fatalf("event %d has Seq %d", i, e.Seq)
+		}
+	}
+
+	phases := []TablePhase{events[3].Phase, events[5].Phase, events[16].Phase}
+	if !slices.Equal(phases, []TablePhase{PhaseBettingOpen, PhaseDealing, PhaseResolved}) || events[3].Deadline.IsZero() {
+		t.Errorf("phase events = %v, deadline %v", phases, events[3].Deadline)
+	}
+	if bet := events[4]; bet.Player != "alice" || bet.Seat != 1 || bet.Bets[rules.Dragon] != 10 {
+		t.Errorf("bet event = %+v", bet)
+	}
+	if stand := events[10]; stand.Decision.Side != SidePlayer || stand.Decision.Hit {
+		t.Errorf("player decision = %+v", stand.Decision)
+	}
+	if hit := events[11]; hit.Decision.Reason() != "Banker hits on 2 (Player stood)" {
+		t.Errorf("banker decision reason = %q", hit.Decision.Reason())
+	}
+	if third := events[12]; !third.Draw.ThirdCard || third.Draw.Side != SideBanker || third.PlayerPoints != 6 || third.BankerPoints != 7 {
+		t.Errorf("third card event = %+v", third)
+	}
+	if out := events[13]; out.Outcome != rules.OutcomeDragon7 || out.Round != 1 {
+		t.Errorf("outcome event = %+v", out)
+	}
+	if s := events[14]; s.Player != "alice" || s.Payout != 510 || s.Net != 400 || s.Balance != 1400 {
+		t.Errorf("settlement event = %+v", s)
+	}
+
+	// Resuming after an event returns only the ones after it.
+	resumed, next, err := tbl.Events(10)
+	if err != nil || len(resumed) != len(events)-10 || resumed[0].Seq != 11 {
+		t.Fatalf("Events(10) = %d events, %v", len(resumed), err)
+	}
+	last := events[len(events)-1].Seq
+	none, next, err := tbl.Events(last)
+	if err != nil || len(none) != 0 {
+		t.Fatalf("Events(last) = %v, %v", none, err)
+	}
+	select {
+	case <-next:
+		t.Fatalf("woken without a new event")
+	default:
+	}
+	tbl.OpenBetting()
+	select {
+	case <-next:
+	default:
+		t.Fatalf("not woken by a new event")
+	}
+	if _, _, err := tbl.Events(last + 5); err != ErrEventsExpired {
+		t.Errorf("Events ahead of the stream: %v, want ErrEventsExpired", err)
+	}
+
+	// Only the most recent events are held.
+	bob := tbl.State().Seats[1]
+	profile, _ := player.NewAccounts(player.NewFileBackend(t.TempDir())).Create(bob.Player, 1000)
+	for i := 0; i < eventHistory; i++ {
+		tbl.Leave(bob.Player)
+		tbl.Sit(profile)
+	}
+	if _, _, err := tbl.Events(1); err != ErrEventsExpired {
+		t.Errorf("Events after an expired Seq: %v, want ErrEventsExpired", err)
+	}
+	if held, _, err := tbl.Events(tbl.LastEventSeq() - 10); err != nil || len(held) != 10 {
+		t.Errorf("recent events = %d, %v", len(held), err)
+	}
+	// After 0 asks for every held event, even once the first ones are gone.
+	held, _, err := tbl.Events(0)
+	if err != nil || len(held) < eventHistory || held[0].Seq == 1 || held[len(held)-1].Seq != tbl.LastEventSeq() {
+		t.Errorf("every held event = %d events, %v", len(held), err)
+	}
+}
+
+func TestTableCountdown(t *testing.T) {
+	tbl, _, _ := newDragon7Table(t, 7, "alice")
+	tbl.cfg.BettingWindow = 100 * time.Millisecond
+	tbl.tick = 20 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := tbl.OpenBetting()
+	done := make(chan error)
+	go func() { done <- tbl.Run(ctx) }()
+	<-r.Done()
+	cancel()
+	<-done
+
+	events, _, _ := tbl.Events(0)
+	ticks := 0
+	for _, e := range events {
+		if e.Type == EventCountdown && e.Round == r.Number {
+			ticks++
+			if e.Remaining <= 0 || e.Remaining > tbl.cfg.BettingWindow || !e.Deadline.Equal(r.Deadline) {
+				t.Errorf("countdown event = %+v", e)
+			}
+		}
+	}
+	if ticks == 0 {
+		t.Errorf("no countdown events in a %v window ticking every %v", tbl.cfg.BettingWindow, tbl.tick)
+	}
+}
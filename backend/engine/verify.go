@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/niubaoshu/es-Baccarat/backend/fair"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// ShoeVerification is the result of replaying one revealed shoe against the round log.
+type ShoeVerification struct {
+	ShoeID string
+	Rounds int   // Number of logged hands replayed successfully
+	Err    error // nil when the shoe and every logged hand match
+}
+
+// VerifyShoe checks a revealed shoe against its commitment, then burns it by
+// the committed burn rule and replays every logged hand dealt from it, in log
+// order, comparing the cards, points and outcome of each hand with every entry
+// logged for it.
+func VerifyShoe(rev fair.Reveal, rounds []RoundLog) ShoeVerification {
+	v := ShoeVerification{ShoeID: rev.ShoeID}
+
+	shoe, err := rev.Verify()
+	if err != nil {
+		v.Err = err
+		return v
+	}
+	if shoe.BurnRule, err = model.ParseBurnRule(rev.BurnRule); err != nil {
+		v.Err = err
+		return v
+	}
+	if err := shoe.Burn(); err != nil {
+		v.Err = fmt.Errorf("burning rebuilt shoe: %w", err)
+		return v
+	}
+
+	// Every bettor of a hand has an entry of their own in the log, with the
+	// round ID of the hand: a hand is dealt once and each entry checked
+	// against it.
+	type dealtHand struct {
+		number int
+		result *RoundResult
+	}
+	dealt := make(map[string]dealtHand)
+	for _, logged := range rounds {
+		if logged.ShoeID != rev.ShoeID {
+			continue
+		}
+		h, ok := dealt[logged.RoundID]
+		newHand := !ok || logged.RoundID == ""
+		if newHand {
+			r, err := ResolveRound(shoe, rules.EZ, nil)
+			if err != nil {
+				v.Err = fmt.Errorf("round %d: %w", v.Rounds+1, err)
+				return v
+			}
+			h = dealtHand{number: v.Rounds + 1, result: r}
+			dealt[logged.RoundID] = h
+		}
+		if v.Err = checkLoggedHand(h.number, h.result, logged); v.Err != nil {
+			return v
+		}
+		if newHand {
+			v.Rounds++
+		}
+	}
+	return v
+}
+
+// checkLoggedHand compares the cards, points and outcome of the dealt hand
+// with the number-th hand of the shoe with a logged entry.
+func checkLoggedHand(number int, r *RoundResult, logged RoundLog) error {
+	player, banker := CardStrings(r.PlayerHand), CardStrings(r.BankerHand)
+	switch {
+	case !slices.Equal(player, logged.PlayerHand) || !slices.Equal(banker, logged.BankerHand):
+		return fmt.Errorf("round %d (%s): dealt Player %v / Banker %v, logged Player %v / Banker %v",
+			number, logged.Timestamp.Format("2006-01-02 15:04:05"), player, banker, logged.PlayerHand, logged.BankerHand)
+	case r.PlayerHand.TotalPoints() != logged.PlayerPoints || r.BankerHand.TotalPoints() != logged.BankerPoints:
+		return fmt.Errorf("round %d: points %d-%d, logged %d-%d",
+			number, r.PlayerHand.TotalPoints(), r.BankerHand.TotalPoints(), logged.PlayerPoints, logged.BankerPoints)
+	case string(r.Outcome) != logged.Outcome:
+		return fmt.Errorf("round %d: outcome %s, logged %s", number, r.Outcome, logged.Outcome)
+	}
+	return nil
+}
+
+// VerifyHistory verifies every revealed shoe in the shoe log against the round log.
+func VerifyHistory(shoes []ShoeLog, rounds []RoundLog) []ShoeVerification {
+	results := make([]ShoeVerification, 0, len(shoes))
+	for _, s := range shoes {
+		results = append(results, VerifyShoe(s.Reveal, rounds))
+	}
+	return results
+}
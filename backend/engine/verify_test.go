@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+func TestProvablyFairShoeVerifies(t *testing.T) {
+	logDir = t.TempDir()
+
+	cfg := config.DefaultConfig()
+	cfg.ProvablyFair = true
+	cfg.ClientSeed = "test-client"
+
+	d := NewShoeDealer(cfg, 0)
+	if _, err := d.NextShoe(); err != nil {
+		t.Fatalf("NextShoe: %v", err)
+	}
+	if d.Commitment == nil || d.Commitment.ShoeID != d.ShoeID {
+		t.Fatalf("expected a commitment for the new shoe, got %+v", d.Commitment)
+	}
+
+	var rounds []RoundLog
+	for i := 0; i < 10; i++ {
+		r, err := ResolveRound(d.Shoe, rules.EZ, nil)
+		if err != nil {
+			t.Fatalf("ResolveRound: %v", err)
+		}
+		log := NewRoundLog("tester", 0, 0, nil, r)
+		log.ShoeID = d.ShoeID
+		rounds = append(rounds, log)
+	}
+
+	reveal, err := d.Retire()
+	if err != nil || reveal == nil {
+		t.Fatalf("Retire = %v, %v", reveal, err)
+	}
+
+	shoes, err := LoadShoeLogs()
+	if err != nil || len(shoes) != 1 {
+		t.Fatalf("LoadShoeLogs = %v, %v", shoes, err)
+	}
+
+	results := VerifyHistory(shoes, rounds)
+	if len(results) != 1 || results[0].Err != nil || results[0].Rounds != 10 {
+		t.Fatalf("VerifyHistory = %+v", results)
+	}
+
+	// A tampered hand must be detected.
+	rounds[4].BankerHand = []string{"A♠", "A♠"}
+	if v := VerifyShoe(*reveal, rounds); v.Err == nil || v.Rounds != 4 {
+		t.Errorf("expected a mismatch at round 5, got %+v", v)
+	}
+}
+
+func TestProvablyFairTableWithSeveralBettorsVerifies(t *testing.T) {
+	logDir = t.TempDir()
+
+	cfg := config.DefaultConfig()
+	cfg.ProvablyFair = true
+	rounds := &memRounds{}
+	tbl, err := NewTable("table-1", cfg, 1, 7, rounds)
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	accounts := player.NewAccounts(player.NewFileBackend(t.TempDir()))
+	for _, name := range []string{"alice", "bob"} {
+		p, err := accounts.Create(name, 1000)
+		if err != nil {
+			t.Fatalf("Create(%s): %v", name, err)
+		}
+		tbl.Sit(p)
+	}
+	for i := 0; i < 3; i++ {
+		tbl.OpenBetting()
+		tbl.PlaceBet("alice", map[rules.BetType]int{rules.Player: 10})
+		tbl.PlaceBet("bob", map[rules.BetType]int{rules.Banker: 10})
+		if _, err := tbl.Deal(); err != nil {
+			t.Fatalf("Deal: %v", err)
+		}
+	}
+	commitment := tbl.State().Commitment
+	if err := tbl.Retire(); err != nil {
+		t.Fatalf("Retire: %v", err)
+	}
+	// The table publishes the seed of the retired shoe.
+	events, _, _ := tbl.Events(0)
+	last := events[len(events)-1]
+	if last.Type != EventShoeReveal || last.Reveal.Commitment != *commitment {
+		t.Fatalf("last event = %+v, want the reveal of %+v", last, commitment)
+	}
+	reveal := last.Reveal
+
+	logs, _ := rounds.LoadRounds()
+	if len(logs) != 6 {
+		t.Fatalf("%d rounds logged, want one per bettor and hand", len(logs))
+	}
+	if v := VerifyShoe(*reveal, logs); v.Err != nil || v.Rounds != 3 {
+		t.Errorf("VerifyShoe = %+v, want 3 hands verified", v)
+	}
+
+	// Every entry of a hand is checked, not just the first.
+	logs[3].PlayerHand = []string{"A♠", "A♠"}
+	if v := VerifyShoe(*reveal, logs); v.Err == nil {
+		t.Errorf("a tampered second entry of a hand was not detected: %+v", v)
+	}
+}
@@ -0,0 +1,139 @@
+// Package fair implements provably-fair shoes. Before a shoe is dealt the house
+// publishes a commitment: the SHA-256 hash of a secret server seed, the client
+// seed mixed into the shuffle, and the hash of the resulting card order. Once the
+// shoe is finished the server seed is revealed, and anyone can rebuild the shoe
+// and check it against the commitment and the hands that were dealt from it.
+package fair
+
+import (
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+)
+
+var (
+	ErrSeedMismatch  = errors.New("server seed does not match the committed hash")
+	ErrOrderMismatch = errors.New("rebuilt shoe order does not match the committed order hash")
+)
+
+// Commitment is published to players before the first card of a shoe is dealt.
+type Commitment struct {
+	ShoeID           string `json:"shoe_id"`
+	ServerSeedHash   string `json:"server_seed_hash"`
+	ClientSeed       string `json:"client_seed"`
+	OrderHash        string `json:"order_hash"`
+	DecksCount       int    `json:"decks_count"`
+	CutCardThreshold int    `json:"cut_card_threshold"`
+	// BurnRule is the burn run on the shoe, in the notation of
+	// model.BurnRule.String. Empty, in older logs, is a burn by face value.
+	BurnRule string `json:"burn_rule,omitempty"`
+}
+
+// Reveal is a Commitment together with its server seed, published once the shoe is retired.
+type Reveal struct {
+	Commitment
+	ServerSeed string `json:"server_seed"`
+}
+
+// NewSeed returns a random 256-bit seed encoded as hex.
+func NewSeed() (string, error) {
+	b := make([]byte, 32)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Hash returns the hex SHA-256 digest of a seed.
+func Hash(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// OrderHash returns the hex SHA-256 digest of a card order, using the cards' display strings.
+func OrderHash(cards []model.Card) string {
+	h := sha256.New()
+	for i, c := range cards {
+		if i > 0 {
+			h.Write([]byte{','})
+		}
+		h.Write([]byte(c.String()))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// stream is a deterministic byte stream: HMAC-SHA256(serverSeed, clientSeed:counter)
+// for counter = 0, 1, 2, ...
+type stream struct {
+	serverSeed []byte
+	clientSeed string
+	counter    uint64
+	buf        []byte
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.buf) == 0 {
+			mac := hmac.New(sha256.New, s.serverSeed)
+			mac.Write([]byte(s.clientSeed))
+			mac.Write([]byte{':'})
+			var ctr [8]byte
+			binary.BigEndian.PutUint64(ctr[:], s.counter)
+			mac.Write(ctr[:])
+			s.buf = mac.Sum(nil)
+			s.counter++
+		}
+		c := copy(p[n:], s.buf)
+		s.buf = s.buf[c:]
+		n += c
+	}
+	return n, nil
+}
+
+// NewRandomizer returns the deterministic shuffle source for a server/client seed pair.
+func NewRandomizer(serverSeed, clientSeed string) model.Randomizer {
+	return model.NewReaderRandomizer(&stream{serverSeed: []byte(serverSeed), clientSeed: clientSeed})
+}
+
+// Shuffle builds and shuffles a shoe from the seed pair.
+func Shuffle(serverSeed, clientSeed string, decksCount, cutCardThreshold int) *model.Shoe {
+	shoe := model.NewShoe(decksCount, cutCardThreshold)
+	shoe.SetRandomizer(NewRandomizer(serverSeed, clientSeed))
+	shoe.Shuffle()
+	return shoe
+}
+
+// Commit shuffles a new shoe from the seed pair and returns it with its public commitment.
+// The shoe ID is derived from the server seed hash.
+func Commit(serverSeed, clientSeed string, decksCount, cutCardThreshold int) (*model.Shoe, Commitment) {
+	shoe := Shuffle(serverSeed, clientSeed, decksCount, cutCardThreshold)
+	seedHash := Hash(serverSeed)
+	return shoe, Commitment{
+		ShoeID:           seedHash[:16],
+		ServerSeedHash:   seedHash,
+		ClientSeed:       clientSeed,
+		OrderHash:        OrderHash(shoe.Cards),
+		DecksCount:       decksCount,
+		CutCardThreshold: cutCardThreshold,
+	}
+}
+
+// Verify checks the revealed seed against the commitment and returns the rebuilt,
+// freshly shuffled shoe (before any burn).
+func (r Reveal) Verify() (*model.Shoe, error) {
+	if Hash(r.ServerSeed) != r.ServerSeedHash {
+		return nil, ErrSeedMismatch
+	}
+	shoe := Shuffle(r.ServerSeed, r.ClientSeed, r.DecksCount, r.CutCardThreshold)
+	if got := OrderHash(shoe.Cards); got != r.OrderHash {
+		return nil, fmt.Errorf("%w (got %s)", ErrOrderMismatch, got)
+	}
+	return shoe, nil
+}
@@ -0,0 +1,55 @@
+package fair
+
+import "testing"
+
+func TestCommitAndVerify(t *testing.T) {
+	serverSeed, err := NewSeed()
+	if err != nil {
+		t.Fatalf("NewSeed: %v", err)
+	}
+
+	shoe, c := Commit(serverSeed, "alice-lucky", 8, 14)
+	if c.ServerSeedHash != Hash(serverSeed) || c.ShoeID != c.ServerSeedHash[:16] {
+		t.Errorf("unexpected commitment: %+v", c)
+	}
+	if len(shoe.Cards) != 8*52 {
+		t.Fatalf("expected 416 cards, got %d", len(shoe.Cards))
+	}
+
+	rebuilt, err := Reveal{Commitment: c, ServerSeed: serverSeed}.Verify()
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	for i := range shoe.Cards {
+		if shoe.Cards[i] != rebuilt.Cards[i] {
+			t.Fatalf("rebuilt shoe differs at position %d", i)
+		}
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	_, c := Commit("server", "client", 1, 0)
+
+	if _, err := (Reveal{Commitment: c, ServerSeed: "other"}).Verify(); err != ErrSeedMismatch {
+		t.Errorf("expected ErrSeedMismatch, got %v", err)
+	}
+
+	c.ClientSeed = "tampered"
+	if _, err := (Reveal{Commitment: c, ServerSeed: "server"}).Verify(); err == nil {
+		t.Errorf("expected an order mismatch after changing the client seed")
+	}
+}
+
+func TestClientSeedChangesOrder(t *testing.T) {
+	a := Shuffle("server", "client-a", 1, 0)
+	b := Shuffle("server", "client-b", 1, 0)
+	same := true
+	for i := range a.Cards {
+		if a.Cards[i] != b.Cards[i] {
+			same = false
+		}
+	}
+	if same {
+		t.Errorf("different client seeds should produce different shoes")
+	}
+}
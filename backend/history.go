@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/engine"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/storage"
+)
+
+// runHistory implements the `history` subcommand: it audits the round log
+// against the rules package and the players' balance history, then prints a
+// summary per player.
+func runHistory(args []string) int {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	file := fs.String("file", "", "Round log to audit (default: the round history of --storage)")
+	playerName := fs.String("player", "", "Only audit the rounds of this player")
+	variant := fs.String("variant", config.DefaultConfig().Rules.Name(), "Rule set the rounds were played under: "+strings.Join(rules.VariantNames(), ", "))
+	pairPays := fs.String("pair_pays", rules.DefaultPairPays.String(), "Pair side bet pay table the rounds were played under")
+	decksCount := fs.Int("decks", config.DefaultConfig().DecksCount, "Number of decks, for the expected outcome frequencies")
+	storageCfg := storageFlags(fs)
+	fs.Parse(args)
+
+	rs, err := rules.Variant(*variant)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+	pays, err := rules.ParsePairPays(*pairPays)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+	rs = rules.WithPairs(rs, pays)
+	if err := config.ValidateDecks(*decksCount); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+
+	var rounds []engine.RoundLog
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 1
+		}
+		rounds, err = engine.ReadRoundLogs(f)
+		f.Close()
+	} else {
+		rounds, err = loadRounds(*storageCfg)
+	}
+	if err != nil {
+		fmt.Printf("Error reading game history: %v\n", err)
+		return 1
+	}
+	if *playerName != "" {
+		var mine []engine.RoundLog
+		for _, r := range rounds {
+			if r.Player == *playerName {
+				mine = append(mine, r)
+			}
+		}
+		rounds = mine
+	}
+	if len(rounds) == 0 {
+		fmt.Println("No rounds to audit.")
+		return 1
+	}
+
+	report := engine.AuditRounds(rounds, rs)
+	engine.PrintAuditReport(os.Stdout, report, *decksCount)
+	if len(report.Issues) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// loadRounds reads the round history kept in the configured store.
+func loadRounds(sc config.StorageConfig) ([]engine.RoundLog, error) {
+	st, err := storage.Open(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+	defer st.Close()
+	return st.LoadRounds()
+}
@@ -1,22 +1,82 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/niubaoshu/es-Baccarat/backend/analysis"
+	"github.com/niubaoshu/es-Baccarat/backend/auth"
 	"github.com/niubaoshu/es-Baccarat/backend/config"
 	"github.com/niubaoshu/es-Baccarat/backend/engine"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
 	"github.com/niubaoshu/es-Baccarat/backend/player"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/server"
+	"github.com/niubaoshu/es-Baccarat/backend/storage"
+	"github.com/niubaoshu/es-Baccarat/backend/strategy"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "verify":
+			os.Exit(runVerify(os.Args[2:]))
+		case "history":
+			os.Exit(runHistory(os.Args[2:]))
+		}
+	}
+
 	var (
 		playerName      string
 		createPlayer    bool
 		initialBalance  int
 		simulateRounds  int
 		simulateWorkers int
+		format          string
+		shoeStats       bool
+		serve           bool
+		serveAddr       string
+		bettingWindow   time.Duration
+		jwtSecret       string
+		jwtKey          string
+		tokenTTL        time.Duration
+		oauthProviders  string
+		seed            int64
+		shuffleMode     string
+		selfTestRounds  int
+		provablyFair    bool
+		clientSeed      string
+		decksCount      int
+		cutCard         int
+		cutCardMax      int
+		burn            string
+		variant         string
+		pairPays        string
+		betLimits       string
+		tableMax        int
+		betUnit         int
+		noPlayerBanker  bool
+		strategyName    string
+		sessions        int
+		bankroll        int
+		baseBet         int
+		strategyBet     string
+		maxHands        int
+		eor             bool
+		countName       string
+		countTags       string
+		countBet        string
+		countTrigger    float64
+		shoeFile        string
+		shoeBurn        bool
 	)
 
 	flag.StringVar(&playerName, "player", "", "Specify the player username")
@@ -24,26 +84,258 @@ func main() {
 	flag.IntVar(&initialBalance, "initial_balance", 10000, "Initial balance for a new player (default 10000)")
 	flag.IntVar(&simulateRounds, "simulate", 0, "Number of rounds to simulate mathematically (if > 0, skips interactive mode)")
 	flag.IntVar(&simulateWorkers, "workers", 4, "Number of concurrent workers for simulation")
+	flag.BoolVar(&shoeStats, "shoe_stats", false, "With --simulate, also report hands per shoe, burn counts, outcomes by cards left and Banker/Player streak lengths")
+	flag.StringVar(&format, "format", engine.FormatText, "Output format of the --simulate report: "+strings.Join(engine.ReportFormats(), ", "))
+	flag.BoolVar(&serve, "serve", false, "Start the gRPC lobby/table server instead of the interactive CLI")
+	flag.StringVar(&serveAddr, "addr", ":50051", "Listen address for --serve mode")
+	flag.DurationVar(&bettingWindow, "betting_window", config.DefaultConfig().BettingWindow, "How long each --serve table takes bets before dealing")
+	flag.StringVar(&jwtSecret, "jwt_secret", "", "Enable --serve login and sign session tokens with HS256 and this secret of at least 32 bytes (default $ES_BACCARAT_JWT_SECRET)")
+	flag.StringVar(&jwtKey, "jwt_key", "", "Enable --serve login and sign session tokens with RS256 and the RSA private key in this PEM file")
+	flag.DurationVar(&tokenTTL, "token_ttl", 24*time.Hour, "How long session tokens issued by --serve stay valid")
+	flag.StringVar(&oauthProviders, "oauth_providers", "", "Identity providers players log in with, each with this server's OAuth client ID: google:<client ID> or <name>:<client ID>=<userinfo URL>|<tokeninfo URL>, comma separated")
+	flag.Int64Var(&seed, "seed", 0, "Shuffle seed for reproducible shoes and simulations (0 = random)")
+	flag.StringVar(&shuffleMode, "shuffle", string(config.ShuffleStandard), "Shuffle source: 'standard' (math/rand, seedable) or 'crypto' (crypto/rand)")
+	flag.IntVar(&selfTestRounds, "shuffle_selftest", 0, "Run a chi-square self-test of the shuffle over this many shuffles and exit")
+	flag.BoolVar(&provablyFair, "provably_fair", false, "Commit to each shoe with a hashed server seed and reveal it when the shoe is retired")
+	flag.StringVar(&clientSeed, "client_seed", "", "Client seed mixed into provably-fair shuffles (default: random per shoe)")
+	flag.IntVar(&decksCount, "decks", config.DefaultConfig().DecksCount, fmt.Sprintf("Number of decks in the shoe (%d-%d)", config.MinDecks, config.MaxDecks))
+	flag.IntVar(&cutCard, "cut_card", config.DefaultConfig().CutCardThreshold, "Number of cards left behind the cut card")
+	flag.IntVar(&cutCardMax, "cut_card_max", 0, "If above --cut_card, place each shoe's cut card at random between --cut_card and this many cards from the end")
+	flag.StringVar(&burn, "burn", config.DefaultConfig().Burn.String(), "Burn procedure of a new shoe: face (burn as many cards as the face-up card, 10 for 10/J/Q/K), none, or a fixed number of cards")
+	flag.StringVar(&variant, "variant", config.DefaultConfig().Rules.Name(), "Rule set: "+strings.Join(rules.VariantNames(), ", "))
+	flag.StringVar(&pairPays, "pair_pays", rules.DefaultPairPays.String(), "Pair side bet pay table: Player Pair, Banker Pair, Either Pair, Perfect Pair and Perfect Pair on both hands (X to 1)")
+	flag.StringVar(&betLimits, "bet_limits", "", "Per-bet limits as <Type>:<Min>-<Max>, comma separated (e.g. P:10-5000,T:5-500)")
+	flag.IntVar(&tableMax, "table_max", 0, "Maximum total of all bets in a round (0 = no limit)")
+	flag.IntVar(&betUnit, "bet_unit", config.DefaultConfig().Limits.BetUnit, "Bets must be multiples of this amount")
+	flag.BoolVar(&noPlayerBanker, "no_player_and_banker", false, "Refuse bets on both Player and Banker in the same round")
+	flag.StringVar(&strategyName, "strategy", "all", "Betting strategy for --sessions: "+strings.Join(strategy.Names(), ", ")+" or all")
+	flag.IntVar(&sessions, "sessions", 0, "Number of independent strategy sessions to simulate (if > 0, skips interactive mode)")
+	flag.IntVar(&bankroll, "bankroll", 10000, "Starting bankroll of each strategy session")
+	flag.IntVar(&baseBet, "base_bet", 100, "Base stake of the betting strategy")
+	flag.StringVar(&strategyBet, "strategy_bet", "B", "Bet type the strategy plays (e.g. P, B)")
+	flag.IntVar(&maxHands, "max_hands", 1000, "Maximum number of hands per strategy session")
+	flag.BoolVar(&eor, "eor", false, "Print the effect of removal of each rank on every bet and exit")
+	flag.StringVar(&countName, "count", "", "With --simulate, bet a side bet only when this count system calls for it: "+strings.Join(strategy.CountNames(), ", ")+" or custom")
+	flag.StringVar(&countTags, "count_tags", "", "Rank tags of the count as <Rank>:<Tag>, comma separated (e.g. 4:-1,5:-1,6:-1,7:-1,8:2,9:2)")
+	flag.StringVar(&countBet, "count_bet", "", "Side bet the count plays (e.g. D, 8)")
+	flag.Float64Var(&countTrigger, "count_trigger", 0, "True count at or above which the count bets")
+	flag.StringVar(&shoeFile, "shoe_file", "", "Deal the cards listed in this file in order (e.g. \"AS 10H 7D ...\") instead of shuffled shoes")
+	flag.BoolVar(&shoeBurn, "shoe_burn", false, "Run the burn procedure on the --shoe_file shoe before dealing")
+	storageCfg := storageFlags(flag.CommandLine)
 	flag.Parse()
 
 	cfg := config.DefaultConfig()
+	cfg.DecksCount = decksCount
+	cfg.CutCardThreshold, cfg.CutCardMax = cutCard, cutCardMax
+	if br, err := model.ParseBurnRule(burn); err == nil {
+		cfg.Burn = br
+	} else {
+		fmt.Printf("Error: --burn: %v\n", err)
+		os.Exit(1)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if rs, err := rules.Variant(variant); err == nil {
+		cfg.Rules = rs
+	} else {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if pays, err := rules.ParsePairPays(pairPays); err == nil {
+		cfg.Rules = rules.WithPairs(cfg.Rules, pays)
+	} else {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if minBet, maxBet, err := rules.ParseBetLimits(betLimits); err == nil {
+		cfg.Limits.MinBet, cfg.Limits.MaxBet = minBet, maxBet
+	} else {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	cfg.Limits.TableMax = tableMax
+	cfg.Limits.BetUnit = betUnit
+	cfg.Limits.NoPlayerAndBanker = noPlayerBanker
+	cfg.Seed = seed
+	cfg.ShuffleMode = config.ShuffleMode(shuffleMode)
+	cfg.ProvablyFair = provablyFair
+	cfg.ClientSeed = clientSeed
+	cfg.Storage = *storageCfg
+	cfg.BettingWindow = bettingWindow
+	if cfg.ShuffleMode != config.ShuffleStandard && cfg.ShuffleMode != config.ShuffleCrypto {
+		fmt.Printf("Error: unknown shuffle mode '%s' (use 'standard' or 'crypto')\n", shuffleMode)
+		os.Exit(1)
+	}
+	if !slices.Contains(engine.ReportFormats(), format) {
+		fmt.Printf("Error: unknown --format %q (use %s)\n", format, strings.Join(engine.ReportFormats(), ", "))
+		os.Exit(1)
+	}
+	// Structured reports keep stdout clean for the report itself.
+	notes := os.Stdout
+	if format != engine.FormatText {
+		notes = os.Stderr
+	}
+	if seed != 0 {
+		fmt.Fprintf(notes, "Using shuffle seed %d.\n", seed)
+	}
+	if shoeFile != "" {
+		cards, err := loadShoeFile(shoeFile)
+		switch {
+		case err != nil:
+		case provablyFair:
+			err = fmt.Errorf("--shoe_file cannot be combined with --provably_fair")
+		case simulateRounds > 0 || sessions > 0 || eor || selfTestRounds > 0:
+			// Simulations and analyses always deal shuffled shoes.
+			err = fmt.Errorf("--shoe_file applies to the interactive game and --serve only")
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.PresetShoe = cards
+		cfg.BurnPresetShoe = shoeBurn
+		fmt.Printf("Dealing %d preset cards from %s.\n", len(cards), shoeFile)
+	}
+
+	// --- Shuffle Self-Test ---
+	if selfTestRounds > 0 {
+		if !runShuffleSelfTest(cfg, selfTestRounds) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --- Effect of Removal ---
+	if eor {
+		engine.PrintRemovalEffects(os.Stdout, analysis.EffectOfRemoval(analysis.NewComposition(cfg.DecksCount), cfg.Rules))
+		return
+	}
+
+	// --- Count Simulation Mode ---
+	if simulateRounds > 0 && countName != "" {
+		if format != engine.FormatText {
+			fmt.Println("Error: --format applies only to --simulate without --count")
+			os.Exit(1)
+		}
+		cs, err := countSystem(countName, countTags, countBet, countTrigger)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Starting count simulation of %d rounds using %d workers...\n", simulateRounds, simulateWorkers)
+		engine.RunCountSimulation(cfg, cs, simulateRounds, simulateWorkers).PrintCountReport(os.Stdout)
+		return
+	}
 
 	// --- Simulation Mode ---
 	if simulateRounds > 0 {
-		stats := engine.RunSimulation(cfg, simulateRounds, simulateWorkers)
-		stats.PrintReport()
+		fmt.Fprintf(notes, "Starting simulation of %d rounds using %d workers...\n", simulateRounds, min(max(simulateWorkers, 1), simulateRounds))
+		// Ctrl-C stops the workers and reports the rounds dealt so far; a
+		// second one kills the process as usual.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		var progress func(engine.SimulationProgress)
+		line := &progressLine{w: os.Stderr}
+		if isTerminal(os.Stderr) {
+			progress = line.update
+		}
+		stats := engine.RunSimulation(ctx, cfg, simulateRounds, engine.SimulationOptions{
+			Workers:   simulateWorkers,
+			Progress:  progress,
+			ShoeStats: shoeStats,
+		})
+		stop()
+		line.done()
+		if stats.Interrupted {
+			fmt.Fprintf(os.Stderr, "Interrupted after %d of %d rounds.\n", stats.TotalRounds, simulateRounds)
+			if stats.TotalRounds == 0 {
+				os.Exit(130)
+			}
+		}
+		if err := stats.WriteReport(os.Stdout, format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			os.Exit(1)
+		}
+		if stats.Interrupted {
+			os.Exit(130)
+		}
+		return
+	}
+
+	// --- Strategy Session Mode ---
+	if sessions > 0 {
+		if !runStrategySessions(cfg, strategyName, strategyBet, baseBet, engine.SessionConfig{Bankroll: bankroll, MaxHands: maxHands}, sessions, simulateWorkers) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --- gRPC Server Mode ---
+	if serve {
+		// Real-money-style tables refuse to open if the secure shuffle looks biased.
+		if cfg.ShuffleMode == config.ShuffleCrypto && !runShuffleSelfTest(cfg, 10000) {
+			os.Exit(1)
+		}
+		st, err := storage.Open(context.Background(), cfg.Storage)
+		if err != nil {
+			fmt.Printf("Error opening %s storage: %v\n", cfg.Storage.Backend, err)
+			os.Exit(1)
+		}
+		defer st.Close()
+		srv, err := server.New(cfg, st, initialBalance)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer srv.Close()
+		if jwtSecret == "" {
+			// Not the flag's default, which -help would print.
+			jwtSecret = os.Getenv("ES_BACCARAT_JWT_SECRET")
+		}
+		issuer, err := tokenIssuer(jwtSecret, jwtKey, tokenTTL)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if issuer != nil {
+			providers, err := auth.ParseProviders(oauthProviders)
+			if err == nil && len(providers) == 0 {
+				err = errors.New("--oauth_providers is required with --jwt_secret or --jwt_key")
+			}
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			srv.EnableAuth(issuer, providers...)
+		} else {
+			fmt.Printf("Warning: no --jwt_secret or --jwt_key; players are trusted to name themselves in %s.\n", server.PlayerMetadataKey)
+		}
+		fmt.Printf("Starting gRPC server on %s...\n", serveAddr)
+		if err := srv.ListenAndServe(serveAddr); err != nil {
+			fmt.Printf("Fatal server error: %v\n", err)
+			os.Exit(1)
+		}
 		return
 	}
 
 	// --- Interactive Mode ---
+	st, err := storage.Open(context.Background(), cfg.Storage)
+	if err != nil {
+		fmt.Printf("Error opening %s storage: %v\n", cfg.Storage.Backend, err)
+		os.Exit(1)
+	}
+	defer st.Close()
+	accounts := player.NewAccounts(st)
+
 	if playerName == "" && !createPlayer {
 		playerName = "default_player"
 		fmt.Printf("No player specified. Using '%s'.\n", playerName)
 
 		// Attempt to create implicitly if doesn't exist
-		_, err := player.LoadProfile(playerName)
-		if err == player.ErrPlayerNotFound {
-			_, err = player.CreateProfile(playerName, initialBalance)
+		_, err := accounts.Load(playerName)
+		if errors.Is(err, player.ErrPlayerNotFound) {
+			_, err = accounts.Create(playerName, initialBalance)
 			if err != nil {
 				fmt.Printf("Fatal error creating default player: %v\n", err)
 				os.Exit(1)
@@ -58,12 +350,11 @@ func main() {
 
 	// 2. Profile Loading or Creation
 	var p *player.Profile
-	var err error
 
 	if createPlayer {
-		p, err = player.CreateProfile(playerName, initialBalance)
+		p, err = accounts.Create(playerName, initialBalance)
 		if err != nil {
-			if err == player.ErrPlayerAlreadyExists {
+			if errors.Is(err, player.ErrPlayerAlreadyExists) {
 				fmt.Printf("Error: Player '%s' already exists. Cannot recreate or overwrite balance.\n", playerName)
 			} else {
 				fmt.Printf("Error creating player: %v\n", err)
@@ -72,9 +363,9 @@ func main() {
 		}
 		fmt.Printf("Successfully created '%s' with starting balance %d.\n", playerName, initialBalance)
 	} else {
-		p, err = player.LoadProfile(playerName)
+		p, err = accounts.Load(playerName)
 		if err != nil {
-			if err == player.ErrPlayerNotFound {
+			if errors.Is(err, player.ErrPlayerNotFound) {
 				fmt.Printf("Error: Player '%s' not found. Please use --create_player to register.\n", playerName)
 			} else {
 				fmt.Printf("Error loading profile: %v\n", err)
@@ -85,10 +376,11 @@ func main() {
 	}
 
 	// 3. Initialize Game Engine
-	game := engine.NewGame(cfg, p)
+	game := engine.NewGame(cfg, p, st)
 
 	// 4. Main Game Loop
 	fmt.Println("\n--- Starting EZ Baccarat Session ---")
+	defer game.Close()
 	for {
 		fmt.Printf("\n[ Current Balance: $%d ]\n", game.Profile.Balance)
 		if game.Profile.Balance <= 0 {
@@ -96,22 +388,169 @@ func main() {
 			break
 		}
 
-		bets := engine.PromptBets()
+		bets := engine.PromptBets(cfg.Rules, cfg.Limits, game.Profile.Balance)
 		if bets == nil {
 			fmt.Println("Thanks for playing! Exiting...")
 			break
 		}
 
-		totalBetAmount := 0
-		for _, v := range bets {
-			totalBetAmount += v
+		if err := game.PlayRound(bets); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	}
+}
+
+// tokenIssuer returns the issuer of the server's session tokens selected by
+// --jwt_secret or --jwt_key, or nil if neither is set.
+func tokenIssuer(secret, keyPath string, ttl time.Duration) (*auth.Issuer, error) {
+	switch {
+	case secret != "" && keyPath != "":
+		return nil, fmt.Errorf("set only one of --jwt_secret and --jwt_key")
+	case secret != "":
+		return auth.NewHS256([]byte(secret), ttl)
+	case keyPath != "":
+		return auth.LoadRS256(keyPath, ttl)
+	}
+	return nil, nil
+}
+
+// progressLine renders simulation progress on a single terminal line.
+type progressLine struct {
+	w     io.Writer
+	shown bool
+}
+
+// update redraws the line with p.
+func (l *progressLine) update(p engine.SimulationProgress) {
+	share := func(outcomes ...rules.Outcome) float64 {
+		total := 0.0
+		for _, o := range outcomes {
+			total += p.Share(o)
+		}
+		return total * 100
+	}
+	fmt.Fprintf(l.w, "\r%5.1f%%  %d/%d rounds  %.1fM rounds/s  ETA %s  Player %.2f%%  Banker %.2f%%  Tie %.2f%%\x1b[K",
+		float64(p.Rounds)*100/float64(p.TotalRounds), p.Rounds, p.TotalRounds, p.RoundsPerSecond()/1e6, p.ETA().Round(time.Second),
+		share(rules.OutcomePlayer, rules.OutcomePanda8), share(rules.OutcomeBanker, rules.OutcomeDragon7), share(rules.OutcomeTie))
+	l.shown = true
+}
+
+// done ends the progress line, if one was drawn.
+func (l *progressLine) done() {
+	if l.shown {
+		fmt.Fprintln(l.w)
+	}
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// storageFlags registers the --storage and --database_url flags on fs and
+// returns the storage settings they fill in.
+func storageFlags(fs *flag.FlagSet) *config.StorageConfig {
+	sc := config.DefaultConfig().Storage
+	fs.StringVar((*string)(&sc.Backend), "storage", string(sc.Backend), "Storage backend for accounts, round history and tables: 'file' or 'postgres'")
+	fs.StringVar(&sc.DatabaseURL, "database_url", os.Getenv("ES_BACCARAT_DATABASE_URL"), "PostgreSQL connection string for --storage=postgres (default $ES_BACCARAT_DATABASE_URL)")
+	return &sc
+}
+
+// runShuffleSelfTest runs the chi-square card position test for the configured
+// shuffle mode, prints the result and reports whether it passed.
+func runShuffleSelfTest(cfg *config.GameConfig, shuffles int) bool {
+	fmt.Printf("Running shuffle self-test (%s mode, %d shuffles)...\n", cfg.ShuffleMode, shuffles)
+	res := model.ShuffleSelfTest(engine.NewRandomizer(cfg, 0), shuffles, 0.001)
+	fmt.Printf("Chi-square: %.2f (df=%d), p-value: %.4f\n", res.ChiSquare, res.DF, res.PValue)
+	if !res.Passed {
+		fmt.Println("Shuffle self-test FAILED: card positions are not uniformly distributed.")
+		return false
+	}
+	fmt.Println("Shuffle self-test passed.")
+	return true
+}
+
+// loadShoeFile reads the ordered cards of a preset shoe.
+func loadShoeFile(path string) ([]model.Card, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := model.ParseCards(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("%s: no cards", path)
+	}
+	return cards, nil
+}
+
+// countSystem returns the named count system, with the tags, bet and trigger
+// replaced by the --count_* flags that were set. A "custom" count needs tags
+// and a bet.
+func countSystem(name, tags, bet string, trigger float64) (strategy.CountSystem, error) {
+	var cs strategy.CountSystem
+	if strings.EqualFold(name, "custom") {
+		if tags == "" || bet == "" {
+			return cs, fmt.Errorf("a custom count needs --count_tags and --count_bet")
 		}
+		cs.Name = "custom"
+	} else {
+		var err error
+		if cs, err = strategy.Count(name); err != nil {
+			return cs, err
+		}
+	}
 
-		if totalBetAmount > game.Profile.Balance {
-			fmt.Printf("Error: Insufficient funds. Total bet ($%d) exceeds balance ($%d).\n", totalBetAmount, game.Profile.Balance)
-			continue
+	var err error
+	flag.Visit(func(f *flag.Flag) {
+		if err != nil {
+			return
+		}
+		switch f.Name {
+		case "count_tags":
+			cs.Tags, err = strategy.ParseCountTags(tags)
+		case "count_bet":
+			if cs.Bet, err = rules.ParseBetType(bet); err != nil {
+				err = fmt.Errorf("%w: %s", err, bet)
+			}
+		case "count_trigger":
+			cs.Trigger = trigger
 		}
+	})
+	return cs, err
+}
 
-		game.PlayRound(bets)
+// runStrategySessions simulates sessions of the named strategy (or every built-in
+// one for "all") and prints the comparison report. It reports whether it succeeded.
+func runStrategySessions(cfg *config.GameConfig, name, betStr string, baseBet int, sc engine.SessionConfig, sessions, workers int) bool {
+	bet, err := rules.ParseBetType(betStr)
+	if err != nil {
+		fmt.Printf("Error: %v: %s\n", err, betStr)
+		return false
+	}
+	names := []string{name}
+	if strings.EqualFold(name, "all") {
+		names = strategy.Names()
+	}
+
+	fmt.Printf("Simulating %d sessions per strategy (%s $%d on %s)...\n", sessions, strings.Join(names, ", "), baseBet, bet)
+	var results []*engine.SessionStats
+	for _, n := range names {
+		factory, err := strategy.Builtin(n, bet, baseBet)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return false
+		}
+		res, err := engine.RunSessions(cfg, factory, sc, sessions, workers)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return false
+		}
+		results = append(results, res)
 	}
+	engine.PrintSessionReport(os.Stdout, results)
+	return true
 }
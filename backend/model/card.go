@@ -1,5 +1,13 @@
 package model
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
 // Suit represents the suit of a playing card.
 type Suit int
 
@@ -44,14 +52,67 @@ func (c Card) PointValue() int {
 	return int(c.Rank)
 }
 
-// String returns a short string representation of the card (e.g., "AS", "10H").
+// String returns a short string representation of the card (e.g., "A♠", "10♥"). ParseCard is its inverse.
 func (c Card) String() string {
-	ranks := map[Rank]string{
-		Ace: "A", Two: "2", Three: "3", Four: "4", Five: "5", Six: "6",
-		Seven: "7", Eight: "8", Nine: "9", Ten: "10", Jack: "J", Queen: "Q", King: "K",
+	var rank, suit string
+	if c.Rank >= Ace && c.Rank <= King {
+		rank = rankNames[c.Rank]
 	}
-	suits := map[Suit]string{
-		Spades: "♠", Hearts: "♥", Diamonds: "♦", Clubs: "♣",
+	if c.Suit >= Spades && c.Suit <= Clubs {
+		suit = suitNames[c.Suit]
+	}
+	return rank + suit
+}
+
+var rankNames = [...]string{
+	Ace: "A", Two: "2", Three: "3", Four: "4", Five: "5", Six: "6",
+	Seven: "7", Eight: "8", Nine: "9", Ten: "10", Jack: "J", Queen: "Q", King: "K",
+}
+
+var suitNames = [...]string{Spades: "♠", Hearts: "♥", Diamonds: "♦", Clubs: "♣"}
+
+// ErrInvalidCard is returned when a card's notation cannot be parsed.
+var ErrInvalidCard = errors.New("invalid card")
+
+// ParseCard parses a card in the notation of Card.String, or with a letter for
+// the suit: "A♠", "AS", "10H" and "TH" are all accepted (case-insensitive).
+func ParseCard(s string) (Card, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	suitRune, size := utf8.DecodeLastRuneInString(s)
+	suit, ok := map[rune]Suit{
+		'♠': Spades, 'S': Spades,
+		'♥': Hearts, 'H': Hearts,
+		'♦': Diamonds, 'D': Diamonds,
+		'♣': Clubs, 'C': Clubs,
+	}[suitRune]
+	if !ok {
+		return Card{}, fmt.Errorf("%w %q: unknown suit", ErrInvalidCard, s)
+	}
+	rank, ok := map[string]Rank{
+		"A": Ace, "2": Two, "3": Three, "4": Four, "5": Five, "6": Six, "7": Seven,
+		"8": Eight, "9": Nine, "10": Ten, "T": Ten, "J": Jack, "Q": Queen, "K": King,
+	}[s[:len(s)-size]]
+	if !ok {
+		return Card{}, fmt.Errorf("%w %q: unknown rank", ErrInvalidCard, s)
+	}
+	return Card{Suit: suit, Rank: rank}, nil
+}
+
+// ParseCards parses a list of cards separated by spaces, commas or new lines.
+// Text from a '#' to the end of its line is a comment.
+func ParseCards(s string) ([]Card, error) {
+	var cards []Card
+	for _, line := range strings.Split(s, "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		for _, field := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			c, err := ParseCard(field)
+			if err != nil {
+				return nil, err
+			}
+			cards = append(cards, c)
+		}
 	}
-	return ranks[c.Rank] + suits[c.Suit]
+	return cards, nil
 }
@@ -1,6 +1,9 @@
 package model
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestCardPointValue(t *testing.T) {
 	tests := []struct {
@@ -36,3 +39,59 @@ func TestCardString(t *testing.T) {
 		t.Errorf("Expected 10♥, got %s", c2.String())
 	}
 }
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Card
+	}{
+		{"A♠", Card{Spades, Ace}},
+		{"AS", Card{Spades, Ace}},
+		{"10H", Card{Hearts, Ten}},
+		{"TH", Card{Hearts, Ten}},
+		{"th", Card{Hearts, Ten}},
+		{"10♥", Card{Hearts, Ten}},
+		{" 7d ", Card{Diamonds, Seven}},
+		{"K♣", Card{Clubs, King}},
+		{"QC", Card{Clubs, Queen}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCard(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseCard(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	// ParseCard is the inverse of String.
+	for _, c := range NewShoe(1, 0).Cards {
+		if got, err := ParseCard(c.String()); err != nil || got != c {
+			t.Errorf("ParseCard(%q) = %v, %v", c.String(), got, err)
+		}
+	}
+
+	for _, bad := range []string{"", "A", "S", "1S", "11H", "AX", "ZS", "10"} {
+		if _, err := ParseCard(bad); !errors.Is(err, ErrInvalidCard) {
+			t.Errorf("ParseCard(%q) error = %v, want ErrInvalidCard", bad, err)
+		}
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	cards, err := ParseCards("# Dragon 7\n10S 2H, 6D\tKC\n\n5H # banker's third card\n")
+	if err != nil {
+		t.Fatalf("ParseCards: %v", err)
+	}
+	want := []Card{{Spades, Ten}, {Hearts, Two}, {Diamonds, Six}, {Clubs, King}, {Hearts, Five}}
+	if len(cards) != len(want) {
+		t.Fatalf("ParseCards returned %v, want %v", cards, want)
+	}
+	for i := range want {
+		if cards[i] != want[i] {
+			t.Errorf("card %d = %v, want %v", i, cards[i], want[i])
+		}
+	}
+
+	if _, err := ParseCards("AS 1H"); !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("ParseCards with a bad card: error = %v", err)
+	}
+}
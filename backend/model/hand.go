@@ -42,3 +42,14 @@ func (h *Hand) String() string {
 	}
 	return res
 }
+
+// IsPair reports whether the first two cards of the hand have the same rank.
+func (h *Hand) IsPair() bool {
+	return len(h.Cards) >= 2 && h.Cards[0].Rank == h.Cards[1].Rank
+}
+
+// IsSuitedPair reports whether the first two cards of the hand are identical in rank and suit.
+// It is only possible with more than one deck in the shoe.
+func (h *Hand) IsSuitedPair() bool {
+	return h.IsPair() && h.Cards[0].Suit == h.Cards[1].Suit
+}
@@ -61,3 +61,30 @@ func TestHandAddCard(t *testing.T) {
 		t.Errorf("Expected 2 cards, got %d", len(h.Cards))
 	}
 }
+
+func TestHandIsPair(t *testing.T) {
+	tests := []struct {
+		name       string
+		cards      []Card
+		pair       bool
+		suitedPair bool
+	}{
+		{"Mixed Pair", []Card{{Spades, Queen}, {Hearts, Queen}}, true, false},
+		{"Suited Pair", []Card{{Hearts, Seven}, {Hearts, Seven}}, true, true},
+		{"Ten and King are not a Pair", []Card{{Spades, Ten}, {Spades, King}}, false, false},
+		{"Third Card does not count", []Card{{Spades, Two}, {Hearts, Three}, {Diamonds, Two}}, false, false},
+		{"Single Card", []Card{{Spades, Eight}}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Hand{Cards: tt.cards}
+			if got := h.IsPair(); got != tt.pair {
+				t.Errorf("Hand.IsPair() = %v, want %v", got, tt.pair)
+			}
+			if got := h.IsSuitedPair(); got != tt.suitedPair {
+				t.Errorf("Hand.IsSuitedPair() = %v, want %v", got, tt.suitedPair)
+			}
+		})
+	}
+}
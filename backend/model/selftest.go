@@ -0,0 +1,61 @@
+package model
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/niubaoshu/es-Baccarat/backend/stats"
+)
+
+// ShuffleTestResult summarises a chi-square test of card positions over many shuffles.
+type ShuffleTestResult struct {
+	Shuffles  int
+	DeckSize  int
+	ChiSquare float64
+	DF        int
+	PValue    float64
+	Passed    bool
+}
+
+// ShuffleSelfTest repeatedly shuffles a single ordered deck with rng and tests
+// whether every card is equally likely to end up in every position. The test
+// passes when the chi-square p-value is at least alpha (e.g. 0.001).
+// A nil rng uses the default clock-seeded source.
+func ShuffleSelfTest(rng Randomizer, shuffles int, alpha float64) ShuffleTestResult {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	shoe := NewShoe(1, 0)
+	shoe.SetRandomizer(rng)
+	n := len(shoe.Cards)
+
+	// counts[card*n + position], where card is the card's index in a fresh, unshuffled deck.
+	counts := make([]int, n*n)
+	for i := 0; i < shuffles; i++ {
+		shoe.populate()
+		shoe.Shuffle()
+		for pos, c := range shoe.Cards {
+			card := int(c.Suit)*13 + int(c.Rank) - 1
+			counts[card*n+pos]++
+		}
+	}
+
+	expected := make([]float64, n*n)
+	for i := range expected {
+		expected[i] = float64(shuffles) / float64(n)
+	}
+	chi, _ := stats.ChiSquare(counts, expected)
+
+	// Row and column totals are fixed, leaving (n-1)^2 degrees of freedom.
+	df := (n - 1) * (n - 1)
+	p := stats.ChiSquarePValue(chi, df)
+	return ShuffleTestResult{
+		Shuffles:  shuffles,
+		DeckSize:  n,
+		ChiSquare: chi,
+		DF:        df,
+		PValue:    p,
+		Passed:    p >= alpha,
+	}
+}
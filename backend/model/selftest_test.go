@@ -0,0 +1,28 @@
+package model
+
+import "testing"
+
+// biasedRandomizer always picks the lowest index, reproducing a classic broken shuffle.
+type biasedRandomizer struct{}
+
+func (biasedRandomizer) Intn(n int) int { return 0 }
+
+func TestShuffleSelfTest(t *testing.T) {
+	res := ShuffleSelfTest(NewSeededRandomizer(1), 5000, 0.001)
+	if !res.Passed {
+		t.Errorf("Seeded math/rand shuffle should pass, got %+v", res)
+	}
+	if res.DF != 51*51 {
+		t.Errorf("DF = %d, want %d", res.DF, 51*51)
+	}
+
+	res = ShuffleSelfTest(NewCryptoRandomizer(), 2000, 1e-9)
+	if !res.Passed {
+		t.Errorf("crypto/rand shuffle should pass, got %+v", res)
+	}
+
+	res = ShuffleSelfTest(biasedRandomizer{}, 2000, 0.001)
+	if res.Passed {
+		t.Errorf("Biased shuffle should fail, got %+v", res)
+	}
+}
@@ -1,20 +1,180 @@
 package model
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"io"
+	"math/bits"
 	"math/rand"
+	randv2 "math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
 var ErrShoeEmpty = errors.New("shoe is empty")
 var ErrPastCutCard = errors.New("cut card reached, please shuffle shoe")
 
+// Randomizer is the source of randomness used to shuffle a shoe.
+// Intn must return a uniformly distributed value in [0, n).
+// *math/rand.Rand satisfies this interface.
+type Randomizer interface {
+	Intn(n int) int
+}
+
+// NewSeededRandomizer returns a deterministic Randomizer: the same seed always
+// produces the same sequence of shuffles.
+func NewSeededRandomizer(seed int64) Randomizer {
+	return rand.New(rand.NewSource(seed))
+}
+
+// DeriveSeed returns an independent seed for the given stream number (e.g. a
+// simulation worker), so that several streams can be derived from one master seed.
+func DeriveSeed(seed int64, stream int) int64 {
+	// SplitMix64 finalizer
+	z := uint64(seed) + uint64(stream+1)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
+
+// pcgRandomizer is a PCG generator with an inlined, unbiased bounded draw.
+type pcgRandomizer struct {
+	pcg randv2.PCG
+}
+
+// NewFastRandomizer returns a deterministic Randomizer backed by a PCG
+// generator. It is much cheaper per draw than NewSeededRandomizer, but the same
+// seed yields a different sequence of shuffles from it.
+func NewFastRandomizer(seed int64) Randomizer {
+	r := &pcgRandomizer{}
+	r.pcg.Seed(uint64(seed), uint64(DeriveSeed(seed, 0)))
+	return r
+}
+
+func (r *pcgRandomizer) Intn(n int) int {
+	if n <= 0 {
+		panic("model: invalid argument to Intn")
+	}
+	// Lemire's multiply-shift, rejecting the low partial bucket.
+	bound := uint64(n)
+	hi, lo := bits.Mul64(r.pcg.Uint64(), bound)
+	if lo < bound {
+		thresh := -bound % bound
+		for lo < thresh {
+			hi, lo = bits.Mul64(r.pcg.Uint64(), bound)
+		}
+	}
+	return int(hi)
+}
+
+// readerRandomizer draws uniform integers from an entropy stream.
+type readerRandomizer struct {
+	r   io.Reader
+	buf [8]byte
+}
+
+// NewReaderRandomizer returns a Randomizer that consumes bytes from r, for example
+// crypto/rand.Reader or a recorded entropy file. It uses rejection sampling so the
+// results carry no modulo bias. Intn panics if r fails to deliver bytes.
+func NewReaderRandomizer(r io.Reader) Randomizer {
+	return &readerRandomizer{r: r}
+}
+
+// NewCryptoRandomizer returns an unpredictable Randomizer backed by crypto/rand.
+// Shoes shuffled with it cannot be reconstructed from the session start time.
+func NewCryptoRandomizer() Randomizer {
+	return NewReaderRandomizer(cryptorand.Reader)
+}
+
+func (rr *readerRandomizer) Intn(n int) int {
+	if n <= 0 {
+		panic("model: invalid argument to Intn")
+	}
+	// Reject the top partial bucket so every residue is equally likely.
+	limit := ^uint64(0) - ^uint64(0)%uint64(n)
+	for {
+		if _, err := io.ReadFull(rr.r, rr.buf[:]); err != nil {
+			panic(fmt.Sprintf("model: entropy source failed: %v", err))
+		}
+		v := binary.LittleEndian.Uint64(rr.buf[:])
+		if v < limit {
+			return int(v % uint64(n))
+		}
+	}
+}
+
+// BurnMode selects the burn procedure run on a new shoe.
+type BurnMode string
+
+const (
+	// BurnFaceValue draws one face-up card and burns as many cards as its rank,
+	// with 10/J/Q/K counting as 10.
+	BurnFaceValue BurnMode = "face"
+	// BurnFixed burns a fixed number of cards.
+	BurnFixed BurnMode = "fixed"
+	// BurnNone burns nothing.
+	BurnNone BurnMode = "none"
+)
+
+// ErrInvalidBurnRule is returned when a burn rule cannot be parsed.
+var ErrInvalidBurnRule = errors.New("invalid burn rule")
+
+// BurnRule is the burn procedure of a shoe. The zero value burns by face value.
+type BurnRule struct {
+	Mode  BurnMode
+	Count int // cards burned by BurnFixed
+}
+
+// ParseBurnRule parses a burn rule in the notation of BurnRule.String: "face",
+// "none", or the number of cards of a fixed burn.
+func ParseBurnRule(s string) (BurnRule, error) {
+	switch mode := BurnMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case BurnFaceValue, "":
+		return BurnRule{Mode: BurnFaceValue}, nil
+	case BurnNone:
+		return BurnRule{Mode: BurnNone}, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 {
+		return BurnRule{}, fmt.Errorf("%w %q (use face, none or a number of cards)", ErrInvalidBurnRule, s)
+	}
+	return BurnRule{Mode: BurnFixed, Count: n}, nil
+}
+
+func (r BurnRule) String() string {
+	switch r.Mode {
+	case BurnFixed:
+		return strconv.Itoa(r.Count)
+	case BurnNone:
+		return string(BurnNone)
+	}
+	return string(BurnFaceValue)
+}
+
+// MaxCards returns the most cards the rule can burn, face-up card included.
+func (r BurnRule) MaxCards() int {
+	switch r.Mode {
+	case BurnFixed:
+		return r.Count
+	case BurnNone:
+		return 0
+	}
+	return 1 + 10
+}
+
 // Shoe represents the dealer's shoe containing multiple decks of cards.
 type Shoe struct {
 	Cards            []Card
 	DecksCount       int
 	CutCardThreshold int
-	currentIndex     int
+	// BurnRule is the procedure run by Burn.
+	BurnRule     BurnRule
+	currentIndex int
+	rng          Randomizer
 }
 
 // NewShoe initializes a new Shoe with a basic, unshuffled set of decks.
@@ -28,6 +188,16 @@ func NewShoe(decksCount int, cutCardThreshold int) *Shoe {
 	return s
 }
 
+// NewStackedShoe returns a shoe that deals the given cards in order, for
+// scripted scenarios and regression tests. It has no cut card: every card is
+// dealt before IsPastCutCard reports true. Shuffle discards the stacking.
+func NewStackedShoe(cards []Card) *Shoe {
+	return &Shoe{
+		Cards:      slices.Clone(cards),
+		DecksCount: (len(cards) + 51) / 52,
+	}
+}
+
 // populate fills the shoe with standard decks in order.
 func (s *Shoe) populate() {
 	s.Cards = s.Cards[:0]
@@ -44,9 +214,23 @@ func (s *Shoe) populate() {
 	s.currentIndex = 0
 }
 
+// SetRandomizer sets the source of randomness used by Shuffle.
+// A nil Randomizer (the default) seeds a new generator from the clock on every shuffle.
+func (s *Shoe) SetRandomizer(r Randomizer) {
+	s.rng = r
+}
+
+// randomizer returns the shoe's Randomizer, or a new clock-seeded one.
+func (s *Shoe) randomizer() Randomizer {
+	if s.rng == nil {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return s.rng
+}
+
 // Shuffle randomizes the order of the cards in the shoe and resets the current index.
 func (s *Shoe) Shuffle() {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := s.randomizer()
 	for i := len(s.Cards) - 1; i > 0; i-- {
 		j := r.Intn(i + 1)
 		s.Cards[i], s.Cards[j] = s.Cards[j], s.Cards[i]
@@ -71,24 +255,57 @@ func (s *Shoe) CardsLeft() int {
 	return len(s.Cards) - s.currentIndex
 }
 
+// Remaining returns the number of cards of each rank not yet drawn, indexed by Rank
+// (index 0 is unused).
+func (s *Shoe) Remaining() [King + 1]int {
+	var counts [King + 1]int
+	for _, c := range s.Cards[s.currentIndex:] {
+		counts[c.Rank]++
+	}
+	return counts
+}
+
 // IsPastCutCard returns true if the number of cards left is less than or equal to the CutCardThreshold.
 // In actual gameplay, if this returns true the current hand is finished, and a new shoe/shuffle is triggered before the next hand.
 func (s *Shoe) IsPastCutCard() bool {
 	return s.CardsLeft() <= s.CutCardThreshold
 }
 
-// Burn performs the standard Baccarat burn card procedure.
-// It draws one face up card, looks at its baccarat point value (10/J/Q/K is considered 10 for burning purposes in many casinos),
-// and then burns (draws and discards) that many cards.
-func (s *Shoe) Burn() error {
-	faceUpCard, err := s.Draw()
-	if err != nil {
-		return err
+// BurnCount returns how many cards are burned under a face-up card: its rank,
+// with 10/J/Q/K counting as 10.
+func BurnCount(faceUp Card) int {
+	if faceUp.Rank >= Ten {
+		return 10
+	}
+	return int(faceUp.Rank)
+}
+
+// PlaceCutCard inserts the cut card so that between lo and hi cards, chosen
+// uniformly with the shoe's Randomizer, are left behind it, as a dealer does
+// by eye. With hi <= lo it is placed lo cards from the end without drawing a
+// random number.
+func (s *Shoe) PlaceCutCard(lo, hi int) {
+	s.CutCardThreshold = lo
+	if hi > lo {
+		s.CutCardThreshold += s.randomizer().Intn(hi - lo + 1)
 	}
+}
 
-	burnCount := int(faceUpCard.Rank)
-	if faceUpCard.Rank >= Ten {
-		burnCount = 10
+// Burn runs the shoe's BurnRule. The standard Baccarat procedure, BurnFaceValue,
+// draws one face up card, looks at its baccarat point value (10/J/Q/K is considered 10 for burning purposes in many casinos),
+// and then burns (draws and discards) that many cards.
+func (s *Shoe) Burn() error {
+	burnCount := 0
+	switch s.BurnRule.Mode {
+	case BurnNone:
+	case BurnFixed:
+		burnCount = s.BurnRule.Count
+	default:
+		faceUpCard, err := s.Draw()
+		if err != nil {
+			return err
+		}
+		burnCount = BurnCount(faceUpCard)
 	}
 
 	for i := 0; i < burnCount; i++ {
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 )
 
@@ -89,3 +91,216 @@ func TestShoeBurn(t *testing.T) {
 		t.Errorf("Expected 41 cards left after burning for Jack, got %d", shoe2.CardsLeft())
 	}
 }
+
+func TestShoeBurnRules(t *testing.T) {
+	tests := []struct {
+		rule BurnRule
+		left int
+	}{
+		{BurnRule{}, 50}, // face value: Ace of Spades on top burns one more card
+		{BurnRule{Mode: BurnFaceValue}, 50},
+		{BurnRule{Mode: BurnFixed, Count: 5}, 47},
+		{BurnRule{Mode: BurnFixed}, 52},
+		{BurnRule{Mode: BurnNone}, 52},
+	}
+	for _, tt := range tests {
+		shoe := NewShoe(1, 0)
+		shoe.BurnRule = tt.rule
+		if err := shoe.Burn(); err != nil {
+			t.Fatalf("%v: %v", tt.rule, err)
+		}
+		if shoe.CardsLeft() != tt.left {
+			t.Errorf("burn %v left %d cards, want %d", tt.rule, shoe.CardsLeft(), tt.left)
+		}
+	}
+
+	shoe := NewStackedShoe([]Card{{Rank: Two}, {Rank: Three}})
+	shoe.BurnRule = BurnRule{Mode: BurnFixed, Count: 3}
+	if err := shoe.Burn(); err != ErrShoeEmpty {
+		t.Errorf("burning 3 of 2 cards: err = %v, want ErrShoeEmpty", err)
+	}
+}
+
+func TestParseBurnRule(t *testing.T) {
+	for in, want := range map[string]BurnRule{
+		"face": {Mode: BurnFaceValue},
+		"":     {Mode: BurnFaceValue},
+		"None": {Mode: BurnNone},
+		"0":    {Mode: BurnFixed},
+		" 7 ":  {Mode: BurnFixed, Count: 7},
+	} {
+		got, err := ParseBurnRule(in)
+		if err != nil || got != want {
+			t.Errorf("ParseBurnRule(%q) = %v, %v; want %v", in, got, err, want)
+		}
+		if again, _ := ParseBurnRule(got.String()); again != got {
+			t.Errorf("%v does not round-trip through %q", got, got.String())
+		}
+	}
+	for _, in := range []string{"-1", "ten", "3.5"} {
+		if _, err := ParseBurnRule(in); !errors.Is(err, ErrInvalidBurnRule) {
+			t.Errorf("ParseBurnRule(%q) err = %v, want ErrInvalidBurnRule", in, err)
+		}
+	}
+}
+
+func TestShoePlaceCutCard(t *testing.T) {
+	shoe := NewShoe(8, 0)
+	shoe.SetRandomizer(NewSeededRandomizer(3))
+	seen := make(map[int]bool)
+	for i := 0; i < 500; i++ {
+		shoe.PlaceCutCard(52, 78)
+		if shoe.CutCardThreshold < 52 || shoe.CutCardThreshold > 78 {
+			t.Fatalf("cut card placed %d cards from the end", shoe.CutCardThreshold)
+		}
+		seen[shoe.CutCardThreshold] = true
+	}
+	if len(seen) != 27 {
+		t.Errorf("placed the cut card at %d of 27 positions", len(seen))
+	}
+
+	shoe.SetRandomizer(NewReaderRandomizer(bytes.NewReader(nil))) // panics if drawn from
+	shoe.PlaceCutCard(14, 14)
+	if shoe.CutCardThreshold != 14 {
+		t.Errorf("fixed cut card placed %d cards from the end", shoe.CutCardThreshold)
+	}
+}
+
+func TestShoeSeededShuffleIsReproducible(t *testing.T) {
+	a := NewShoe(8, 14)
+	a.SetRandomizer(NewSeededRandomizer(42))
+	a.Shuffle()
+
+	b := NewShoe(8, 14)
+	b.SetRandomizer(NewSeededRandomizer(42))
+	b.Shuffle()
+
+	c := NewShoe(8, 14)
+	c.SetRandomizer(NewSeededRandomizer(43))
+	c.Shuffle()
+
+	same, different := true, false
+	for i := range a.Cards {
+		if a.Cards[i] != b.Cards[i] {
+			same = false
+		}
+		if a.Cards[i] != c.Cards[i] {
+			different = true
+		}
+	}
+	if !same {
+		t.Errorf("Expected identical order for the same seed")
+	}
+	if !different {
+		t.Errorf("Expected a different order for a different seed")
+	}
+}
+
+func TestReaderRandomizer(t *testing.T) {
+	// A stream of 0xFF bytes is always rejected for n=3 (it falls in the biased top bucket),
+	// so the randomizer must move on to the next 8 bytes.
+	data := append(bytes.Repeat([]byte{0xFF}, 8), 5, 0, 0, 0, 0, 0, 0, 0)
+	r := NewReaderRandomizer(bytes.NewReader(data))
+	if got := r.Intn(3); got != 2 {
+		t.Errorf("Intn(3) = %d, want 2", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic on exhausted entropy source")
+		}
+	}()
+	r.Intn(3)
+}
+
+func TestFastRandomizer(t *testing.T) {
+	a, b := NewFastRandomizer(7), NewFastRandomizer(7)
+	for i := 0; i < 1000; i++ {
+		n := i%52 + 1
+		x := a.Intn(n)
+		if x < 0 || x >= n {
+			t.Fatalf("Intn(%d) = %d", n, x)
+		}
+		if y := b.Intn(n); x != y {
+			t.Fatalf("same seed diverged at draw %d: %d != %d", i, x, y)
+		}
+	}
+
+	if res := ShuffleSelfTest(NewFastRandomizer(11), 20000, 0.001); !res.Passed {
+		t.Errorf("fast randomizer failed the shuffle self-test: %+v", res)
+	}
+}
+
+func TestDeriveSeed(t *testing.T) {
+	seen := make(map[int64]bool)
+	for stream := 0; stream < 100; stream++ {
+		s := DeriveSeed(7, stream)
+		if seen[s] {
+			t.Fatalf("DeriveSeed produced a duplicate seed for stream %d", stream)
+		}
+		seen[s] = true
+		if s != DeriveSeed(7, stream) {
+			t.Fatalf("DeriveSeed is not deterministic")
+		}
+	}
+}
+
+func TestShoeRemaining(t *testing.T) {
+	shoe := NewShoe(2, 0)
+	for r := Ace; r <= King; r++ {
+		if n := shoe.Remaining()[r]; n != 8 {
+			t.Fatalf("fresh 2-deck shoe has %d cards of rank %d, want 8", n, r)
+		}
+	}
+
+	drawn := make(map[Rank]int)
+	for i := 0; i < 30; i++ {
+		c, _ := shoe.Draw()
+		drawn[c.Rank]++
+	}
+	rem := shoe.Remaining()
+	total := 0
+	for r := Ace; r <= King; r++ {
+		if rem[r] != 8-drawn[r] {
+			t.Errorf("rank %d: %d remaining, want %d", r, rem[r], 8-drawn[r])
+		}
+		total += rem[r]
+	}
+	if total != shoe.CardsLeft() {
+		t.Errorf("Remaining totals %d, CardsLeft is %d", total, shoe.CardsLeft())
+	}
+}
+
+func TestStackedShoe(t *testing.T) {
+	cards := []Card{{Spades, Ace}, {Hearts, Ten}, {Diamonds, Seven}}
+	shoe := NewStackedShoe(cards)
+	cards[0] = Card{Clubs, King} // the shoe keeps its own copy
+
+	if shoe.DecksCount != 1 || shoe.IsPastCutCard() {
+		t.Fatalf("stacked shoe: %d decks, past cut card %v", shoe.DecksCount, shoe.IsPastCutCard())
+	}
+	for _, want := range []Card{{Spades, Ace}, {Hearts, Ten}, {Diamonds, Seven}} {
+		if c, err := shoe.Draw(); err != nil || c != want {
+			t.Errorf("Draw() = %v, %v, want %v", c, err, want)
+		}
+	}
+	if !shoe.IsPastCutCard() {
+		t.Errorf("a stacked shoe should reach its cut card only when empty")
+	}
+	if _, err := shoe.Draw(); err != ErrShoeEmpty {
+		t.Errorf("Draw() on an empty shoe: %v, want ErrShoeEmpty", err)
+	}
+
+	// A Jack on top burns 10 more cards, leaving 2 of the 13.
+	cards, err := ParseCards("JS 2H 3H 4H 5H 6H 7H 8H 9H 10H JH QH KH")
+	if err != nil {
+		t.Fatalf("ParseCards: %v", err)
+	}
+	shoe = NewStackedShoe(cards)
+	if err := shoe.Burn(); err != nil {
+		t.Fatalf("Burn: %v", err)
+	}
+	if shoe.CardsLeft() != 2 {
+		t.Errorf("Expected 2 cards left after burning for Jack, got %d", shoe.CardsLeft())
+	}
+}
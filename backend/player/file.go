@@ -0,0 +1,211 @@
+package player
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FileBackend keeps each profile snapshot in <Dir>/profiles/<user>.json and
+// each ledger in <Dir>/ledger/<user>.jsonl.
+type FileBackend struct {
+	Dir string
+}
+
+// NewFileBackend returns a backend storing files under dir.
+func NewFileBackend(dir string) *FileBackend {
+	return &FileBackend{Dir: dir}
+}
+
+func (b *FileBackend) profilePath(username string) string {
+	return filepath.Join(b.Dir, "profiles", fmt.Sprintf("%s.json", username))
+}
+
+func (b *FileBackend) ledgerPath(username string) string {
+	return filepath.Join(b.Dir, "ledger", fmt.Sprintf("%s.jsonl", username))
+}
+
+// LoadSnapshot reads a player's profile file.
+func (b *FileBackend) LoadSnapshot(username string) (*Profile, error) {
+	data, err := os.ReadFile(b.profilePath(username))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, err
+	}
+
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SaveSnapshot atomically replaces the profile file: it writes a temporary
+// file, syncs it and renames it over the old one, so a crash leaves either the
+// old or the new snapshot.
+func (b *FileBackend) SaveSnapshot(p *Profile) error {
+	dir := filepath.Dir(b.profilePath(p.Username))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, p.Username+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), b.profilePath(p.Username))
+}
+
+// LoadLedger reads every entry of a player's ledger, oldest first. A final
+// line cut short by a crash is ignored.
+func (b *FileBackend) LoadLedger(username string) ([]LedgerEntry, error) {
+	data, err := os.ReadFile(b.ledgerPath(username))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return readLedger(bytes.NewReader(completeLines(data)))
+}
+
+// Create writes the first entries and snapshot of a new player.
+func (b *FileBackend) Create(p *Profile, entries []LedgerEntry) error {
+	for _, path := range []string{b.profilePath(p.Username), b.ledgerPath(p.Username)} {
+		if _, err := os.Stat(path); err == nil {
+			return ErrPlayerAlreadyExists
+		}
+	}
+	return b.Commit(p, entries)
+}
+
+// Commit appends entries to the ledger and syncs it, then saves the snapshot.
+func (b *FileBackend) Commit(p *Profile, entries []LedgerEntry) error {
+	if err := b.appendLedger(p.Username, entries); err != nil {
+		return err
+	}
+	if err := b.SaveSnapshot(p); err != nil {
+		return fmt.Errorf("%w: %v", ErrSnapshotNotSaved, err)
+	}
+	return nil
+}
+
+// Delete removes the profile and ledger files.
+func (b *FileBackend) Delete(username string) error {
+	for _, path := range []string{b.profilePath(username), b.ledgerPath(username)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func readLedger(r io.Reader) ([]LedgerEntry, error) {
+	var entries []LedgerEntry
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e LedgerEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrLedgerCorrupt, line, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// completeLines drops a trailing partial line.
+func completeLines(data []byte) []byte {
+	return data[:bytes.LastIndexByte(data, '\n')+1]
+}
+
+// ledgerTailChunk is how much of a ledger ledgerEnd reads at a time, from the
+// end, looking for the last complete line.
+const ledgerTailChunk = 4096
+
+// ledgerEnd returns the size of a ledger file and the offset just past its last
+// complete line. Only the partial line at the end, if any, is read.
+func ledgerEnd(f *os.File) (size, end int64, err error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	size = fi.Size()
+	buf := make([]byte, ledgerTailChunk)
+	for end = size; end > 0; {
+		n := min(end, ledgerTailChunk)
+		if _, err := f.ReadAt(buf[:n], end-n); err != nil {
+			return 0, 0, err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			return size, end - n + int64(i) + 1, nil
+		}
+		end -= n
+	}
+	return size, 0, nil
+}
+
+// appendLedger writes entries to the end of a player's ledger and syncs the
+// file before returning, so an entry that was acknowledged survives a crash.
+// A partial line left by an earlier crash is cut off first.
+func (b *FileBackend) appendLedger(username string, entries []LedgerEntry) error {
+	path := b.ledgerPath(username)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	size, end, err := ledgerEnd(f)
+	if err != nil {
+		return err
+	}
+	if end != size {
+		if err := f.Truncate(end); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(data, '\n'))
+	}
+	if _, err := f.WriteAt(buf.Bytes(), end); err != nil {
+		return err
+	}
+	return f.Sync()
+}
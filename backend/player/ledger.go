@@ -0,0 +1,157 @@
+package player
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EntryKind is the kind of balance change a ledger entry records.
+type EntryKind string
+
+const (
+	// EntryOpen sets the opening balance of a new account, or of a profile
+	// created before the ledger existed.
+	EntryOpen EntryKind = "open"
+	// EntryDebit is a bet placed.
+	EntryDebit EntryKind = "debit"
+	// EntryCredit is a payout or a stake returned on a push.
+	EntryCredit EntryKind = "credit"
+)
+
+// LedgerEntry is one line of a player's append-only ledger.
+type LedgerEntry struct {
+	Seq       int64     `json:"seq"`
+	Timestamp time.Time `json:"timestamp"`
+	Kind      EntryKind `json:"kind"`
+	RoundID   string    `json:"round_id,omitempty"`
+	BetType   string    `json:"bet_type,omitempty"`
+	Amount    int       `json:"amount"`
+	Balance   int       `json:"balance"` // balance after this entry
+
+	// Open entries of migrated profiles carry the statistics of the hands
+	// played before the ledger existed.
+	HandsPlayed int `json:"hands_played,omitempty"`
+	TotalWager  int `json:"total_wager,omitempty"`
+}
+
+// Wager is a bet settled in a round.
+type Wager struct {
+	BetType  string
+	Amount   int
+	Returned int // winnings plus the returned stake; 0 if the bet lost
+}
+
+// ErrLedgerCorrupt is returned when a ledger's entries do not add up.
+var ErrLedgerCorrupt = errors.New("ledger is corrupt")
+
+// balanceAfter returns the balance that results from applying the entry to balance.
+func (e LedgerEntry) balanceAfter(balance int) int {
+	switch e.Kind {
+	case EntryOpen:
+		return e.Amount
+	case EntryDebit:
+		return balance - e.Amount
+	case EntryCredit:
+		return balance + e.Amount
+	}
+	return balance
+}
+
+// apply replays entries onto the profile, checking that each entry follows
+// the one before it and that its recorded balance adds up.
+func (p *Profile) apply(entries []LedgerEntry) error {
+	for _, e := range entries {
+		if e.Seq <= p.LedgerSeq {
+			continue
+		}
+		if e.Seq != p.LedgerSeq+1 {
+			return fmt.Errorf("%w: entry %d follows entry %d", ErrLedgerCorrupt, e.Seq, p.LedgerSeq)
+		}
+		switch e.Kind {
+		case EntryOpen:
+			p.HandsPlayed, p.TotalWager = e.HandsPlayed, e.TotalWager
+		case EntryDebit:
+			p.TotalWager += e.Amount
+		case EntryCredit:
+		default:
+			return fmt.Errorf("%w: entry %d has unknown kind %q", ErrLedgerCorrupt, e.Seq, e.Kind)
+		}
+		if p.Balance = e.balanceAfter(p.Balance); p.Balance != e.Balance {
+			return fmt.Errorf("%w: entry %d records balance %d, entries add up to %d", ErrLedgerCorrupt, e.Seq, e.Balance, p.Balance)
+		}
+		if e.RoundID != "" && e.RoundID != p.lastRound {
+			p.HandsPlayed++
+			p.lastRound = e.RoundID
+		}
+		p.LedgerSeq = e.Seq
+	}
+	return nil
+}
+
+// stamp numbers entries after the profile's last one and fills in the balance
+// each leaves.
+func (p *Profile) stamp(entries []LedgerEntry) []LedgerEntry {
+	now := time.Now()
+	balance := p.Balance
+	for i := range entries {
+		e := &entries[i]
+		e.Seq, e.Timestamp = p.LedgerSeq+int64(i)+1, now
+		balance = e.balanceAfter(balance)
+		e.Balance = balance
+	}
+	return entries
+}
+
+// record commits entries to the ledger, applies them and saves the snapshot.
+// The profile is unchanged if the ledger cannot be written.
+func (p *Profile) record(entries []LedgerEntry) error {
+	next := *p
+	entries = next.stamp(entries)
+	if err := next.apply(entries); err != nil {
+		return err
+	}
+	err := p.store().Commit(&next, entries)
+	if err == nil || errors.Is(err, ErrSnapshotNotSaved) {
+		// Only the fields apply changes are copied back: Username and funds may
+		// be read concurrently by holders of the profile.
+		p.Balance, p.HandsPlayed, p.TotalWager = next.Balance, next.HandsPlayed, next.TotalWager
+		p.LedgerSeq, p.lastRound = next.LedgerSeq, next.lastRound
+	}
+	return err
+}
+
+// Settle records the bets of a round as debits and their returns as credits,
+// then saves the updated snapshot.
+func (p *Profile) Settle(roundID string, wagers []Wager) error {
+	_, _, err := p.SettleHeld(roundID, 0, wagers)
+	return err
+}
+
+// SettleHeld settles a round like Settle and releases held, the stakes of its
+// bets set aside with Hold, whether or not the settlement is recorded. It
+// returns the balance before and after the settlement.
+func (p *Profile) SettleHeld(roundID string, held int, wagers []Wager) (before, after int, err error) {
+	f := p.lock()
+	defer f.mu.Unlock()
+	f.held -= held
+	before = p.Balance
+	err = p.settle(roundID, wagers)
+	return before, p.Balance, err
+}
+
+func (p *Profile) settle(roundID string, wagers []Wager) error {
+	var entries []LedgerEntry
+	for _, w := range wagers {
+		entries = append(entries, LedgerEntry{Kind: EntryDebit, RoundID: roundID, BetType: w.BetType, Amount: w.Amount})
+	}
+	for _, w := range wagers {
+		if w.Returned > 0 {
+			entries = append(entries, LedgerEntry{Kind: EntryCredit, RoundID: roundID, BetType: w.BetType, Amount: w.Returned})
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return p.record(entries)
+}
@@ -0,0 +1,230 @@
+package player
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// defaultFiles is the backend of the package-level functions.
+var defaultFiles = defaultAccounts.backend.(*FileBackend)
+
+func TestSettleRecordsLedger(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	p, err := CreateProfile("alice", 1000)
+	if err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+	// Banker pushes on a Dragon 7 and the Dragon 7 bet pays 40 to 1.
+	err = p.Settle("shoe/1", []Wager{{BetType: "Banker", Amount: 100, Returned: 100}, {BetType: "Dragon 7", Amount: 10, Returned: 410}})
+	if err != nil {
+		t.Fatalf("Settle: %v", err)
+	}
+	// A lost Player bet.
+	if err := p.Settle("shoe/2", []Wager{{BetType: "Player", Amount: 50}}); err != nil {
+		t.Fatalf("Settle: %v", err)
+	}
+	if p.Balance != 1000+400-50 || p.HandsPlayed != 2 || p.TotalWager != 160 || p.LedgerSeq != 6 {
+		t.Errorf("profile after two rounds: %+v", p)
+	}
+
+	entries, err := LoadLedger("alice")
+	if err != nil {
+		t.Fatalf("LoadLedger: %v", err)
+	}
+	want := []struct {
+		kind    EntryKind
+		round   string
+		amount  int
+		balance int
+	}{
+		{EntryOpen, "", 1000, 1000},
+		{EntryDebit, "shoe/1", 100, 900},
+		{EntryDebit, "shoe/1", 10, 890},
+		{EntryCredit, "shoe/1", 100, 990},
+		{EntryCredit, "shoe/1", 410, 1400},
+		{EntryDebit, "shoe/2", 50, 1350},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("ledger has %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Seq != int64(i+1) || e.Kind != w.kind || e.RoundID != w.round || e.Amount != w.amount || e.Balance != w.balance {
+			t.Errorf("entry %d = %+v, want %+v", i, e, w)
+		}
+	}
+
+	loaded, err := LoadProfile("alice")
+	if err != nil || loaded.Balance != p.Balance || loaded.LedgerSeq != 6 {
+		t.Errorf("LoadProfile = %+v, %v", loaded, err)
+	}
+}
+
+func TestRebuildProfile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	p, _ := CreateProfile("bob", 500)
+	_ = p.Settle("r1", []Wager{{BetType: "Player", Amount: 100, Returned: 200}})
+	_ = p.Settle("r2", []Wager{{BetType: "Tie", Amount: 20}})
+	want := *p
+
+	// A corrupt snapshot is rebuilt from the ledger.
+	if err := os.WriteFile(defaultFiles.profilePath("bob"), []byte("{\"username\": \"bob\", \"bal"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadProfile("bob")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if got.Balance != want.Balance || got.HandsPlayed != want.HandsPlayed || got.TotalWager != want.TotalWager || got.LedgerSeq != want.LedgerSeq {
+		t.Errorf("rebuilt profile %+v, want %+v", got, want)
+	}
+
+	// So is a missing one.
+	os.Remove(defaultFiles.profilePath("bob"))
+	if got, err := LoadProfile("bob"); err != nil || got.Balance != 580 {
+		t.Errorf("LoadProfile without a snapshot = %+v, %v", got, err)
+	}
+	if _, err := CreateProfile("bob", 1000); err != ErrPlayerAlreadyExists {
+		t.Errorf("CreateProfile over an existing ledger: %v", err)
+	}
+}
+
+func TestLoadProfileCatchesUpWithLedger(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	p, _ := CreateProfile("carol", 300)
+	stale := *p
+	_ = p.Settle("r1", []Wager{{BetType: "Banker", Amount: 100, Returned: 195}})
+
+	// Simulate a crash after the ledger write but before the snapshot.
+	if err := stale.Save(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadProfile("carol")
+	if err != nil || got.Balance != 395 || got.HandsPlayed != 1 || got.LedgerSeq != 3 {
+		t.Errorf("LoadProfile = %+v, %v", got, err)
+	}
+}
+
+func TestLedgerTornWrite(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	p, _ := CreateProfile("dave", 100)
+	f, err := os.OpenFile(defaultFiles.ledgerPath("dave"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"seq":2,"kind":"deb`)
+	f.Close()
+
+	if entries, err := LoadLedger("dave"); err != nil || len(entries) != 1 {
+		t.Fatalf("LoadLedger with a torn line = %v, %v", entries, err)
+	}
+	if err := p.Settle("r1", []Wager{{BetType: "Player", Amount: 10, Returned: 20}}); err != nil {
+		t.Fatalf("Settle: %v", err)
+	}
+	entries, err := LoadLedger("dave")
+	if err != nil || len(entries) != 3 || entries[2].Balance != 110 {
+		t.Errorf("ledger after repair = %+v, %v", entries, err)
+	}
+}
+
+func TestLedgerEnd(t *testing.T) {
+	long := strings.Repeat("x", 2*ledgerTailChunk+10)
+	for _, tc := range []struct {
+		data string
+		end  int64
+	}{
+		{"", 0},
+		{"a\n", 2},
+		{"a\nb\n", 4},
+		{"a\nb", 2},
+		{"partial", 0},
+		{long + "\n", int64(len(long)) + 1},
+		{"a\n" + long, 2},
+	} {
+		path := t.TempDir() + "/ledger.jsonl"
+		if err := os.WriteFile(path, []byte(tc.data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size, end, err := ledgerEnd(f)
+		f.Close()
+		if err != nil || size != int64(len(tc.data)) || end != tc.end {
+			t.Errorf("ledgerEnd(%.10q...) = %d, %d, %v, want %d, %d", tc.data, size, end, err, len(tc.data), tc.end)
+		}
+	}
+}
+
+func TestLedgerCorrupt(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	p, _ := CreateProfile("erin", 100)
+	_ = p.Settle("r1", []Wager{{BetType: "Player", Amount: 10}})
+	f, _ := os.OpenFile(defaultFiles.ledgerPath("erin"), os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString(`{"seq":3,"kind":"credit","round_id":"r2","amount":50,"balance":1000}` + "\n")
+	f.Close()
+
+	if _, err := RebuildProfile("erin"); !errors.Is(err, ErrLedgerCorrupt) {
+		t.Errorf("RebuildProfile with a wrong balance: %v, want ErrLedgerCorrupt", err)
+	}
+}
+
+func TestLoadProfileMigratesLegacySnapshot(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	legacy := &Profile{Username: "frank", Balance: 750, HandsPlayed: 12, TotalWager: 1200}
+	if err := legacy.Save(); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadProfile("frank")
+	if err != nil || p.LedgerSeq != 1 {
+		t.Fatalf("LoadProfile = %+v, %v", p, err)
+	}
+	rebuilt, err := RebuildProfile("frank")
+	if err != nil || rebuilt.Balance != 750 || rebuilt.HandsPlayed != 12 || rebuilt.TotalWager != 1200 {
+		t.Errorf("RebuildProfile of a migrated profile = %+v, %v", rebuilt, err)
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	for _, name := range []string{"alice", "Bob_99", "li.wei", "张伟", "a-b"} {
+		if err := ValidateUsername(name); err != nil {
+			t.Errorf("ValidateUsername(%q): %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".hidden", "../alice", "a/b", "John Doe", strings.Repeat("x", MaxUsernameLen+1)} {
+		if err := ValidateUsername(name); !errors.Is(err, ErrInvalidUsername) {
+			t.Errorf("ValidateUsername(%q) = %v, want ErrInvalidUsername", name, err)
+		}
+	}
+}
+
+func TestAccountsRejectInvalidUsername(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	const name = "../../escaped"
+	if _, err := CreateProfile(name, 1000); !errors.Is(err, ErrInvalidUsername) {
+		t.Errorf("CreateProfile(%q) = %v, want ErrInvalidUsername", name, err)
+	}
+	if _, err := LoadProfile(name); !errors.Is(err, ErrInvalidUsername) {
+		t.Errorf("LoadProfile(%q) = %v, want ErrInvalidUsername", name, err)
+	}
+	if _, err := RebuildProfile(name); !errors.Is(err, ErrInvalidUsername) {
+		t.Errorf("RebuildProfile(%q) = %v, want ErrInvalidUsername", name, err)
+	}
+	if _, err := LoadLedger(name); !errors.Is(err, ErrInvalidUsername) {
+		t.Errorf("LoadLedger(%q) = %v, want ErrInvalidUsername", name, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("files written for an invalid name: %v", entries)
+	}
+}
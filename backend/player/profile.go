@@ -1,90 +1,273 @@
 package player
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"os"
-	"path/filepath"
+	"sync"
+	"unicode"
 )
 
-// Profile represents a player's persistent data.
+// Profile represents a player's persistent data. The player's ledger is the
+// record of every balance change; the profile is a snapshot derived from it,
+// and RebuildProfile recreates it from the ledger alone.
 type Profile struct {
 	Username    string `json:"username"`
 	Balance     int    `json:"balance"`
 	HandsPlayed int    `json:"hands_played"`
 	TotalWager  int    `json:"total_wager"`
+	// LedgerSeq is the sequence number of the last ledger entry the snapshot
+	// includes.
+	LedgerSeq int64 `json:"ledger_seq"`
+
+	lastRound string  // round ID of the last entry applied, to count hands
+	backend   Backend // where the profile was loaded from
+	funds     *funds  // shared by the copies record makes; see Hold
+}
+
+// funds guards the balance of a profile that several tables settle, and holds
+// the stakes of its bets that are not settled yet.
+type funds struct {
+	mu   sync.Mutex
+	held int
+}
+
+// fundsInit guards the lazy creation of Profile.funds.
+var fundsInit sync.Mutex
+
+// lock locks the profile's funds and returns them.
+func (p *Profile) lock() *funds {
+	fundsInit.Lock()
+	if p.funds == nil {
+		p.funds = &funds{}
+	}
+	f := p.funds
+	fundsInit.Unlock()
+	f.mu.Lock()
+	return f
+}
+
+// Funds returns the balance and the part of it not held for unsettled bets.
+// Unlike reading Balance, it is safe while other goroutines settle bets.
+func (p *Profile) Funds() (balance, available int) {
+	f := p.lock()
+	defer f.mu.Unlock()
+	return p.Balance, p.Balance - f.held
+}
+
+// Hold sets amount of the balance aside for bets that are not settled yet, so
+// that they cannot be staked again elsewhere, e.g. at another table. It
+// reports false, holding nothing, if less than amount is available.
+func (p *Profile) Hold(amount int) bool {
+	f := p.lock()
+	defer f.mu.Unlock()
+	if amount > p.Balance-f.held {
+		return false
+	}
+	f.held += amount
+	return true
+}
+
+// Release returns held stakes of bets that were withdrawn to the available
+// balance.
+func (p *Profile) Release(amount int) {
+	f := p.lock()
+	defer f.mu.Unlock()
+	f.held -= amount
 }
 
 var ErrPlayerNotFound = errors.New("player profile not found")
 var ErrPlayerAlreadyExists = errors.New("player already exists")
+var ErrInvalidUsername = errors.New("invalid player name")
 
-const profileDir = "data/profiles"
+// MaxUsernameLen is the longest player name ValidateUsername accepts.
+const MaxUsernameLen = 32
 
-func getProfilePath(username string) string {
-	return filepath.Join(profileDir, fmt.Sprintf("%s.json", username))
+// ValidateUsername checks that name is usable as a player name, and as a
+// file name by the file backend: 1 to 32 letters, digits, '_', '-' or '.',
+// not starting with '.'.
+func ValidateUsername(name string) error {
+	if name == "" || len([]rune(name)) > MaxUsernameLen {
+		return fmt.Errorf("%w: must be 1 to %d characters", ErrInvalidUsername, MaxUsernameLen)
+	}
+	if name[0] == '.' {
+		return fmt.Errorf("%w: must not start with '.'", ErrInvalidUsername)
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.' {
+			return fmt.Errorf("%w: %q is not allowed", ErrInvalidUsername, r)
+		}
+	}
+	return nil
 }
 
-// LoadProfile attempts to read a player's profile from disk.
+// ErrSnapshotNotSaved is returned, wrapped, when ledger entries were committed
+// but the profile snapshot could not be stored. The entries stand; the
+// snapshot catches up with the ledger the next time the profile is loaded.
+var ErrSnapshotNotSaved = errors.New("profile snapshot not saved")
+
+// Backend stores profile snapshots and ledgers.
+type Backend interface {
+	// LoadSnapshot returns the stored profile of username, or ErrPlayerNotFound.
+	LoadSnapshot(username string) (*Profile, error)
+	// SaveSnapshot stores p as the profile of p.Username.
+	SaveSnapshot(p *Profile) error
+	// LoadLedger returns the ledger entries of username, oldest first. A player
+	// without a ledger has no entries.
+	LoadLedger(username string) ([]LedgerEntry, error)
+	// Create stores the first ledger entries and snapshot of a new player. It
+	// fails with ErrPlayerAlreadyExists if the player has a snapshot or a ledger.
+	Create(p *Profile, entries []LedgerEntry) error
+	// Commit appends entries to the ledger of p.Username and stores p, which
+	// includes them, as the new snapshot.
+	Commit(p *Profile, entries []LedgerEntry) error
+	// Delete removes the snapshot and ledger of username, if it has them.
+	Delete(username string) error
+}
+
+// Accounts loads and creates profiles kept in a Backend.
+type Accounts struct {
+	backend Backend
+}
+
+// NewAccounts returns the accounts stored in b.
+func NewAccounts(b Backend) *Accounts {
+	return &Accounts{backend: b}
+}
+
+// DefaultDataDir is where the package-level functions keep profiles and ledgers.
+const DefaultDataDir = "data"
+
+var defaultAccounts = NewAccounts(NewFileBackend(DefaultDataDir))
+
+// LoadProfile loads a profile from the files under DefaultDataDir (see Accounts.Load).
 func LoadProfile(username string) (*Profile, error) {
-	path := getProfilePath(username)
-	file, err := os.Open(path)
+	return defaultAccounts.Load(username)
+}
+
+// CreateProfile creates a profile in the files under DefaultDataDir (see Accounts.Create).
+func CreateProfile(username string, initBalance int) (*Profile, error) {
+	return defaultAccounts.Create(username, initBalance)
+}
+
+// RebuildProfile rebuilds a profile in the files under DefaultDataDir (see Accounts.Rebuild).
+func RebuildProfile(username string) (*Profile, error) {
+	return defaultAccounts.Rebuild(username)
+}
+
+// LoadLedger reads a ledger from the files under DefaultDataDir.
+func LoadLedger(username string) ([]LedgerEntry, error) {
+	return defaultAccounts.Ledger(username)
+}
+
+// Ledger returns every entry of a player's ledger, oldest first.
+func (a *Accounts) Ledger(username string) ([]LedgerEntry, error) {
+	if err := ValidateUsername(username); err != nil {
+		return nil, err
+	}
+	return a.backend.LoadLedger(username)
+}
+
+// Load reads a player's profile snapshot and brings it up to date with any
+// ledger entries it is missing, e.g. after a crash between the two writes. If
+// the snapshot is missing or unreadable it is rebuilt from the ledger. A
+// profile that predates the ledger is given an opening entry. Like every
+// Accounts method, it fails with ErrInvalidUsername, wrapped, if username does
+// not pass ValidateUsername, before reaching the backend.
+func (a *Accounts) Load(username string) (*Profile, error) {
+	if err := ValidateUsername(username); err != nil {
+		return nil, err
+	}
+	p, err := a.backend.LoadSnapshot(username)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, ErrPlayerNotFound
+		if rebuilt, rerr := a.Rebuild(username); rerr == nil {
+			return rebuilt, nil
+		} else if !errors.Is(rerr, ErrPlayerNotFound) {
+			return nil, rerr
 		}
 		return nil, err
 	}
-	defer file.Close()
+	p.backend = a.backend
 
-	var p Profile
-	bytes, err := io.ReadAll(file)
+	entries, err := a.backend.LoadLedger(username)
 	if err != nil {
 		return nil, err
 	}
+	if len(entries) == 0 {
+		open := LedgerEntry{Kind: EntryOpen, Amount: p.Balance, HandsPlayed: p.HandsPlayed, TotalWager: p.TotalWager}
+		p.LedgerSeq = 0
+		if err := p.record([]LedgerEntry{open}); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
 
-	if err := json.Unmarshal(bytes, &p); err != nil {
+	seq := p.LedgerSeq
+	if err := p.apply(entries); err != nil {
 		return nil, err
 	}
-
-	return &p, nil
+	if p.LedgerSeq != seq {
+		if err := p.Save(); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
 }
 
-// CreateProfile makes a new profile and saves it. Fails if it already exists.
-func CreateProfile(username string, initBalance int) (*Profile, error) {
-	if err := os.MkdirAll(profileDir, 0755); err != nil {
+// Create makes a new profile with an opening ledger entry. Fails if the player
+// already exists.
+func (a *Accounts) Create(username string, initBalance int) (*Profile, error) {
+	if err := ValidateUsername(username); err != nil {
 		return nil, err
 	}
-
-	path := getProfilePath(username)
-	if _, err := os.Stat(path); err == nil {
-		return nil, ErrPlayerAlreadyExists
-	}
-
-	p := &Profile{
-		Username: username,
-		Balance:  initBalance,
+	p := &Profile{Username: username, backend: a.backend}
+	entries := p.stamp([]LedgerEntry{{Kind: EntryOpen, Amount: initBalance}})
+	if err := p.apply(entries); err != nil {
+		return nil, err
 	}
-
-	if err := p.Save(); err != nil {
+	if err := a.backend.Create(p, entries); err != nil {
 		return nil, err
 	}
-
 	return p, nil
 }
 
-// Save writes the current state of the profile to disk.
-func (p *Profile) Save() error {
-	if err := os.MkdirAll(profileDir, 0755); err != nil {
+// Delete removes a player's snapshot and ledger. It is meant for undoing a
+// Create whose account could not be set up, before the player has played.
+func (a *Accounts) Delete(username string) error {
+	if err := ValidateUsername(username); err != nil {
 		return err
 	}
+	return a.backend.Delete(username)
+}
 
-	data, err := json.MarshalIndent(p, "", "  ")
+// Rebuild derives a player's profile from the ledger alone and saves it as the
+// new snapshot.
+func (a *Accounts) Rebuild(username string) (*Profile, error) {
+	if err := ValidateUsername(username); err != nil {
+		return nil, err
+	}
+	entries, err := a.backend.LoadLedger(username)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	if len(entries) == 0 {
+		return nil, ErrPlayerNotFound
+	}
+	p := &Profile{Username: username, backend: a.backend}
+	if err := p.apply(entries); err != nil {
+		return nil, err
+	}
+	return p, p.Save()
+}
 
-	path := getProfilePath(p.Username)
-	return os.WriteFile(path, data, 0644)
+// Save stores the profile snapshot.
+func (p *Profile) Save() error {
+	return p.store().SaveSnapshot(p)
+}
+
+// store returns the profile's backend, defaulting to the files under DefaultDataDir.
+func (p *Profile) store() Backend {
+	if p.backend == nil {
+		return defaultAccounts.backend
+	}
+	return p.backend
 }
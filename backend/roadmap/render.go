@@ -0,0 +1,83 @@
+package roadmap
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// beadSymbols are the Bead Plate letters of each outcome.
+var beadSymbols = map[rules.Outcome]string{
+	rules.OutcomeBanker:  "B",
+	rules.OutcomePlayer:  "P",
+	rules.OutcomeTie:     "T",
+	rules.OutcomeDragon7: "D",
+	rules.OutcomePanda8:  "8",
+}
+
+// Render writes every road as a text grid, showing at most the last maxCols
+// columns of each, followed by the ask road predictions.
+func (m *Roadmap) Render(w io.Writer, maxCols int) {
+	fmt.Fprintf(w, "Bead Plate (B/P/T, D = Dragon 7, 8 = Panda 8):\n")
+	renderGrid(w, m.BeadPlate(), maxCols, func(o rules.Outcome) string { return beadSymbols[o] })
+
+	fmt.Fprintf(w, "Big Road (digit = ties):\n")
+	renderGrid(w, m.BigRoad(), maxCols, func(mk Mark) string {
+		s := "P"
+		if mk.Banker() {
+			s = "B"
+		}
+		if mk.Ties > 0 {
+			s += fmt.Sprint(min(mk.Ties, 9))
+		}
+		return s
+	})
+
+	colorSymbol := func(c Color) string { return strings.ToUpper(c.String()[:1]) }
+	fmt.Fprintf(w, "Big Eye Boy (R = red, B = blue):\n")
+	renderGrid(w, m.BigEyeBoy(), maxCols, colorSymbol)
+	fmt.Fprintf(w, "Small Road:\n")
+	renderGrid(w, m.SmallRoad(), maxCols, colorSymbol)
+	fmt.Fprintf(w, "Cockroach Pig:\n")
+	renderGrid(w, m.CockroachPig(), maxCols, colorSymbol)
+
+	for _, next := range []rules.Outcome{rules.OutcomeBanker, rules.OutcomePlayer} {
+		p := m.Ask(next)
+		fmt.Fprintf(w, "Ask road, %s next: Big Eye Boy %s, Small Road %s, Cockroach Pig %s\n",
+			next, askColor(p.BigEyeBoy), askColor(p.SmallRoad), askColor(p.CockroachPig))
+	}
+}
+
+func askColor(c Color) string {
+	if c == 0 {
+		return "-"
+	}
+	return c.String()
+}
+
+// renderGrid writes the last maxCols columns of g, each cell two characters wide.
+// A road that has not started yet takes a single line.
+func renderGrid[T comparable](w io.Writer, g Grid[T], maxCols int, symbol func(T) string) {
+	if maxCols > 0 && len(g) > maxCols {
+		g = g[len(g)-maxCols:]
+	}
+	if len(g) == 0 {
+		fmt.Fprintln(w, "  (empty)")
+		return
+	}
+	var zero T
+	for row := 0; row < Rows; row++ {
+		var b strings.Builder
+		b.WriteString("  |")
+		for col := range g {
+			s := "."
+			if v := g[col][row]; v != zero {
+				s = symbol(v)
+			}
+			fmt.Fprintf(&b, "%-2s", s)
+		}
+		fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+	}
+}
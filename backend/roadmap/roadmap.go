@@ -0,0 +1,263 @@
+// Package roadmap builds the scoreboards shown at Baccarat tables from the
+// outcomes of the current shoe: the Bead Plate, the Big Road and the three roads
+// derived from it (Big Eye Boy, Small Road and Cockroach Pig).
+package roadmap
+
+import "github.com/niubaoshu/es-Baccarat/backend/rules"
+
+// Rows is the height of every road.
+const Rows = 6
+
+// Grid is a road laid out in columns of Rows cells, left to right. Empty cells
+// hold the zero value of T.
+type Grid[T comparable] [][Rows]T
+
+// At returns the cell at the given column and row, or the zero value if it is
+// outside the grid.
+func (g Grid[T]) At(col, row int) T {
+	var zero T
+	if col < 0 || col >= len(g) || row < 0 || row >= Rows {
+		return zero
+	}
+	return g[col][row]
+}
+
+// Mark is a Big Road cell: one Banker or Player win and the ties that followed it.
+type Mark struct {
+	Outcome rules.Outcome // never OutcomeTie
+	Ties    int
+}
+
+// Banker reports whether the mark is a Banker win (including Dragon 7).
+func (m Mark) Banker() bool {
+	return m.Outcome == rules.OutcomeBanker || m.Outcome == rules.OutcomeDragon7
+}
+
+// Color is a cell of a derived road.
+type Color uint8
+
+const (
+	// Red marks a repeating pattern in the Big Road.
+	Red Color = iota + 1
+	// Blue marks a break in the pattern.
+	Blue
+)
+
+func (c Color) String() string {
+	switch c {
+	case Red:
+		return "red"
+	case Blue:
+		return "blue"
+	}
+	return ""
+}
+
+// Derived road offsets: how many Big Road columns back each road compares with.
+const (
+	bigEyeBoyOffset    = 1
+	smallRoadOffset    = 2
+	cockroachPigOffset = 3
+)
+
+// Roadmap records the outcomes of one shoe.
+type Roadmap struct {
+	outcomes []rules.Outcome
+}
+
+// New returns an empty roadmap.
+func New() *Roadmap {
+	return &Roadmap{}
+}
+
+// Add records the outcome of a hand.
+func (m *Roadmap) Add(o rules.Outcome) {
+	m.outcomes = append(m.outcomes, o)
+}
+
+// Reset clears the history for a new shoe.
+func (m *Roadmap) Reset() {
+	m.outcomes = m.outcomes[:0]
+}
+
+// Outcomes returns the recorded outcomes, oldest first.
+func (m *Roadmap) Outcomes() []rules.Outcome {
+	return m.outcomes
+}
+
+// BeadPlate returns every hand, ties included, filled top to bottom and then left to right.
+func (m *Roadmap) BeadPlate() Grid[rules.Outcome] {
+	g := make(Grid[rules.Outcome], (len(m.outcomes)+Rows-1)/Rows)
+	for i, o := range m.outcomes {
+		g[i/Rows][i%Rows] = o
+	}
+	return g
+}
+
+// BigRoad returns the Big Road: a new column starts whenever the winning side
+// changes, ties are counted on the preceding mark, and streaks longer than the
+// column turn right into a dragon tail.
+func (m *Roadmap) BigRoad() Grid[Mark] {
+	return layout(bigRoadColumns(m.outcomes))
+}
+
+// BigEyeBoy returns the road that compares each Big Road column with the one before it.
+func (m *Roadmap) BigEyeBoy() Grid[Color] {
+	return layout(streaks(derive(bigRoadColumns(m.outcomes), bigEyeBoyOffset), sameColor))
+}
+
+// SmallRoad returns the road that compares each Big Road column with the one two before it.
+func (m *Roadmap) SmallRoad() Grid[Color] {
+	return layout(streaks(derive(bigRoadColumns(m.outcomes), smallRoadOffset), sameColor))
+}
+
+// CockroachPig returns the road that compares each Big Road column with the one three before it.
+func (m *Roadmap) CockroachPig() Grid[Color] {
+	return layout(streaks(derive(bigRoadColumns(m.outcomes), cockroachPigOffset), sameColor))
+}
+
+// Prediction is an "ask road": the mark each derived road would add if the next
+// hand were won by Next. A zero Color means that road would not add a mark yet.
+type Prediction struct {
+	Next         rules.Outcome
+	BigEyeBoy    Color
+	SmallRoad    Color
+	CockroachPig Color
+}
+
+// Ask predicts the derived road marks for a Banker or Player win next.
+func (m *Roadmap) Ask(next rules.Outcome) Prediction {
+	cols := bigRoadColumns(append(m.outcomes[:len(m.outcomes):len(m.outcomes)], next))
+	p := Prediction{Next: next}
+	for _, road := range []struct {
+		offset int
+		color  *Color
+	}{
+		{bigEyeBoyOffset, &p.BigEyeBoy},
+		{smallRoadOffset, &p.SmallRoad},
+		{cockroachPigOffset, &p.CockroachPig},
+	} {
+		col := len(cols) - 1
+		*road.color = compare(cols, col, len(cols[col])-1, road.offset)
+	}
+	return p
+}
+
+// streaks groups a road's marks into logical columns of consecutive marks on
+// the same side.
+func streaks[T comparable](marks []T, same func(a, b T) bool) [][]T {
+	var cols [][]T
+	for _, mk := range marks {
+		if n := len(cols); n > 0 && same(cols[n-1][0], mk) {
+			cols[n-1] = append(cols[n-1], mk)
+		} else {
+			cols = append(cols, []T{mk})
+		}
+	}
+	return cols
+}
+
+func sameSide(a, b Mark) bool   { return a.Banker() == b.Banker() }
+func sameColor(a, b Color) bool { return a == b }
+
+// bigRoadColumns returns the Big Road's logical columns, before dragon tails are laid out.
+func bigRoadColumns(outcomes []rules.Outcome) [][]Mark {
+	return streaks(toMarks(outcomes), sameSide)
+}
+
+// toMarks converts outcomes into Big Road marks, folding each tie into the mark
+// before it. Ties before the first win are counted on the first mark.
+func toMarks(outcomes []rules.Outcome) []Mark {
+	var marks []Mark
+	leadingTies := 0
+	for _, o := range outcomes {
+		switch {
+		case o != rules.OutcomeTie:
+			marks = append(marks, Mark{Outcome: o})
+			if len(marks) == 1 {
+				marks[0].Ties = leadingTies
+			}
+		case len(marks) > 0:
+			marks[len(marks)-1].Ties++
+		default:
+			leadingTies++
+		}
+	}
+	return marks
+}
+
+// derive builds a derived road from the Big Road's logical columns.
+func derive(bigRoad [][]Mark, offset int) []Color {
+	var colors []Color
+	for col, marks := range bigRoad {
+		for row := range marks {
+			if c := compare(bigRoad, col, row, offset); c != 0 {
+				colors = append(colors, c)
+			}
+		}
+	}
+	return colors
+}
+
+// compare returns the derived road mark for the Big Road entry at (col, row), or
+// 0 if the road has not started yet. The road starts at the entry in the second
+// row of column offset, or at the top of column offset+1 if there is none.
+//
+// The first entry of a column is red if the two columns before it, offset
+// columns apart, are the same length. Any other entry is red if column col-offset
+// has an entry at the same row or stopped before the row above; it is blue if
+// column col-offset ends exactly at the row above.
+func compare(bigRoad [][]Mark, col, row, offset int) Color {
+	if col < offset || (col == offset && row == 0) {
+		return 0
+	}
+	if row == 0 {
+		if len(bigRoad[col-1]) == len(bigRoad[col-1-offset]) {
+			return Red
+		}
+		return Blue
+	}
+	if len(bigRoad[col-offset]) == row {
+		return Blue
+	}
+	return Red
+}
+
+// layout places logical columns on a grid of Rows rows. Each column starts at
+// the top of the grid column after the previous one's start; when it reaches the
+// bottom or runs into an earlier mark it turns right (a dragon tail).
+func layout[T comparable](cols [][]T) Grid[T] {
+	var (
+		g        Grid[T]
+		zero     T
+		startCol int
+	)
+	occupied := func(col, row int) bool { return g.At(col, row) != zero }
+	place := func(col, row int, v T) {
+		for len(g) <= col {
+			g = append(g, [Rows]T{})
+		}
+		g[col][row] = v
+	}
+
+	for i, marks := range cols {
+		if i > 0 {
+			startCol++
+		}
+		for occupied(startCol, 0) {
+			startCol++
+		}
+		col, row := startCol, 0
+		for j, v := range marks {
+			if j > 0 {
+				if row+1 < Rows && !occupied(col, row+1) && col == startCol {
+					row++
+				} else {
+					col++
+				}
+			}
+			place(col, row, v)
+		}
+	}
+	return g
+}
@@ -0,0 +1,171 @@
+package roadmap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+const (
+	b   = rules.OutcomeBanker
+	p   = rules.OutcomePlayer
+	tie = rules.OutcomeTie
+	d7  = rules.OutcomeDragon7
+	p8  = rules.OutcomePanda8
+)
+
+// sample's Big Road columns are [B D7] [P P P8] [B] [P P] [B], with a leading
+// tie and a tie after the first Player win.
+func sample() *Roadmap {
+	m := New()
+	for _, o := range []rules.Outcome{tie, b, d7, p, tie, p, p8, b, p, p, b} {
+		m.Add(o)
+	}
+	return m
+}
+
+// column returns the non-empty cells of a grid column.
+func column[T comparable](g Grid[T], col int) []T {
+	var zero T
+	var cells []T
+	for row := 0; row < Rows; row++ {
+		if v := g.At(col, row); v != zero {
+			cells = append(cells, v)
+		}
+	}
+	return cells
+}
+
+func colors(g Grid[Color]) string {
+	var s strings.Builder
+	for col := range g {
+		for _, c := range column(g, col) {
+			s.WriteString(c.String()[:1])
+		}
+		s.WriteString(" ")
+	}
+	return strings.TrimSpace(s.String())
+}
+
+func TestBeadPlate(t *testing.T) {
+	g := sample().BeadPlate()
+	if len(g) != 2 {
+		t.Fatalf("expected 2 columns, got %d", len(g))
+	}
+	if g.At(0, 0) != tie || g.At(0, 5) != p || g.At(1, 0) != p8 || g.At(1, 4) != b || g.At(1, 5) != "" {
+		t.Errorf("unexpected bead plate: %v", g)
+	}
+}
+
+func TestBigRoad(t *testing.T) {
+	g := sample().BigRoad()
+	if len(g) != 5 {
+		t.Fatalf("expected 5 columns, got %d", len(g))
+	}
+	wantLens := []int{2, 3, 1, 2, 1}
+	for col, n := range wantLens {
+		if got := len(column(g, col)); got != n {
+			t.Errorf("column %d has %d marks, want %d", col, got, n)
+		}
+	}
+	if mk := g.At(0, 0); mk.Outcome != b || mk.Ties != 1 {
+		t.Errorf("leading tie should be counted on the first mark, got %+v", mk)
+	}
+	if mk := g.At(1, 0); mk.Outcome != p || mk.Ties != 1 {
+		t.Errorf("tie should be counted on the preceding mark, got %+v", mk)
+	}
+	if !g.At(0, 1).Banker() || g.At(1, 2).Banker() {
+		t.Errorf("Dragon 7 counts as Banker and Panda 8 as Player")
+	}
+}
+
+func TestBigRoadDragonTail(t *testing.T) {
+	m := New()
+	for i := 0; i < 8; i++ {
+		m.Add(b)
+	}
+	for i := 0; i < 6; i++ {
+		m.Add(p)
+	}
+	g := m.BigRoad()
+
+	// The Banker streak runs down column 0 and turns right along the bottom row.
+	for _, pos := range [][2]int{{0, 5}, {1, 5}, {2, 5}} {
+		if !g.At(pos[0], pos[1]).Banker() {
+			t.Errorf("expected Banker tail at %v", pos)
+		}
+	}
+	// The Player streak starts in column 1, is blocked by the tail and turns at row 4.
+	for _, pos := range [][2]int{{1, 0}, {1, 4}, {2, 4}} {
+		if mk := g.At(pos[0], pos[1]); mk.Outcome != p {
+			t.Errorf("expected Player mark at %v, got %+v", pos, mk)
+		}
+	}
+	if g.At(1, 5).Outcome != b || g.At(3, 4).Outcome != "" {
+		t.Errorf("unexpected tail layout: %v", g)
+	}
+}
+
+func TestDerivedRoads(t *testing.T) {
+	m := sample()
+	if got := colors(m.BigEyeBoy()); got != "r bbbbb" {
+		t.Errorf("Big Eye Boy = %q", got)
+	}
+	if got := colors(m.SmallRoad()); got != "b r b" {
+		t.Errorf("Small Road = %q", got)
+	}
+	if got := colors(m.CockroachPig()); got != "rr" {
+		t.Errorf("Cockroach Pig = %q", got)
+	}
+}
+
+func TestAsk(t *testing.T) {
+	m := sample()
+	if got, want := m.Ask(b), (Prediction{Next: b, BigEyeBoy: Red, SmallRoad: Blue, CockroachPig: Red}); got != want {
+		t.Errorf("Ask(Banker) = %+v, want %+v", got, want)
+	}
+	if got, want := m.Ask(p), (Prediction{Next: p, BigEyeBoy: Blue, SmallRoad: Red, CockroachPig: Blue}); got != want {
+		t.Errorf("Ask(Player) = %+v, want %+v", got, want)
+	}
+	if n := len(m.Outcomes()); n != 11 {
+		t.Errorf("Ask must not record the hypothetical hand, %d outcomes", n)
+	}
+
+	if got := New().Ask(b); got != (Prediction{Next: b}) {
+		t.Errorf("no road should draw on an empty shoe, got %+v", got)
+	}
+}
+
+func TestReset(t *testing.T) {
+	m := sample()
+	m.Reset()
+	if len(m.Outcomes()) != 0 || len(m.BigRoad()) != 0 || len(m.BeadPlate()) != 0 {
+		t.Errorf("Reset should clear every road")
+	}
+}
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	sample().Render(&buf, 0)
+	out := buf.String()
+	for _, want := range []string{
+		"  |T 8\n",
+		"  |B1P1B P B\n",
+		"Cockroach Pig:\n  |R\n  |R\n",
+		"Ask road, Banker next: Big Eye Boy red, Small Road blue, Cockroach Pig red",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	New().Render(&buf, 0)
+	if n := strings.Count(buf.String(), "(empty)"); n != 5 {
+		t.Errorf("expected 5 empty roads, got %d:\n%s", n, buf.String())
+	}
+}
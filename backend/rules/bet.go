@@ -1,5 +1,10 @@
 package rules
 
+import (
+	"errors"
+	"strings"
+)
+
 // BetType represents the different betting options in EZ Baccarat Panda 8.
 type BetType string
 
@@ -9,4 +14,88 @@ const (
 	Tie    BetType = "Tie"
 	Dragon BetType = "Dragon 7"
 	Panda  BetType = "Panda 8"
+
+	PlayerPair  BetType = "Player Pair"
+	BankerPair  BetType = "Banker Pair"
+	EitherPair  BetType = "Either Pair"
+	PerfectPair BetType = "Perfect Pair"
+
+	DragonBonusPlayer BetType = "Dragon Bonus Player"
+	DragonBonusBanker BetType = "Dragon Bonus Banker"
 )
+
+// Code returns the short code accepted by ParseBetType (e.g. "P" for Player).
+func (b BetType) Code() string {
+	switch b {
+	case Player:
+		return "P"
+	case Banker:
+		return "B"
+	case Tie:
+		return "T"
+	case Dragon:
+		return "D"
+	case Panda:
+		return "8"
+	case PlayerPair:
+		return "PP"
+	case BankerPair:
+		return "BP"
+	case EitherPair:
+		return "EP"
+	case PerfectPair:
+		return "PF"
+	case DragonBonusPlayer:
+		return "DBP"
+	case DragonBonusBanker:
+		return "DBB"
+	}
+	return string(b)
+}
+
+// IsPair reports whether b is one of the pair side bets, which are settled on the
+// first two cards of each hand alone.
+func (b BetType) IsPair() bool {
+	switch b {
+	case PlayerPair, BankerPair, EitherPair, PerfectPair:
+		return true
+	}
+	return false
+}
+
+// IsDragonBonus reports whether b is a Dragon Bonus bet on either hand.
+func (b BetType) IsDragonBonus() bool {
+	return b == DragonBonusPlayer || b == DragonBonusBanker
+}
+
+// ErrUnknownBetType is returned by ParseBetType for unrecognised input.
+var ErrUnknownBetType = errors.New("unknown bet type")
+
+// ParseBetType converts user or client input (e.g. "P", "banker", "Dragon 7") into a BetType.
+func ParseBetType(s string) (BetType, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "P", "PLAYER":
+		return Player, nil
+	case "B", "BANKER":
+		return Banker, nil
+	case "T", "TIE":
+		return Tie, nil
+	case "D", "DRAGON", "DRAGON7", "DRAGON 7":
+		return Dragon, nil
+	case "8", "PANDA", "PANDA8", "PANDA 8":
+		return Panda, nil
+	case "PP", "PLAYERPAIR", "PLAYER PAIR":
+		return PlayerPair, nil
+	case "BP", "BANKERPAIR", "BANKER PAIR":
+		return BankerPair, nil
+	case "EP", "EITHERPAIR", "EITHER PAIR":
+		return EitherPair, nil
+	case "PF", "PERFECTPAIR", "PERFECT PAIR":
+		return PerfectPair, nil
+	case "DBP", "DRAGON BONUS PLAYER":
+		return DragonBonusPlayer, nil
+	case "DBB", "DRAGON BONUS BANKER":
+		return DragonBonusBanker, nil
+	}
+	return "", ErrUnknownBetType
+}
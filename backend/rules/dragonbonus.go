@@ -0,0 +1,42 @@
+package rules
+
+import "github.com/niubaoshu/es-Baccarat/backend/model"
+
+// dragonBonusMargins is the Dragon Bonus pay table (X to 1) for a non-natural
+// win, indexed by the margin of victory. Wins by fewer than 4 points lose.
+var dragonBonusMargins = [10]int{4: 1, 5: 2, 6: 4, 7: 6, 8: 10, 9: 30}
+
+// DragonBonusPayout settles a Dragon Bonus bet on the Player or the Banker hand.
+// A natural win pays 1:1 and a tie between two naturals is a push. Any other win
+// pays according to the margin of victory, from 1:1 for 4 points up to 30:1 for 9.
+func DragonBonusPayout(playerHand, bankerHand *model.Hand, betType BetType, betAmount int) PayoutResult {
+	own, other := playerHand, bankerHand
+	if betType == DragonBonusBanker {
+		own, other = bankerHand, playerHand
+	}
+
+	won := false
+	switch DetermineOutcome(playerHand, bankerHand) {
+	case OutcomePlayer, OutcomePanda8:
+		won = betType == DragonBonusPlayer
+	case OutcomeBanker, OutcomeDragon7:
+		won = betType == DragonBonusBanker
+	case OutcomeTie:
+		if own.IsNatural() && other.IsNatural() {
+			// Push
+			return PayoutResult{WinAmount: 0, Returned: betAmount}
+		}
+	}
+
+	if won {
+		if own.IsNatural() {
+			return PayoutResult{WinAmount: betAmount, Returned: betAmount}
+		}
+		if pays := dragonBonusMargins[own.TotalPoints()-other.TotalPoints()]; pays > 0 {
+			return PayoutResult{WinAmount: betAmount * pays, Returned: betAmount}
+		}
+	}
+
+	// Any other combo is a loss
+	return PayoutResult{WinAmount: 0, Returned: 0}
+}
@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+)
+
+func TestDragonBonusPayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		player  *model.Hand
+		banker  *model.Hand
+		betType BetType
+		wantNet int
+	}{
+		{"Natural win pays 1:1", hand(model.Four, model.Five), hand(model.Two, model.Ten), DragonBonusPlayer, 10},
+		{"Natural 9 over natural 8 pays 1:1", hand(model.Nine, model.Ten), hand(model.Eight, model.Jack), DragonBonusPlayer, 10},
+		{"Natural tie pushes", hand(model.Eight, model.King), hand(model.Four, model.Four), DragonBonusBanker, 0},
+		{"Non-natural tie loses", hand(model.Two, model.Two, model.Three), hand(model.Seven), DragonBonusPlayer, -10},
+		{"Win by 9 pays 30:1", hand(model.Two, model.Three, model.Four), hand(model.King, model.Ten, model.Queen), DragonBonusPlayer, 300},
+		{"Win by 4 pays 1:1", hand(model.Two), hand(model.Six), DragonBonusBanker, 10},
+		{"Win by 3 loses", hand(model.Two), hand(model.Five), DragonBonusBanker, -10},
+		{"Win by 6 pays 4:1", hand(model.Ace), hand(model.Three, model.Two, model.Two), DragonBonusBanker, 40},
+		{"Losing side loses", hand(model.Ace), hand(model.Three, model.Two, model.Two), DragonBonusPlayer, -10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DragonBonusPayout(tt.player, tt.banker, tt.betType, 10).NetChange(10)
+			if got != tt.wantNet {
+				t.Errorf("NetChange = %d, want %d", got, tt.wantNet)
+			}
+			if viaRules := EZ.Payout(tt.player, tt.banker, tt.betType, 10).NetChange(10); viaRules != got {
+				t.Errorf("EZ.Payout NetChange = %d, want %d", viaRules, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors wrapped by BetError, for use with errors.Is.
+var (
+	ErrNoBets            = errors.New("no bets placed")
+	ErrBetNotOffered     = errors.New("not offered at this table")
+	ErrInvalidAmount     = errors.New("amount must be positive")
+	ErrBetUnit           = errors.New("not a multiple of the betting unit")
+	ErrBelowMinimum      = errors.New("below the table minimum")
+	ErrAboveMaximum      = errors.New("above the table maximum")
+	ErrTableMax          = errors.New("total bet exceeds the table maximum")
+	ErrBackLineBet       = errors.New("Dragon 7 and Panda 8 bets require an active Player or Banker base bet")
+	ErrPlayerAndBanker   = errors.New("Player and Banker cannot be bet in the same round")
+	ErrInsufficientFunds = errors.New("total bet exceeds balance")
+)
+
+// BetError reports a set of bets rejected by TableLimits.Validate. Err is one of
+// the Err* values above.
+type BetError struct {
+	BetType BetType // the offending bet, or empty if the error concerns the whole round
+	Amount  int     // the offending bet amount, or the round total
+	Limit   int     // the limit that was broken, if any
+	Err     error
+}
+
+func (e *BetError) Error() string {
+	msg := e.Err.Error()
+	if e.BetType != "" {
+		msg = fmt.Sprintf("%s bet ($%d): %s", e.BetType, e.Amount, msg)
+	} else if e.Amount != 0 {
+		msg = fmt.Sprintf("%s: $%d", msg, e.Amount)
+	}
+	if e.Limit != 0 {
+		msg += fmt.Sprintf(" (limit $%d)", e.Limit)
+	}
+	return msg
+}
+
+func (e *BetError) Unwrap() error { return e.Err }
+
+// TableLimits is the betting policy of a table. The zero value only requires
+// positive amounts that the player can cover.
+type TableLimits struct {
+	// MinBet and MaxBet are per-bet-type limits. A missing entry means no limit.
+	MinBet map[BetType]int
+	MaxBet map[BetType]int
+	// TableMax caps the total of all bets in a round. 0 means no cap.
+	TableMax int
+	// BetUnit requires every bet to be a multiple of it. 0 or 1 accepts any amount.
+	BetUnit int
+	// NoBackLinePanda refuses Dragon 7 and Panda 8 bets placed without a Player or
+	// Banker bet in the same round ("back-line" side bets).
+	NoBackLinePanda bool
+	// NoPlayerAndBanker refuses bets on both Player and Banker in the same round.
+	NoPlayerAndBanker bool
+}
+
+// Validate checks a round's bets against the limits, the bets offered by rs and
+// the player's balance. It returns a *BetError describing the first problem found.
+func (l TableLimits) Validate(rs RuleSet, bets map[BetType]int, balance int) error {
+	if len(bets) == 0 {
+		return &BetError{Err: ErrNoBets}
+	}
+
+	types := make([]BetType, 0, len(bets))
+	for bType := range bets {
+		types = append(types, bType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	total := 0
+	for _, bType := range types {
+		amt := bets[bType]
+		switch {
+		case !Offers(rs, bType):
+			return &BetError{BetType: bType, Amount: amt, Err: ErrBetNotOffered}
+		case amt <= 0:
+			return &BetError{BetType: bType, Amount: amt, Err: ErrInvalidAmount}
+		case l.BetUnit > 1 && amt%l.BetUnit != 0:
+			return &BetError{BetType: bType, Amount: amt, Limit: l.BetUnit, Err: ErrBetUnit}
+		case l.MinBet[bType] > 0 && amt < l.MinBet[bType]:
+			return &BetError{BetType: bType, Amount: amt, Limit: l.MinBet[bType], Err: ErrBelowMinimum}
+		case l.MaxBet[bType] > 0 && amt > l.MaxBet[bType]:
+			return &BetError{BetType: bType, Amount: amt, Limit: l.MaxBet[bType], Err: ErrAboveMaximum}
+		case amt > math.MaxInt-total:
+			// A total past MaxInt is more than any balance could cover.
+			return &BetError{BetType: bType, Amount: amt, Limit: balance, Err: ErrInsufficientFunds}
+		}
+		total += amt
+	}
+
+	hasBase := bets[Player] > 0 || bets[Banker] > 0
+	if l.NoBackLinePanda && !hasBase && (bets[Dragon] > 0 || bets[Panda] > 0) {
+		return &BetError{Err: ErrBackLineBet}
+	}
+	if l.NoPlayerAndBanker && bets[Player] > 0 && bets[Banker] > 0 {
+		return &BetError{Err: ErrPlayerAndBanker}
+	}
+	if l.TableMax > 0 && total > l.TableMax {
+		return &BetError{Amount: total, Limit: l.TableMax, Err: ErrTableMax}
+	}
+	if total > balance {
+		return &BetError{Amount: total, Limit: balance, Err: ErrInsufficientFunds}
+	}
+	return nil
+}
+
+// ParseBetLimits parses per-bet-type limits written as comma-separated
+// <Type>:<Min>-<Max> entries (e.g. "P:10-5000,B:10-5000,T:5-500"). Either bound
+// may be left empty for no limit.
+func ParseBetLimits(s string) (minBet, maxBet map[BetType]int, err error) {
+	minBet = make(map[BetType]int)
+	maxBet = make(map[BetType]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.Split(entry, ":")
+		if len(kv) != 2 {
+			return nil, nil, fmt.Errorf("invalid bet limit %q, use <Type>:<Min>-<Max>", entry)
+		}
+		bType, err := ParseBetType(kv[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", err, kv[0])
+		}
+		bounds := strings.Split(kv[1], "-")
+		if len(bounds) != 2 {
+			return nil, nil, fmt.Errorf("invalid bet limit %q, use <Type>:<Min>-<Max>", entry)
+		}
+		for i, dst := range []map[BetType]int{minBet, maxBet} {
+			b := strings.TrimSpace(bounds[i])
+			if b == "" {
+				continue
+			}
+			n, err := strconv.Atoi(b)
+			if err != nil || n < 0 {
+				return nil, nil, fmt.Errorf("invalid bet limit %q", entry)
+			}
+			dst[bType] = n
+		}
+		if maxBet[bType] > 0 && minBet[bType] > maxBet[bType] {
+			return nil, nil, fmt.Errorf("bet limit %q has a minimum above its maximum", entry)
+		}
+	}
+	return minBet, maxBet, nil
+}
@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTableLimitsValidate(t *testing.T) {
+	limits := TableLimits{
+		MinBet:            map[BetType]int{Player: 10, Banker: 10},
+		MaxBet:            map[BetType]int{Tie: 500},
+		TableMax:          2000,
+		BetUnit:           5,
+		NoBackLinePanda:   true,
+		NoPlayerAndBanker: true,
+	}
+
+	tests := []struct {
+		name    string
+		rs      RuleSet
+		bets    map[BetType]int
+		wantErr error
+	}{
+		{"Valid", EZ, map[BetType]int{Player: 100, Panda: 10, Tie: 25}, nil},
+		{"No bets", EZ, map[BetType]int{}, ErrNoBets},
+		{"Not offered", Classic, map[BetType]int{Banker: 100, Dragon: 10}, ErrBetNotOffered},
+		{"Non-positive amount", EZ, map[BetType]int{Player: 0}, ErrInvalidAmount},
+		{"Bet unit", EZ, map[BetType]int{Player: 12}, ErrBetUnit},
+		{"Below minimum", EZ, map[BetType]int{Banker: 5}, ErrBelowMinimum},
+		{"Above maximum", EZ, map[BetType]int{Player: 100, Tie: 505}, ErrAboveMaximum},
+		{"Table max", EZ, map[BetType]int{Player: 1800, Tie: 300}, ErrTableMax},
+		{"Back-line Panda", EZ, map[BetType]int{Panda: 10, Tie: 10}, ErrBackLineBet},
+		{"Back-line Dragon", EZ, map[BetType]int{Dragon: 10}, ErrBackLineBet},
+		{"Side bets without base are fine", EZ, map[BetType]int{PlayerPair: 10, DragonBonusBanker: 10}, nil},
+		{"Player and Banker", EZ, map[BetType]int{Player: 100, Banker: 100}, ErrPlayerAndBanker},
+		{"Insufficient funds", EZ, map[BetType]int{Player: 1500}, ErrInsufficientFunds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.Validate(tt.rs, tt.bets, 1000)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+			var betErr *BetError
+			if err != nil && !errors.As(err, &betErr) {
+				t.Errorf("Validate() returned %T, want *BetError", err)
+			}
+		})
+	}
+}
+
+func TestTableLimitsZeroValue(t *testing.T) {
+	var limits TableLimits
+	if err := limits.Validate(EZ, map[BetType]int{Player: 7, Banker: 3, Dragon: 1}, 100); err != nil {
+		t.Errorf("zero TableLimits should accept any covered bets, got %v", err)
+	}
+	// Stakes whose total wraps around must not pass as a small total.
+	if err := limits.Validate(EZ, map[BetType]int{Player: math.MaxInt, Tie: math.MaxInt}, 1000); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("MaxInt stakes: %v, want ErrInsufficientFunds", err)
+	}
+}
+
+func TestBetErrorMessage(t *testing.T) {
+	err := TableLimits{MinBet: map[BetType]int{Player: 25}}.Validate(EZ, map[BetType]int{Player: 10}, 100)
+	if want := "Player bet ($10): below the table minimum (limit $25)"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+}
+
+func TestParseBetLimits(t *testing.T) {
+	minBet, maxBet, err := ParseBetLimits("P:10-5000, T:-500,8:5-")
+	if err != nil {
+		t.Fatalf("ParseBetLimits: %v", err)
+	}
+	if minBet[Player] != 10 || maxBet[Player] != 5000 || minBet[Tie] != 0 || maxBet[Tie] != 500 || minBet[Panda] != 5 || maxBet[Panda] != 0 {
+		t.Errorf("unexpected limits: min %v, max %v", minBet, maxBet)
+	}
+	for _, bad := range []string{"P10-50", "X:1-2", "P:10", "P:a-5", "P:50-10"} {
+		if _, _, err := ParseBetLimits(bad); err == nil {
+			t.Errorf("ParseBetLimits(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+)
+
+// PairPays is the pay table of the pair side bets, each paid X to 1.
+type PairPays struct {
+	PlayerPair int // Player's first two cards are a pair
+	BankerPair int // Banker's first two cards are a pair
+	EitherPair int // Either hand starts with a pair
+	// PerfectPair pays when either hand starts with a suited pair (same rank and suit),
+	// and PerfectPairBoth when both do.
+	PerfectPair     int
+	PerfectPairBoth int
+}
+
+// DefaultPairPays is the common pay table: 11:1 on Player or Banker Pair, 5:1 on
+// Either Pair and 25:1 (200:1 for both hands) on Perfect Pair.
+var DefaultPairPays = PairPays{PlayerPair: 11, BankerPair: 11, EitherPair: 5, PerfectPair: 25, PerfectPairBoth: 200}
+
+// String formats the pay table in the form accepted by ParsePairPays.
+func (p PairPays) String() string {
+	return fmt.Sprintf("%d,%d,%d,%d,%d", p.PlayerPair, p.BankerPair, p.EitherPair, p.PerfectPair, p.PerfectPairBoth)
+}
+
+// ParsePairPays parses a pay table written as five comma-separated X-to-1 payouts:
+// Player Pair, Banker Pair, Either Pair, Perfect Pair and Perfect Pair on both hands
+// (e.g. "11,11,5,25,200").
+func ParsePairPays(s string) (PairPays, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 5 {
+		return PairPays{}, fmt.Errorf("pair pay table %q must have 5 comma-separated payouts", s)
+	}
+	var pays [5]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n <= 0 {
+			return PairPays{}, fmt.Errorf("invalid payout %q in pair pay table", part)
+		}
+		pays[i] = n
+	}
+	return PairPays{PlayerPair: pays[0], BankerPair: pays[1], EitherPair: pays[2], PerfectPair: pays[3], PerfectPairBoth: pays[4]}, nil
+}
+
+// PairPayout settles a pair side bet on the first two cards of each hand.
+func PairPayout(playerHand, bankerHand *model.Hand, betType BetType, betAmount int, pays PairPays) PayoutResult {
+	win := 0
+	switch betType {
+	case PlayerPair:
+		if playerHand.IsPair() {
+			win = pays.PlayerPair
+		}
+	case BankerPair:
+		if bankerHand.IsPair() {
+			win = pays.BankerPair
+		}
+	case EitherPair:
+		if playerHand.IsPair() || bankerHand.IsPair() {
+			win = pays.EitherPair
+		}
+	case PerfectPair:
+		pSuited, bSuited := playerHand.IsSuitedPair(), bankerHand.IsSuitedPair()
+		if pSuited && bSuited {
+			win = pays.PerfectPairBoth
+		} else if pSuited || bSuited {
+			win = pays.PerfectPair
+		}
+	}
+
+	if win == 0 {
+		return PayoutResult{WinAmount: 0, Returned: 0}
+	}
+	return PayoutResult{WinAmount: betAmount * win, Returned: betAmount}
+}
+
+// pairRules adds the pair side bets to a rule set.
+type pairRules struct {
+	RuleSet
+	Pays PairPays
+}
+
+// WithPairs returns rs with the four pair side bets offered at the given pay table.
+// If rs already offers pair bets, their pay table is replaced.
+func WithPairs(rs RuleSet, pays PairPays) RuleSet {
+	if pr, ok := rs.(pairRules); ok {
+		rs = pr.RuleSet
+	}
+	return pairRules{RuleSet: rs, Pays: pays}
+}
+
+func (p pairRules) BetTypes() []BetType {
+	return append(p.RuleSet.BetTypes(), PlayerPair, BankerPair, EitherPair, PerfectPair)
+}
+
+func (p pairRules) Payout(playerHand, bankerHand *model.Hand, betType BetType, betAmount int) PayoutResult {
+	if betType.IsPair() {
+		return PairPayout(playerHand, bankerHand, betType, betAmount, p.Pays)
+	}
+	return p.RuleSet.Payout(playerHand, bankerHand, betType, betAmount)
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+)
+
+func cards(cs ...model.Card) *model.Hand {
+	return &model.Hand{Cards: cs}
+}
+
+func TestPairPayout(t *testing.T) {
+	var (
+		mixedPair  = cards(model.Card{Suit: model.Spades, Rank: model.Queen}, model.Card{Suit: model.Hearts, Rank: model.Queen})
+		suitedPair = cards(model.Card{Suit: model.Clubs, Rank: model.Five}, model.Card{Suit: model.Clubs, Rank: model.Five})
+		noPair     = cards(model.Card{Suit: model.Spades, Rank: model.Ten}, model.Card{Suit: model.Spades, Rank: model.King})
+	)
+
+	tests := []struct {
+		name    string
+		player  *model.Hand
+		banker  *model.Hand
+		betType BetType
+		wantNet int
+	}{
+		{"Player Pair wins", mixedPair, noPair, PlayerPair, 110},
+		{"Player Pair loses on Banker pair", noPair, mixedPair, PlayerPair, -10},
+		{"Banker Pair wins", noPair, suitedPair, BankerPair, 110},
+		{"Either Pair wins on Player pair", mixedPair, noPair, EitherPair, 50},
+		{"Either Pair pays once for both pairs", mixedPair, suitedPair, EitherPair, 50},
+		{"Either Pair loses", noPair, noPair, EitherPair, -10},
+		{"Perfect Pair loses on mixed pair", mixedPair, noPair, PerfectPair, -10},
+		{"Perfect Pair wins on one suited pair", mixedPair, suitedPair, PerfectPair, 250},
+		{"Perfect Pair on both hands", suitedPair, suitedPair, PerfectPair, 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PairPayout(tt.player, tt.banker, tt.betType, 10, DefaultPairPays).NetChange(10)
+			if got != tt.wantNet {
+				t.Errorf("NetChange = %d, want %d", got, tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestWithPairs(t *testing.T) {
+	pays := PairPays{PlayerPair: 12, BankerPair: 11, EitherPair: 5, PerfectPair: 25, PerfectPairBoth: 250}
+	rs := WithPairs(Classic, pays)
+	if rs.Name() != "classic" {
+		t.Errorf("Name() = %q, want classic", rs.Name())
+	}
+	if n := len(rs.BetTypes()); n != len(Classic.BetTypes()) {
+		t.Errorf("replacing the pay table changed the bet list: %d bets", n)
+	}
+
+	mixedPair := cards(model.Card{Suit: model.Spades, Rank: model.Two}, model.Card{Suit: model.Hearts, Rank: model.Two})
+	noPair := cards(model.Card{Suit: model.Spades, Rank: model.Three}, model.Card{Suit: model.Hearts, Rank: model.Four})
+	if got := rs.Payout(mixedPair, noPair, PlayerPair, 10).NetChange(10); got != 120 {
+		t.Errorf("Player Pair NetChange = %d, want 120", got)
+	}
+	// Main bets are still settled by the wrapped variant (Banker 7 vs Player 4, 5% commission).
+	if got := rs.Payout(mixedPair, noPair, Banker, 100).NetChange(100); got != 95 {
+		t.Errorf("Banker NetChange = %d, want 95", got)
+	}
+}
+
+func TestParsePairPays(t *testing.T) {
+	pays, err := ParsePairPays(DefaultPairPays.String())
+	if err != nil || pays != DefaultPairPays {
+		t.Errorf("round trip = %+v, %v", pays, err)
+	}
+	for _, bad := range []string{"", "11,11,5,25", "11,11,5,25,x", "11,11,0,25,200"} {
+		if _, err := ParsePairPays(bad); err == nil {
+			t.Errorf("ParsePairPays(%q) should fail", bad)
+		}
+	}
+}
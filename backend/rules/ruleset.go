@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+)
+
+// RuleSet defines how a Baccarat variant settles its bets. The drawing rules
+// (DeterminePlayerHit, DetermineBankerHit) are the same in every variant.
+type RuleSet interface {
+	// Name returns the variant's identifier, as accepted by Variant.
+	Name() string
+	// BetTypes lists the wagers offered at the table, in display order.
+	BetTypes() []BetType
+	// Payout settles a single bet on a finished hand.
+	Payout(playerHand, bankerHand *model.Hand, betType BetType, betAmount int) PayoutResult
+}
+
+// Offers reports whether rs accepts bets of the given type.
+func Offers(rs RuleSet, betType BetType) bool {
+	for _, b := range rs.BetTypes() {
+		if b == betType {
+			return true
+		}
+	}
+	return false
+}
+
+// ezRules is EZ Baccarat Panda 8: no commission, Banker pushes on Dragon 7,
+// with the Dragon 7, Panda 8 and Dragon Bonus side bets.
+type ezRules struct{}
+
+func (ezRules) Name() string { return "ez" }
+
+func (ezRules) BetTypes() []BetType {
+	return []BetType{Player, Banker, Tie, Dragon, Panda, DragonBonusPlayer, DragonBonusBanker}
+}
+
+func (ezRules) Payout(playerHand, bankerHand *model.Hand, betType BetType, betAmount int) PayoutResult {
+	if betType.IsDragonBonus() {
+		return DragonBonusPayout(playerHand, bankerHand, betType, betAmount)
+	}
+	return CalculatePayout(DetermineOutcome(playerHand, bankerHand), betType, betAmount)
+}
+
+// StandardRules covers the commission-based and Super 6 style games, which offer
+// the Player, Banker and Tie main bets and the Dragon Bonus side bets.
+type StandardRules struct {
+	VariantName string
+	// BankerCommission is the percentage deducted from Banker winnings (5 in classic Baccarat).
+	// Winnings are paid in whole units and rounded down, as a table without
+	// coins smaller than the unit pays them: a 30 Banker bet wins 28 (28.5),
+	// and only stakes that are multiples of 20 are paid exactly.
+	BankerCommission int
+	// BankerSixPaysHalf pays Banker wins on a total of 6 at 1:2 instead of 1:1 (Super 6 / Punto 2000).
+	// The half and the commission are rounded down together, once.
+	BankerSixPaysHalf bool
+	// TiePays is the Tie payout, X to 1.
+	TiePays int
+}
+
+func (s StandardRules) Name() string { return s.VariantName }
+
+func (s StandardRules) BetTypes() []BetType {
+	return []BetType{Player, Banker, Tie, DragonBonusPlayer, DragonBonusBanker}
+}
+
+func (s StandardRules) Payout(playerHand, bankerHand *model.Hand, betType BetType, betAmount int) PayoutResult {
+	if betType.IsDragonBonus() {
+		return DragonBonusPayout(playerHand, bankerHand, betType, betAmount)
+	}
+	pPts, bPts := playerHand.TotalPoints(), bankerHand.TotalPoints()
+
+	switch {
+	case pPts == bPts:
+		switch betType {
+		case Tie:
+			return PayoutResult{WinAmount: betAmount * s.TiePays, Returned: betAmount}
+		case Player, Banker:
+			// Push
+			return PayoutResult{WinAmount: 0, Returned: betAmount}
+		}
+
+	case pPts > bPts:
+		if betType == Player {
+			return PayoutResult{WinAmount: betAmount, Returned: betAmount}
+		}
+
+	default:
+		if betType == Banker {
+			win := betAmount * (100 - s.BankerCommission)
+			if s.BankerSixPaysHalf && bPts == 6 {
+				win /= 2
+			}
+			return PayoutResult{WinAmount: win / 100, Returned: betAmount}
+		}
+	}
+
+	// Any other combo is a loss
+	return PayoutResult{WinAmount: 0, Returned: 0}
+}
+
+var (
+	// EZ is EZ Baccarat Panda 8, the default variant.
+	EZ = WithPairs(ezRules{}, DefaultPairPays)
+	// Classic is traditional Baccarat with a 5% commission on Banker wins.
+	Classic = WithPairs(StandardRules{VariantName: "classic", BankerCommission: 5, TiePays: 8}, DefaultPairPays)
+	// Super6 is commission-free Baccarat where a Banker win on 6 pays 1:2.
+	Super6 = WithPairs(StandardRules{VariantName: "super6", BankerSixPaysHalf: true, TiePays: 8}, DefaultPairPays)
+	// NoCommission is the Super 6 Banker rule with Tie paying 9:1.
+	NoCommission = WithPairs(StandardRules{VariantName: "nocommission", BankerSixPaysHalf: true, TiePays: 9}, DefaultPairPays)
+)
+
+var variants = []RuleSet{EZ, Classic, Super6, NoCommission}
+
+// VariantNames lists the identifiers accepted by Variant.
+func VariantNames() []string {
+	names := make([]string, len(variants))
+	for i, v := range variants {
+		names[i] = v.Name()
+	}
+	return names
+}
+
+// Variant looks up a built-in rule set by name (case-insensitive).
+func Variant(name string) (RuleSet, error) {
+	for _, v := range variants {
+		if strings.EqualFold(v.Name(), strings.TrimSpace(name)) {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown variant %q (available: %s)", name, strings.Join(VariantNames(), ", "))
+}
@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+)
+
+func hand(ranks ...model.Rank) *model.Hand {
+	h := &model.Hand{}
+	for _, r := range ranks {
+		h.AddCard(model.Card{Suit: model.Spades, Rank: r})
+	}
+	return h
+}
+
+func TestStandardRulesPayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		rs      RuleSet
+		player  *model.Hand
+		banker  *model.Hand
+		betType BetType
+		amount  int
+		wantNet int
+	}{
+		{"Classic Banker win pays 0.95", Classic, hand(model.Two, model.Three), hand(model.Four, model.Two), Banker, 100, 95},
+		{"Classic commission rounds down", Classic, hand(model.Two, model.Three), hand(model.Four, model.Two), Banker, 10, 9},
+		{"Classic commission rounds down on an odd stake", Classic, hand(model.Two, model.Three), hand(model.Four, model.Two), Banker, 31, 29},
+		{"Classic commission is exact on a multiple of 20", Classic, hand(model.Two, model.Three), hand(model.Four, model.Two), Banker, 60, 57},
+		{"Classic Dragon 7 is a normal Banker win", Classic, hand(model.Six), hand(model.Two, model.Two, model.Three), Banker, 100, 95},
+		{"Classic Player win pays 1:1", Classic, hand(model.Nine), hand(model.Eight), Player, 100, 100},
+		{"Classic Tie pays 8:1", Classic, hand(model.Seven), hand(model.Seven), Tie, 10, 80},
+		{"Classic Banker pushes on Tie", Classic, hand(model.Seven), hand(model.Seven), Banker, 100, 0},
+		{"Super 6 Banker win on 6 pays 1:2", Super6, hand(model.Five), hand(model.Six), Banker, 100, 50},
+		{"Super 6 half pay rounds down on an odd stake", Super6, hand(model.Five), hand(model.Six), Banker, 15, 7},
+		{"Half pay and commission round down once", StandardRules{BankerCommission: 5, BankerSixPaysHalf: true}, hand(model.Five), hand(model.Six), Banker, 19, 9},
+		{"Super 6 Banker win on 7 pays 1:1", Super6, hand(model.Five), hand(model.Seven), Banker, 100, 100},
+		{"Super 6 Player loses to Banker 6", Super6, hand(model.Five), hand(model.Six), Player, 100, -100},
+		{"No-commission Tie pays 9:1", NoCommission, hand(model.Seven), hand(model.Seven), Tie, 10, 90},
+		{"EZ Banker pushes on Dragon 7", EZ, hand(model.Six), hand(model.Two, model.Two, model.Three), Banker, 100, 0},
+		{"EZ Panda 8 pays 25:1", EZ, hand(model.Three, model.Three, model.Two), hand(model.Seven), Panda, 10, 250},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rs.Payout(tt.player, tt.banker, tt.betType, tt.amount).NetChange(tt.amount)
+			if got != tt.wantNet {
+				t.Errorf("NetChange = %d, want %d", got, tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestVariant(t *testing.T) {
+	for _, name := range VariantNames() {
+		rs, err := Variant(name)
+		if err != nil || rs.Name() != name {
+			t.Errorf("Variant(%q) = %v, %v", name, rs, err)
+		}
+	}
+	if rs, err := Variant("Classic"); err != nil || rs != Classic {
+		t.Errorf("Variant lookup should be case-insensitive")
+	}
+	if _, err := Variant("blackjack"); err == nil {
+		t.Errorf("Expected error for unknown variant")
+	}
+	if Offers(Classic, Dragon) || !Offers(EZ, Dragon) {
+		t.Errorf("Dragon 7 is only offered at EZ tables")
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	baccaratv1 "github.com/niubaoshu/es-Baccarat/backend/api/baccarat/v1"
+	"github.com/niubaoshu/es-Baccarat/backend/auth"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+)
+
+// EnableAuth makes players log in through one of the providers and identify
+// themselves with the tokens issuer issues, instead of naming themselves in
+// the PlayerMetadataKey header. Call it before serving, and serve with
+// ServerOptions.
+func (s *Server) EnableAuth(issuer *auth.Issuer, providers ...auth.Provider) {
+	s.issuer = issuer
+	s.providers = make(map[string]auth.Provider, len(providers))
+	for _, p := range providers {
+		s.providers[p.Name()] = p
+	}
+}
+
+// ServerOptions returns the options a gRPC server needs to serve s: the
+// interceptors that authenticate callers when auth is enabled.
+func (s *Server) ServerOptions() []grpc.ServerOption {
+	if s.issuer == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(auth.UnaryInterceptor(s.issuer)),
+		grpc.ChainStreamInterceptor(auth.StreamInterceptor(s.issuer)),
+	}
+}
+
+// Login verifies the credential with its provider and issues a token for the
+// player linked to the identity, registering the player on its first login.
+func (s *Server) Login(ctx context.Context, req *baccaratv1.LoginRequest) (*baccaratv1.LoginResponse, error) {
+	if s.issuer == nil {
+		return nil, status.Error(codes.Unimplemented, "authentication is not enabled on this server")
+	}
+	provider, ok := s.providers[req.GetProvider()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown identity provider %q", req.GetProvider())
+	}
+	id, err := provider.Verify(ctx, req.GetCredential())
+	if errors.Is(err, auth.ErrInvalidCredential) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "verifying credential: %v", err)
+	}
+
+	s.mu.Lock()
+	p, created, err := s.loginProfile(provider.Name(), id, req.GetPlayerName())
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	token, expires, err := s.issuer.Issue(p.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "issuing token: %v", err)
+	}
+	// The profile may be seated and settling at a table already.
+	balance, _ := p.Funds()
+	return &baccaratv1.LoginResponse{
+		AccessToken:     token,
+		ExpiresAtUnixMs: expires.UnixMilli(),
+		PlayerName:      p.Username,
+		Balance:         int64(balance),
+		NewPlayer:       created,
+	}, nil
+}
+
+// loginProfile returns the profile linked to the identity, or creates one
+// named name (the provider's suggestion if empty) and links it. If the link
+// cannot be stored the new profile is removed again. Caller must hold s.mu.
+func (s *Server) loginProfile(provider string, id auth.Identity, name string) (*player.Profile, bool, error) {
+	username, err := s.store.LookupIdentity(provider, id.Subject)
+	if err == nil {
+		p, err := s.profile(username)
+		if err != nil {
+			return nil, false, status.Errorf(codes.Internal, "loading %s: %v", username, err)
+		}
+		return p, false, nil
+	}
+	if !errors.Is(err, auth.ErrIdentityNotFound) {
+		return nil, false, status.Errorf(codes.Internal, "looking up identity: %v", err)
+	}
+
+	if name == "" {
+		name = id.Name
+	}
+	if err := player.ValidateUsername(name); err != nil {
+		return nil, false, status.Errorf(codes.InvalidArgument, "choose a player_name: %v", err)
+	}
+	p, err := s.accounts.Create(name, s.initialBalance)
+	if errors.Is(err, player.ErrPlayerAlreadyExists) {
+		return nil, false, status.Errorf(codes.AlreadyExists, "player name %q is taken; choose another player_name", name)
+	}
+	if err != nil {
+		return nil, false, status.Errorf(codes.Internal, "creating %s: %v", name, err)
+	}
+	if err := s.store.LinkIdentity(provider, id.Subject, name); err != nil {
+		// Without the link nobody can log in as the new player, and the name
+		// would stay taken; remove the account so the login can be retried.
+		if derr := s.accounts.Delete(name); derr != nil {
+			return nil, false, status.Errorf(codes.Internal, "linking identity: %v (and removing %s: %v)", err, name, derr)
+		}
+		return nil, false, status.Errorf(codes.Internal, "linking identity: %v", err)
+	}
+	s.players[name] = p
+	return p, true, nil
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	baccaratv1 "github.com/niubaoshu/es-Baccarat/backend/api/baccarat/v1"
+	"github.com/niubaoshu/es-Baccarat/backend/engine"
+	"github.com/niubaoshu/es-Baccarat/backend/fair"
+)
+
+// SubscribeTable streams a table's events until the client goes away or the
+// server closes. With after_seq set it first replays the held events after it,
+// or every held event if it is 0; without it only live events are sent.
+func (s *Server) SubscribeTable(req *baccaratv1.SubscribeTableRequest, stream baccaratv1.TableService_SubscribeTableServer) error {
+	s.mu.Lock()
+	t, ok := s.tables[req.GetTableId()]
+	s.mu.Unlock()
+	if !ok {
+		return status.Error(codes.NotFound, ErrTableNotFound.Error())
+	}
+
+	after := req.GetAfterSeq()
+	if req.AfterSeq == nil {
+		after = t.LastEventSeq()
+	}
+	ctx := stream.Context()
+	for {
+		events, next, err := t.Events(after)
+		if errors.Is(err, engine.ErrEventsExpired) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		for _, e := range events {
+			if err := stream.Send(eventProto(t.ID, e)); err != nil {
+				return err
+			}
+			after = e.Seq
+		}
+
+		select {
+		case <-next:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.ctx.Done():
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+	}
+}
+
+// eventProto converts a table event into its wire form.
+func eventProto(tableID string, e engine.TableEvent) *baccaratv1.TableEvent {
+	out := &baccaratv1.TableEvent{
+		Seq:        e.Seq,
+		TimeUnixMs: e.Time.UnixMilli(),
+		TableId:    tableID,
+		Round:      int32(e.Round),
+	}
+	switch e.Type {
+	case engine.EventPhase:
+		phase := &baccaratv1.PhaseEvent{Status: string(e.Phase)}
+		if !e.Deadline.IsZero() {
+			phase.DeadlineUnixMs = e.Deadline.UnixMilli()
+		}
+		out.Event = &baccaratv1.TableEvent_Phase{Phase: phase}
+	case engine.EventCountdown:
+		out.Event = &baccaratv1.TableEvent_Countdown{Countdown: &baccaratv1.CountdownEvent{
+			RemainingMs:    e.Remaining.Milliseconds(),
+			DeadlineUnixMs: e.Deadline.UnixMilli(),
+		}}
+	case engine.EventSeat:
+		out.Event = &baccaratv1.TableEvent_Seat{Seat: &baccaratv1.SeatEvent{
+			SeatNumber: int32(e.Seat),
+			PlayerName: e.Player,
+			Joined:     e.Joined,
+		}}
+	case engine.EventBet:
+		bets := make(map[string]int64, len(e.Bets))
+		for k, v := range e.Bets {
+			bets[string(k)] = int64(v)
+		}
+		out.Event = &baccaratv1.TableEvent_Bet{Bet: &baccaratv1.BetEvent{
+			SeatNumber: int32(e.Seat),
+			PlayerName: e.Player,
+			Bets:       bets,
+		}}
+	case engine.EventCard:
+		out.Event = &baccaratv1.TableEvent_Card{Card: &baccaratv1.CardEvent{
+			Side:        e.Draw.Side.String(),
+			Card:        e.Draw.Card.String(),
+			ThirdCard:   e.Draw.ThirdCard,
+			PlayerTotal: int32(e.PlayerPoints),
+			BankerTotal: int32(e.BankerPoints),
+		}}
+	case engine.EventDecision:
+		out.Event = &baccaratv1.TableEvent_Decision{Decision: &baccaratv1.DecisionEvent{
+			Side:   e.Decision.Side.String(),
+			Hit:    e.Decision.Hit,
+			Reason: e.Decision.Reason(),
+		}}
+	case engine.EventOutcome:
+		out.Event = &baccaratv1.TableEvent_Outcome{Outcome: &baccaratv1.OutcomeEvent{
+			Outcome:     string(e.Outcome),
+			PlayerTotal: int32(e.PlayerPoints),
+			BankerTotal: int32(e.BankerPoints),
+		}}
+	case engine.EventSettlement:
+		out.Event = &baccaratv1.TableEvent_Settlement{Settlement: &baccaratv1.SettlementEvent{
+			SeatNumber:  int32(e.Seat),
+			PlayerName:  e.Player,
+			TotalPayout: int64(e.Payout),
+			NetChange:   int64(e.Net),
+			NewBalance:  int64(e.Balance),
+		}}
+	case engine.EventCutCard:
+		out.Event = &baccaratv1.TableEvent_CutCard{CutCard: &baccaratv1.CutCardEvent{}}
+	case engine.EventNewShoe:
+		out.Event = &baccaratv1.TableEvent_NewShoe{NewShoe: &baccaratv1.NewShoeEvent{
+			ShoeId:         e.ShoeID,
+			CardsRemaining: int32(e.CardsLeft),
+			Commitment:     commitmentProto(e.Commitment),
+		}}
+	case engine.EventShoeReveal:
+		out.Event = &baccaratv1.TableEvent_ShoeReveal{ShoeReveal: &baccaratv1.ShoeRevealEvent{
+			Commitment: commitmentProto(&e.Reveal.Commitment),
+			ServerSeed: e.Reveal.ServerSeed,
+		}}
+	}
+	return out
+}
+
+// commitmentProto converts a shoe commitment into its wire form; nil stays nil.
+func commitmentProto(c *fair.Commitment) *baccaratv1.ShoeCommitment {
+	if c == nil {
+		return nil
+	}
+	return &baccaratv1.ShoeCommitment{
+		ShoeId:           c.ShoeID,
+		ServerSeedHash:   c.ServerSeedHash,
+		ClientSeed:       c.ClientSeed,
+		OrderHash:        c.OrderHash,
+		DecksCount:       int32(c.DecksCount),
+		CutCardThreshold: int32(c.CutCardThreshold),
+		BurnRule:         c.BurnRule,
+	}
+}
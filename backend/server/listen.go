@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net"
+
+	"google.golang.org/grpc"
+
+	baccaratv1 "github.com/niubaoshu/es-Baccarat/backend/api/baccarat/v1"
+)
+
+// Register attaches the auth, lobby and table services to a gRPC server.
+func (s *Server) Register(gs *grpc.Server) {
+	baccaratv1.RegisterAuthServiceServer(gs, s)
+	baccaratv1.RegisterLobbyServiceServer(gs, s)
+	baccaratv1.RegisterTableServiceServer(gs, s)
+}
+
+// ListenAndServe starts a gRPC server on addr and blocks until it stops.
+func (s *Server) ListenAndServe(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	gs := grpc.NewServer(s.ServerOptions()...)
+	s.Register(gs)
+	return gs.Serve(lis)
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	baccaratv1 "github.com/niubaoshu/es-Baccarat/backend/api/baccarat/v1"
+	"github.com/niubaoshu/es-Baccarat/backend/roadmap"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// roadmapProto converts a table's scoreboard into its wire form.
+func roadmapProto(m *roadmap.Roadmap) *baccaratv1.Roadmap {
+	colorCell := func(c roadmap.Color) *baccaratv1.RoadCell { return &baccaratv1.RoadCell{Value: c.String()} }
+
+	resp := &baccaratv1.Roadmap{
+		BeadPlate: roadProto(m.BeadPlate(), func(o rules.Outcome) *baccaratv1.RoadCell {
+			return &baccaratv1.RoadCell{Value: string(o)}
+		}),
+		BigRoad: roadProto(m.BigRoad(), func(mk roadmap.Mark) *baccaratv1.RoadCell {
+			return &baccaratv1.RoadCell{Value: string(mk.Outcome), Ties: int32(mk.Ties)}
+		}),
+		BigEyeBoy:    roadProto(m.BigEyeBoy(), colorCell),
+		SmallRoad:    roadProto(m.SmallRoad(), colorCell),
+		CockroachPig: roadProto(m.CockroachPig(), colorCell),
+	}
+	for _, o := range m.Outcomes() {
+		resp.Outcomes = append(resp.Outcomes, string(o))
+	}
+	for _, next := range []rules.Outcome{rules.OutcomeBanker, rules.OutcomePlayer} {
+		p := m.Ask(next)
+		resp.AskRoads = append(resp.AskRoads, &baccaratv1.AskRoad{
+			Next:         string(next),
+			BigEyeBoy:    p.BigEyeBoy.String(),
+			SmallRoad:    p.SmallRoad.String(),
+			CockroachPig: p.CockroachPig.String(),
+		})
+	}
+	return resp
+}
+
+// roadProto lists the occupied cells of a road grid.
+func roadProto[T comparable](g roadmap.Grid[T], cell func(T) *baccaratv1.RoadCell) *baccaratv1.Road {
+	var zero T
+	road := &baccaratv1.Road{Columns: int32(len(g))}
+	for col := range g {
+		for row, v := range g[col] {
+			if v == zero {
+				continue
+			}
+			c := cell(v)
+			c.Column, c.Row = int32(col), int32(row)
+			road.Cells = append(road.Cells, c)
+		}
+	}
+	return road
+}
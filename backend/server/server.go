@@ -0,0 +1,439 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	baccaratv1 "github.com/niubaoshu/es-Baccarat/backend/api/baccarat/v1"
+	"github.com/niubaoshu/es-Baccarat/backend/auth"
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/engine"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/storage"
+)
+
+// PlayerMetadataKey is the gRPC metadata header that carries the caller's
+// username on a server without authentication (see EnableAuth).
+const PlayerMetadataKey = "x-player-name"
+
+// DefaultMaxPlayers is the seat count used when CreateTable does not specify one.
+const DefaultMaxPlayers = 7
+
+var (
+	ErrTableNotFound   = errors.New("table not found")
+	ErrTableFull       = engine.ErrTableFull
+	ErrNotSeated       = engine.ErrNotSeated
+	ErrSeatedElsewhere = errors.New("player is seated at another table")
+	ErrNoHand          = errors.New("the round closed before the bets were dealt")
+	ErrDuplicateBet    = errors.New("bet type given more than once")
+)
+
+// record returns the persisted form of a table.
+func record(st engine.TableState) storage.TableRecord {
+	seats := make(map[int]string, len(st.Seats))
+	for _, seat := range st.Seats {
+		seats[seat.Seat] = seat.Player
+	}
+	return storage.TableRecord{ID: st.ID, MaxPlayers: st.MaxPlayers, Seats: seats}
+}
+
+// Server implements the LobbyService and TableService gRPC APIs.
+// Every table runs its own round cycle (see engine.Table). Tables, seats and
+// player accounts are written through to the store, along with every round
+// dealt; shoes live only in memory. A player sits at one table at a time.
+type Server struct {
+	baccaratv1.UnimplementedAuthServiceServer
+	baccaratv1.UnimplementedLobbyServiceServer
+	baccaratv1.UnimplementedTableServiceServer
+
+	cfg            *config.GameConfig
+	initialBalance int
+	store          storage.Store
+	accounts       *player.Accounts
+
+	issuer    *auth.Issuer // nil if callers name themselves in PlayerMetadataKey
+	providers map[string]auth.Provider
+
+	ctx  context.Context // stops the tables' round cycles
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+
+	mu      sync.Mutex
+	tables  map[string]*engine.Table
+	players map[string]*player.Profile
+	seated  map[string]string // username -> table ID
+	nextID  int
+}
+
+// New creates a Server backed by st and reopens the tables stored in it, each
+// with a new shoe. Players that connect without an existing profile are
+// registered with initialBalance. Close stops the tables.
+func New(cfg *config.GameConfig, st storage.Store, initialBalance int) (*Server, error) {
+	s := &Server{
+		cfg:            cfg,
+		initialBalance: initialBalance,
+		store:          st,
+		accounts:       player.NewAccounts(st),
+		tables:         make(map[string]*engine.Table),
+		players:        make(map[string]*player.Profile),
+		seated:         make(map[string]string),
+	}
+	s.ctx, s.stop = context.WithCancel(context.Background())
+
+	records, err := st.LoadTables()
+	if err != nil {
+		return nil, fmt.Errorf("loading tables: %w", err)
+	}
+	for _, rec := range records {
+		n, err := strconv.Atoi(strings.TrimPrefix(rec.ID, "table-"))
+		if err != nil {
+			return nil, fmt.Errorf("loading tables: unexpected table ID %q", rec.ID)
+		}
+		s.nextID = max(s.nextID, n)
+
+		t, err := engine.NewTable(rec.ID, cfg, n, rec.MaxPlayers, st)
+		if err != nil {
+			return nil, fmt.Errorf("reopening %s: %w", rec.ID, err)
+		}
+		for seat, name := range rec.Seats {
+			p, err := s.profile(name)
+			if err == nil {
+				err = t.SitAt(p, seat)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("reseating %s at %s: %w", name, rec.ID, err)
+			}
+			s.seated[name] = rec.ID
+		}
+		s.tables[t.ID] = t
+	}
+	for _, t := range s.tables {
+		s.open(t)
+	}
+	return s, nil
+}
+
+// open starts the table's round cycle. Betting is open when it returns.
+func (s *Server) open(t *engine.Table) {
+	t.OnError = func(err error) {
+		log.Printf("table %s: round voided: %v", t.ID, err)
+	}
+	t.OpenBetting()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := t.Run(s.ctx); !errors.Is(err, context.Canceled) {
+			log.Printf("table %s: stopped: %v", t.ID, err)
+		}
+	}()
+}
+
+// Close stops every table's round cycle. Rounds still taking bets close
+// without dealing. The tables' shoes are retired, so in provably-fair mode
+// their server seeds are revealed in the shoe log.
+func (s *Server) Close() {
+	s.stop()
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tables {
+		if err := t.Retire(); err != nil {
+			log.Printf("table %s: retiring shoe: %v", t.ID, err)
+		}
+	}
+}
+
+// playerName returns the caller's username: the player its token was issued
+// to, or the PlayerMetadataKey header on a server without authentication.
+func (s *Server) playerName(ctx context.Context) (string, error) {
+	if s.issuer != nil {
+		if username, ok := auth.PlayerFrom(ctx); ok {
+			return username, nil
+		}
+		return "", status.Errorf(codes.Unauthenticated, "missing %s metadata", auth.MetadataKey)
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		if v := md.Get(PlayerMetadataKey); len(v) > 0 && strings.TrimSpace(v[0]) != "" {
+			username := strings.TrimSpace(v[0])
+			if err := player.ValidateUsername(username); err != nil {
+				return "", status.Errorf(codes.InvalidArgument, "%s: %v", PlayerMetadataKey, err)
+			}
+			return username, nil
+		}
+	}
+	return "", status.Errorf(codes.Unauthenticated, "missing %s metadata", PlayerMetadataKey)
+}
+
+// profile returns the cached profile for username, loading or creating it on first use.
+// Caller must hold s.mu.
+func (s *Server) profile(username string) (*player.Profile, error) {
+	if p, ok := s.players[username]; ok {
+		return p, nil
+	}
+	p, err := s.accounts.Load(username)
+	if errors.Is(err, player.ErrPlayerNotFound) {
+		p, err = s.accounts.Create(username, s.initialBalance)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.players[username] = p
+	return p, nil
+}
+
+// ListTables returns a summary of every active table, ordered by table ID.
+func (s *Server) ListTables(ctx context.Context, req *baccaratv1.ListTablesRequest) (*baccaratv1.ListTablesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &baccaratv1.ListTablesResponse{}
+	for _, t := range s.tables {
+		st := t.State()
+		status := string(st.Phase)
+		if len(st.Seats) >= st.MaxPlayers {
+			status = "FULL"
+		}
+		resp.Tables = append(resp.Tables, &baccaratv1.TableSummary{
+			TableId:       st.ID,
+			PlayersSeated: int32(len(st.Seats)),
+			MaxPlayers:    int32(st.MaxPlayers),
+			Status:        status,
+		})
+	}
+	sort.Slice(resp.Tables, func(i, j int) bool { return resp.Tables[i].TableId < resp.Tables[j].TableId })
+	return resp, nil
+}
+
+// CreateTable opens a new table with a freshly shuffled shoe and starts taking bets.
+func (s *Server) CreateTable(ctx context.Context, req *baccaratv1.CreateTableRequest) (*baccaratv1.CreateTableResponse, error) {
+	maxPlayers := int(req.GetMaxPlayers())
+	if maxPlayers < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_players must not be negative")
+	}
+	if maxPlayers == 0 {
+		maxPlayers = DefaultMaxPlayers
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	t, err := engine.NewTable(fmt.Sprintf("table-%d", s.nextID), s.cfg, s.nextID, maxPlayers, s.store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if err := s.store.SaveTable(record(t.State())); err != nil {
+		return nil, status.Errorf(codes.Internal, "saving table: %v", err)
+	}
+	s.tables[t.ID] = t
+	s.open(t)
+	return &baccaratv1.CreateTableResponse{TableId: t.ID}, nil
+}
+
+// JoinTable seats the caller at the lowest free seat. Joining a table the caller
+// is already seated at returns the existing seat.
+func (s *Server) JoinTable(ctx context.Context, req *baccaratv1.JoinTableRequest) (*baccaratv1.JoinTableResponse, error) {
+	username, err := s.playerName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tables[req.GetTableId()]
+	if !ok {
+		return &baccaratv1.JoinTableResponse{ErrorMessage: ErrTableNotFound.Error()}, nil
+	}
+	if id, ok := s.seated[username]; ok && id != t.ID {
+		return &baccaratv1.JoinTableResponse{ErrorMessage: ErrSeatedElsewhere.Error()}, nil
+	}
+	p, err := s.profile(username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading profile: %v", err)
+	}
+	seat, err := t.Sit(p)
+	if err != nil {
+		return &baccaratv1.JoinTableResponse{ErrorMessage: err.Error()}, nil
+	}
+	if _, ok := s.seated[username]; !ok {
+		if err := s.store.SaveTable(record(t.State())); err != nil {
+			t.Leave(username)
+			return nil, status.Errorf(codes.Internal, "saving table: %v", err)
+		}
+		s.seated[username] = t.ID
+	}
+	return &baccaratv1.JoinTableResponse{Success: true, SeatNumber: int32(seat)}, nil
+}
+
+// LeaveTable frees the caller's seat. Bets already locked for the hand being
+// dealt are still settled.
+func (s *Server) LeaveTable(ctx context.Context, req *baccaratv1.LeaveTableRequest) (*baccaratv1.LeaveTableResponse, error) {
+	username, err := s.playerName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tables[req.GetTableId()]
+	if !ok {
+		return &baccaratv1.LeaveTableResponse{}, nil
+	}
+	if err := t.Leave(username); err != nil {
+		return &baccaratv1.LeaveTableResponse{}, nil
+	}
+	delete(s.seated, username)
+	if err := s.store.SaveTable(record(t.State())); err != nil {
+		return nil, status.Errorf(codes.Internal, "saving table: %v", err)
+	}
+	return &baccaratv1.LeaveTableResponse{Success: true}, nil
+}
+
+// GetTableState returns the phase, seats, balances, shoe level and roadmap of a table.
+func (s *Server) GetTableState(ctx context.Context, req *baccaratv1.GetTableStateRequest) (*baccaratv1.GetTableStateResponse, error) {
+	s.mu.Lock()
+	t, ok := s.tables[req.GetTableId()]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Error(codes.NotFound, ErrTableNotFound.Error())
+	}
+
+	st := t.State()
+	resp := &baccaratv1.GetTableStateResponse{
+		TableId:            st.ID,
+		Status:             string(st.Phase),
+		ShoeCardsRemaining: int32(st.CardsLeft),
+		Roadmap:            roadmapProto(st.Road),
+		ShoeId:             st.ShoeID,
+		ShoeCommitment:     commitmentProto(st.Commitment),
+	}
+	for _, seat := range st.Seats {
+		resp.Players = append(resp.Players, &baccaratv1.SeatedPlayer{
+			SeatNumber: int32(seat.Seat),
+			PlayerName: seat.Player,
+			Balance:    int64(seat.Balance),
+		})
+	}
+	return resp, nil
+}
+
+// parseBets converts the wire bet map into rules.BetType amounts. Each bet
+// type may appear once, under any of its names, with a positive amount; the
+// table checks the rest against its limits, like the interactive CLI.
+func parseBets(in map[string]int64) (map[rules.BetType]int, error) {
+	bets := make(map[rules.BetType]int)
+	keys := make(map[rules.BetType]string)
+	for k, amt := range in {
+		bType, err := rules.ParseBetType(k)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, k)
+		}
+		if prev, ok := keys[bType]; ok {
+			return nil, fmt.Errorf("%w: %q and %q are both %s", ErrDuplicateBet, prev, k, bType)
+		}
+		switch {
+		case amt <= 0:
+			return nil, &rules.BetError{BetType: bType, Amount: int(max(amt, math.MinInt)), Err: rules.ErrInvalidAmount}
+		case amt > math.MaxInt:
+			return nil, &rules.BetError{BetType: bType, Amount: math.MaxInt, Err: rules.ErrInsufficientFunds}
+		}
+		keys[bType] = k
+		bets[bType] = int(amt)
+	}
+	return bets, nil
+}
+
+// PlaceBet places the caller's bets in the table's open round, replacing any
+// placed earlier in the same betting window. It returns once the hand has been
+// dealt to every seated player and settled, with the complete hand for
+// client-side animation.
+func (s *Server) PlaceBet(ctx context.Context, req *baccaratv1.PlaceBetRequest) (*baccaratv1.PlaceBetResponse, error) {
+	username, err := s.playerName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	t, ok := s.tables[req.GetTableId()]
+	s.mu.Unlock()
+	if !ok {
+		return &baccaratv1.PlaceBetResponse{ErrorMessage: ErrTableNotFound.Error()}, nil
+	}
+
+	bets, err := parseBets(req.GetBets())
+	if err != nil {
+		return &baccaratv1.PlaceBetResponse{ErrorMessage: err.Error()}, nil
+	}
+	round, err := t.PlaceBet(username, bets)
+	if err != nil {
+		return &baccaratv1.PlaceBetResponse{ErrorMessage: err.Error()}, nil
+	}
+
+	select {
+	case <-round.Done():
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	settled, ok := round.Settlement(username)
+	if !ok {
+		return &baccaratv1.PlaceBetResponse{ErrorMessage: ErrNoHand.Error()}, nil
+	}
+	if settled.Err != nil {
+		return nil, status.Errorf(codes.Internal, "settling round: %v", settled.Err)
+	}
+
+	r := settled.Result
+	result := &baccaratv1.HandResult{
+		PlayerCards: engine.CardStrings(r.PlayerHand),
+		BankerCards: engine.CardStrings(r.BankerHand),
+		PlayerTotal: int32(r.PlayerHand.TotalPoints()),
+		BankerTotal: int32(r.BankerHand.TotalPoints()),
+		Outcome:     string(r.Outcome),
+		TotalPayout: int64(r.TotalPayout),
+		NewBalance:  int64(settled.FinalBalance),
+	}
+	return &baccaratv1.PlaceBetResponse{Success: true, Result: result}, nil
+}
+
+// MaxClientSeedLength is the longest client seed SetClientSeed accepts, in bytes.
+const MaxClientSeedLength = 128
+
+// SetClientSeed sets the client seed of the table's next provably-fair shoes
+// for a seated caller.
+func (s *Server) SetClientSeed(ctx context.Context, req *baccaratv1.SetClientSeedRequest) (*baccaratv1.SetClientSeedResponse, error) {
+	username, err := s.playerName(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seed := req.GetClientSeed()
+	if seed == "" || len(seed) > MaxClientSeedLength {
+		return nil, status.Errorf(codes.InvalidArgument, "client_seed must be 1 to %d bytes", MaxClientSeedLength)
+	}
+
+	s.mu.Lock()
+	t, ok := s.tables[req.GetTableId()]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Error(codes.NotFound, ErrTableNotFound.Error())
+	}
+	if err := t.SetClientSeed(username, seed); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &baccaratv1.SetClientSeedResponse{}, nil
+}
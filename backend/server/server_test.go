@@ -0,0 +1,652 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	baccaratv1 "github.com/niubaoshu/es-Baccarat/backend/api/baccarat/v1"
+	"github.com/niubaoshu/es-Baccarat/backend/auth"
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/fair"
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+	"github.com/niubaoshu/es-Baccarat/backend/storage"
+)
+
+// testConfig returns the default config with a betting window short enough
+// for PlaceBet calls to return quickly.
+func testConfig() *config.GameConfig {
+	cfg := config.DefaultConfig()
+	cfg.BettingWindow = 20 * time.Millisecond
+	return cfg
+}
+
+func newTestClients(t *testing.T) (baccaratv1.LobbyServiceClient, baccaratv1.TableServiceClient) {
+	t.Helper()
+	return newTestClientsWithConfig(t, testConfig())
+}
+
+func newTestClientsWithConfig(t *testing.T, cfg *config.GameConfig) (baccaratv1.LobbyServiceClient, baccaratv1.TableServiceClient) {
+	t.Helper()
+	// Profiles and logs are written relative to the working directory.
+	t.Chdir(t.TempDir())
+	return newTestClientsWithStore(t, cfg, storage.NewFiles(cfg.Storage.DataDir))
+}
+
+func newTestClientsWithStore(t *testing.T, cfg *config.GameConfig, st storage.Store) (baccaratv1.LobbyServiceClient, baccaratv1.TableServiceClient) {
+	t.Helper()
+	srv, err := New(cfg, st, 1000)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(srv.Close)
+	conn := dial(t, srv)
+	return baccaratv1.NewLobbyServiceClient(conn), baccaratv1.NewTableServiceClient(conn)
+}
+
+// dial serves srv in memory and returns a connection to it.
+func dial(t *testing.T, srv *Server) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(srv.ServerOptions()...)
+	srv.Register(gs)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func asPlayer(name string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), PlayerMetadataKey, name)
+}
+
+func TestCreateJoinAndPlaceBet(t *testing.T) {
+	lobby, tables := newTestClients(t)
+	ctx := asPlayer("alice")
+
+	created, err := lobby.CreateTable(ctx, &baccaratv1.CreateTableRequest{MaxPlayers: 2})
+	if err != nil {
+		t.Fatalf("CreateTable: %v", err)
+	}
+
+	list, err := lobby.ListTables(ctx, &baccaratv1.ListTablesRequest{})
+	if err != nil || len(list.Tables) != 1 || list.Tables[0].MaxPlayers != 2 {
+		t.Fatalf("ListTables = %v, %v", list, err)
+	}
+
+	join, err := tables.JoinTable(ctx, &baccaratv1.JoinTableRequest{TableId: created.TableId})
+	if err != nil || !join.Success || join.SeatNumber != 1 {
+		t.Fatalf("JoinTable = %v, %v", join, err)
+	}
+
+	resp, err := tables.PlaceBet(ctx, &baccaratv1.PlaceBetRequest{TableId: created.TableId, Bets: map[string]int64{"Player": 100}})
+	if err != nil || !resp.Success {
+		t.Fatalf("PlaceBet = %v, %v", resp, err)
+	}
+	r := resp.Result
+	if len(r.PlayerCards) < 2 || len(r.BankerCards) < 2 {
+		t.Errorf("expected at least two cards per side, got %v / %v", r.PlayerCards, r.BankerCards)
+	}
+	if r.NewBalance != 1000-100+r.TotalPayout {
+		t.Errorf("NewBalance = %d, want %d", r.NewBalance, 1000-100+r.TotalPayout)
+	}
+
+	state, err := tables.GetTableState(ctx, &baccaratv1.GetTableStateRequest{TableId: created.TableId})
+	if err != nil {
+		t.Fatalf("GetTableState: %v", err)
+	}
+	if len(state.Players) != 1 || state.Players[0].Balance != r.NewBalance {
+		t.Errorf("unexpected table state: %v", state)
+	}
+	road := state.GetRoadmap()
+	if len(road.GetOutcomes()) != 1 || road.Outcomes[0] != r.Outcome {
+		t.Errorf("roadmap outcomes = %v, want [%s]", road.GetOutcomes(), r.Outcome)
+	}
+	if cells := road.GetBeadPlate().GetCells(); len(cells) != 1 || cells[0].Value != r.Outcome {
+		t.Errorf("bead plate = %v", cells)
+	}
+	if len(road.GetAskRoads()) != 2 {
+		t.Errorf("expected Banker and Player ask roads, got %v", road.GetAskRoads())
+	}
+
+	// The bet and its payout are recorded in the player's ledger.
+	entries, err := player.LoadLedger("alice")
+	if err != nil || len(entries) < 2 {
+		t.Fatalf("LoadLedger = %v, %v", entries, err)
+	}
+	if debit := entries[1]; debit.Kind != player.EntryDebit || debit.Amount != 100 || debit.RoundID == "" {
+		t.Errorf("first bet entry = %+v", debit)
+	}
+	if last := entries[len(entries)-1]; last.Balance != int(r.NewBalance) {
+		t.Errorf("ledger ends at $%d, balance is $%d", last.Balance, r.NewBalance)
+	}
+}
+
+func TestPlaceBetRejections(t *testing.T) {
+	cfg := testConfig()
+	cfg.Limits.NoPlayerAndBanker = true
+	lobby, tables := newTestClientsWithConfig(t, cfg)
+	ctx := asPlayer("bob")
+
+	created, _ := lobby.CreateTable(ctx, &baccaratv1.CreateTableRequest{})
+
+	resp, err := tables.PlaceBet(ctx, &baccaratv1.PlaceBetRequest{TableId: created.TableId, Bets: map[string]int64{"Player": 10}})
+	if err != nil || resp.Success || resp.ErrorMessage != ErrNotSeated.Error() {
+		t.Errorf("expected not seated error, got %v, %v", resp, err)
+	}
+
+	_, _ = tables.JoinTable(ctx, &baccaratv1.JoinTableRequest{TableId: created.TableId})
+
+	cases := map[string]map[string]int64{
+		"side bet without base": {"Dragon": 10},
+		"insufficient funds":    {"Banker": 5000},
+		"unknown bet type":      {"Lucky 6": 10},
+		"non-positive amount":   {"Player": 0},
+		"player and banker":     {"Player": 10, "Banker": 10},
+		"negative alias":        {"Player": 100, "P": -50},
+		"duplicate bet type":    {"Banker": 10, "B": 10},
+		"overflowing total":     {"Player": math.MaxInt64, "Tie": math.MaxInt64},
+	}
+	for name, bets := range cases {
+		resp, err := tables.PlaceBet(ctx, &baccaratv1.PlaceBetRequest{TableId: created.TableId, Bets: bets})
+		if err != nil || resp.Success {
+			t.Errorf("%s: expected rejection, got %v, %v", name, resp, err)
+		}
+	}
+
+	_, err = tables.JoinTable(context.Background(), &baccaratv1.JoinTableRequest{TableId: created.TableId})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without player metadata, got %v", err)
+	}
+	_, err = tables.JoinTable(asPlayer("../../escaped"), &baccaratv1.JoinTableRequest{TableId: created.TableId})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a player name outside the data directory, got %v", err)
+	}
+}
+
+func TestParseBets(t *testing.T) {
+	bets, err := parseBets(map[string]int64{"P": 100, "Tie": 10})
+	if err != nil || len(bets) != 2 || bets[rules.Player] != 100 || bets[rules.Tie] != 10 {
+		t.Errorf("parseBets = %v, %v", bets, err)
+	}
+	for _, tt := range []struct {
+		in   map[string]int64
+		want error
+	}{
+		{map[string]int64{"Player": 100, "P": 50}, ErrDuplicateBet},
+		{map[string]int64{"P": -50}, rules.ErrInvalidAmount},
+		{map[string]int64{"Banker": 0}, rules.ErrInvalidAmount},
+		{map[string]int64{"Lucky 6": 10}, rules.ErrUnknownBetType},
+	} {
+		if _, err := parseBets(tt.in); !errors.Is(err, tt.want) {
+			t.Errorf("parseBets(%v) = %v, want %v", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestTableFull(t *testing.T) {
+	lobby, tables := newTestClients(t)
+
+	created, _ := lobby.CreateTable(asPlayer("a"), &baccaratv1.CreateTableRequest{MaxPlayers: 1})
+	if r, _ := tables.JoinTable(asPlayer("a"), &baccaratv1.JoinTableRequest{TableId: created.TableId}); !r.Success {
+		t.Fatalf("first join failed: %v", r)
+	}
+	if r, _ := tables.JoinTable(asPlayer("b"), &baccaratv1.JoinTableRequest{TableId: created.TableId}); r.Success {
+		t.Errorf("second join should fail on a full table")
+	}
+	if r, _ := tables.LeaveTable(asPlayer("a"), &baccaratv1.LeaveTableRequest{TableId: created.TableId}); !r.Success {
+		t.Errorf("leave failed")
+	}
+	if r, _ := tables.JoinTable(asPlayer("b"), &baccaratv1.JoinTableRequest{TableId: created.TableId}); !r.Success || r.SeatNumber != 1 {
+		t.Errorf("join after leave = %v", r)
+	}
+
+	other, _ := lobby.CreateTable(asPlayer("b"), &baccaratv1.CreateTableRequest{})
+	if r, _ := tables.JoinTable(asPlayer("b"), &baccaratv1.JoinTableRequest{TableId: other.TableId}); r.Success || r.ErrorMessage != ErrSeatedElsewhere.Error() {
+		t.Errorf("joining a second table = %v", r)
+	}
+}
+
+func TestPlayersShareTheHand(t *testing.T) {
+	cfg := testConfig()
+	// Long enough for every player below to bet in the same window.
+	cfg.BettingWindow = 500 * time.Millisecond
+	lobby, tables := newTestClientsWithConfig(t, cfg)
+	created, _ := lobby.CreateTable(asPlayer("alice"), &baccaratv1.CreateTableRequest{})
+
+	names := []string{"alice", "bob", "carol"}
+	results := make([]*baccaratv1.HandResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		ctx := asPlayer(name)
+		if r, err := tables.JoinTable(ctx, &baccaratv1.JoinTableRequest{TableId: created.TableId}); err != nil || !r.Success {
+			t.Fatalf("JoinTable(%s) = %v, %v", name, r, err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := tables.PlaceBet(ctx, &baccaratv1.PlaceBetRequest{TableId: created.TableId, Bets: map[string]int64{"P": int64(10 * (i + 1))}})
+			if err != nil || !resp.Success {
+				t.Errorf("PlaceBet(%s) = %v, %v", name, resp, err)
+				return
+			}
+			results[i] = resp.Result
+		}()
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	state, _ := tables.GetTableState(asPlayer("alice"), &baccaratv1.GetTableStateRequest{TableId: created.TableId})
+	if hands := len(state.GetRoadmap().GetOutcomes()); hands != 1 {
+		t.Fatalf("%d hands dealt, want one shared hand", hands)
+	}
+	for i, r := range results {
+		if !slices.Equal(r.PlayerCards, results[0].PlayerCards) || !slices.Equal(r.BankerCards, results[0].BankerCards) {
+			t.Errorf("%s was dealt %v / %v, alice %v / %v", names[i], r.PlayerCards, r.BankerCards, results[0].PlayerCards, results[0].BankerCards)
+		}
+		if bet := int64(10 * (i + 1)); r.NewBalance != 1000-bet+r.TotalPayout {
+			t.Errorf("%s: NewBalance = %d, want %d", names[i], r.NewBalance, 1000-bet+r.TotalPayout)
+		}
+	}
+}
+
+func TestPresetShoe(t *testing.T) {
+	cfg := testConfig()
+	// A Banker three-card 7 against Player 6, dealt again every hand.
+	cards, err := model.ParseCards("10S 2H 6D KC 5H")
+	if err != nil {
+		t.Fatalf("ParseCards: %v", err)
+	}
+	cfg.PresetShoe = cards
+	lobby, tables := newTestClientsWithConfig(t, cfg)
+	ctx := asPlayer("alice")
+
+	created, _ := lobby.CreateTable(ctx, &baccaratv1.CreateTableRequest{MaxPlayers: 1})
+	tables.JoinTable(ctx, &baccaratv1.JoinTableRequest{TableId: created.TableId})
+	for i := 0; i < 2; i++ {
+		resp, err := tables.PlaceBet(ctx, &baccaratv1.PlaceBetRequest{TableId: created.TableId, Bets: map[string]int64{"B": 10, "D": 10}})
+		if err != nil || !resp.Success {
+			t.Fatalf("PlaceBet = %v, %v", resp, err)
+		}
+		r := resp.Result
+		if r.Outcome != string(rules.OutcomeDragon7) || !slices.Equal(r.BankerCards, []string{"2♥", "K♣", "5♥"}) {
+			t.Errorf("hand %d: %s with banker %v, want the preset Dragon 7", i, r.Outcome, r.BankerCards)
+		}
+		// The Banker bet pushes and Dragon 7 pays 40 to 1.
+		if r.TotalPayout != 10+410 {
+			t.Errorf("hand %d: TotalPayout = %d, want 420", i, r.TotalPayout)
+		}
+	}
+}
+
+func TestTablesSurviveRestart(t *testing.T) {
+	st := storage.NewFiles(t.TempDir())
+	cfg := testConfig()
+	lobby, tables := newTestClientsWithStore(t, cfg, st)
+	ctx := asPlayer("alice")
+
+	created, _ := lobby.CreateTable(ctx, &baccaratv1.CreateTableRequest{MaxPlayers: 3})
+	tables.JoinTable(ctx, &baccaratv1.JoinTableRequest{TableId: created.TableId})
+	resp, err := tables.PlaceBet(ctx, &baccaratv1.PlaceBetRequest{TableId: created.TableId, Bets: map[string]int64{"P": 50}})
+	if err != nil || !resp.Success {
+		t.Fatalf("PlaceBet = %v, %v", resp, err)
+	}
+
+	// A second server on the same store reopens the table with alice seated.
+	lobby, tables = newTestClientsWithStore(t, cfg, st)
+	state, err := tables.GetTableState(ctx, &baccaratv1.GetTableStateRequest{TableId: created.TableId})
+	if err != nil {
+		t.Fatalf("GetTableState after restart: %v", err)
+	}
+	if len(state.Players) != 1 || state.Players[0].PlayerName != "alice" || state.Players[0].Balance != resp.Result.NewBalance {
+		t.Errorf("restored players = %v, want alice with $%d", state.Players, resp.Result.NewBalance)
+	}
+	if next, _ := lobby.CreateTable(ctx, &baccaratv1.CreateTableRequest{}); next.GetTableId() == created.TableId {
+		t.Errorf("new table reused the ID %s", created.TableId)
+	}
+
+	rounds, err := st.LoadRounds()
+	if err != nil || len(rounds) != 1 || rounds[0].Player != "alice" {
+		t.Errorf("LoadRounds = %v, %v", rounds, err)
+	}
+}
+
+func TestSubscribeTable(t *testing.T) {
+	cfg := testConfig()
+	cards, err := model.ParseCards("10S 2H 6D KC 5H")
+	if err != nil {
+		t.Fatalf("ParseCards: %v", err)
+	}
+	cfg.PresetShoe = cards
+	lobby, tables := newTestClientsWithConfig(t, cfg)
+	ctx, cancel := context.WithCancel(asPlayer("alice"))
+	defer cancel()
+
+	created, _ := lobby.CreateTable(ctx, &baccaratv1.CreateTableRequest{})
+	// The table's first event brings out the shoe; replay everything after it.
+	stream, err := tables.SubscribeTable(ctx, &baccaratv1.SubscribeTableRequest{TableId: created.TableId, AfterSeq: proto.Uint64(1)})
+	if err != nil {
+		t.Fatalf("SubscribeTable: %v", err)
+	}
+	tables.JoinTable(ctx, &baccaratv1.JoinTableRequest{TableId: created.TableId})
+	if resp, err := tables.PlaceBet(ctx, &baccaratv1.PlaceBetRequest{TableId: created.TableId, Bets: map[string]int64{"B": 10, "D": 10}}); err != nil || !resp.Success {
+		t.Fatalf("PlaceBet = %v, %v", resp, err)
+	}
+
+	var events []*baccaratv1.TableEvent
+	var cardsDealt []string
+	var reasons []string
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		events = append(events, e)
+		if e.Seq != uint64(len(events)+1) || e.TableId != created.TableId {
+			t.Fatalf("event %d = %v", len(events), e)
+		}
+		switch ev := e.Event.(type) {
+		case *baccaratv1.TableEvent_Seat:
+			if ev.Seat.PlayerName != "alice" || !ev.Seat.Joined {
+				t.Errorf("seat event = %v", ev.Seat)
+			}
+		case *baccaratv1.TableEvent_Card:
+			cardsDealt = append(cardsDealt, ev.Card.Card)
+		case *baccaratv1.TableEvent_Decision:
+			reasons = append(reasons, ev.Decision.Reason)
+		case *baccaratv1.TableEvent_Outcome:
+			if ev.Outcome.Outcome != string(rules.OutcomeDragon7) || ev.Outcome.BankerTotal != 7 {
+				t.Errorf("outcome event = %v", ev.Outcome)
+			}
+		}
+		if s := e.GetSettlement(); s != nil {
+			if s.PlayerName != "alice" || s.TotalPayout != 420 || s.NetChange != 400 || s.NewBalance != 1400 {
+				t.Errorf("settlement event = %v", s)
+			}
+			break
+		}
+	}
+	if want := []string{"10♠", "2♥", "6♦", "K♣", "5♥"}; !slices.Equal(cardsDealt, want) {
+		t.Errorf("cards = %v, want %v", cardsDealt, want)
+	}
+	if len(reasons) != 2 || reasons[1] != "Banker hits on 2 (Player stood)" {
+		t.Errorf("decision reasons = %q", reasons)
+	}
+
+	// A reconnecting client resumes after the last event it received.
+	from := events[len(events)-3].Seq
+	resumed, err := tables.SubscribeTable(ctx, &baccaratv1.SubscribeTableRequest{TableId: created.TableId, AfterSeq: proto.Uint64(from)})
+	if err != nil {
+		t.Fatalf("SubscribeTable resume: %v", err)
+	}
+	if e, err := resumed.Recv(); err != nil || e.Seq != from+1 {
+		t.Errorf("first resumed event = %v, %v, want seq %d", e, err, from+1)
+	}
+	// after_seq 0 replays from the table's first event.
+	replayed, err := tables.SubscribeTable(ctx, &baccaratv1.SubscribeTableRequest{TableId: created.TableId, AfterSeq: proto.Uint64(0)})
+	if err != nil {
+		t.Fatalf("SubscribeTable from 0: %v", err)
+	}
+	if e, err := replayed.Recv(); err != nil || e.Seq != 1 || e.GetNewShoe() == nil {
+		t.Errorf("first replayed event = %v, %v, want the shoe at seq 1", e, err)
+	}
+
+	expired, _ := tables.SubscribeTable(ctx, &baccaratv1.SubscribeTableRequest{TableId: created.TableId, AfterSeq: proto.Uint64(1 << 40)})
+	if _, err := expired.Recv(); status.Code(err) != codes.OutOfRange {
+		t.Errorf("resuming after an unknown seq: %v, want OutOfRange", err)
+	}
+	missing, _ := tables.SubscribeTable(ctx, &baccaratv1.SubscribeTableRequest{TableId: "nope"})
+	if _, err := missing.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("subscribing to a missing table: %v, want NotFound", err)
+	}
+}
+
+func TestProvablyFairShoes(t *testing.T) {
+	cfg := testConfig()
+	cfg.ProvablyFair = true
+	// One deck with the cut card 40 cards from the end ends a shoe every few hands.
+	cfg.DecksCount, cfg.CutCardThreshold, cfg.Burn = 1, 40, model.BurnRule{Mode: model.BurnNone}
+	lobby, tables := newTestClientsWithConfig(t, cfg)
+	ctx, cancel := context.WithCancel(asPlayer("alice"))
+	defer cancel()
+
+	created, _ := lobby.CreateTable(ctx, &baccaratv1.CreateTableRequest{})
+	id := created.TableId
+	state, err := tables.GetTableState(ctx, &baccaratv1.GetTableStateRequest{TableId: id})
+	if err != nil {
+		t.Fatalf("GetTableState: %v", err)
+	}
+	first := state.ShoeCommitment
+	if first == nil || first.ShoeId != state.ShoeId || len(first.ServerSeedHash) != 64 || first.DecksCount != 1 || first.CutCardThreshold != 40 || first.BurnRule != "none" {
+		t.Fatalf("shoe %s commitment = %v", state.ShoeId, first)
+	}
+
+	if _, err := tables.SetClientSeed(ctx, &baccaratv1.SetClientSeedRequest{TableId: id, ClientSeed: "lucky"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("SetClientSeed before joining: %v, want FailedPrecondition", err)
+	}
+	tables.JoinTable(ctx, &baccaratv1.JoinTableRequest{TableId: id})
+	if _, err := tables.SetClientSeed(ctx, &baccaratv1.SetClientSeedRequest{TableId: id}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SetClientSeed without a seed: %v, want InvalidArgument", err)
+	}
+	if _, err := tables.SetClientSeed(ctx, &baccaratv1.SetClientSeedRequest{TableId: id, ClientSeed: "lucky"}); err != nil {
+		t.Fatalf("SetClientSeed: %v", err)
+	}
+
+	// The shoe's events replay after the table's first one, which brought it out.
+	stream, err := tables.SubscribeTable(ctx, &baccaratv1.SubscribeTableRequest{TableId: id, AfterSeq: proto.Uint64(1)})
+	if err != nil {
+		t.Fatalf("SubscribeTable: %v", err)
+	}
+	for i := 0; ; i++ {
+		if i == 10 {
+			t.Fatal("the first shoe did not end in 10 hands")
+		}
+		if resp, err := tables.PlaceBet(ctx, &baccaratv1.PlaceBetRequest{TableId: id, Bets: map[string]int64{"P": 10}}); err != nil || !resp.Success {
+			t.Fatalf("PlaceBet = %v, %v", resp, err)
+		}
+		if state, _ = tables.GetTableState(ctx, &baccaratv1.GetTableStateRequest{TableId: id}); state.ShoeId != first.ShoeId {
+			break
+		}
+	}
+
+	var reveal *baccaratv1.ShoeRevealEvent
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if r := e.GetShoeReveal(); r != nil {
+			reveal = r
+		}
+		if n := e.GetNewShoe(); n != nil {
+			if reveal == nil {
+				t.Fatal("the new shoe was announced before the old one was revealed")
+			}
+			if n.ShoeId != state.ShoeId || !proto.Equal(n.Commitment, state.ShoeCommitment) || n.Commitment.ClientSeed != "lucky" {
+				t.Errorf("new shoe event = %v, want shoe %s committed with client seed lucky", n, state.ShoeId)
+			}
+			break
+		}
+	}
+	if !proto.Equal(reveal.Commitment, first) || fair.Hash(reveal.ServerSeed) != first.ServerSeedHash {
+		t.Errorf("reveal = %v, want the server seed of %v", reveal, first)
+	}
+
+	// A server without provably-fair shoes has no client seed to set.
+	lobby, tables = newTestClientsWithStore(t, testConfig(), storage.NewFiles(t.TempDir()))
+	created, _ = lobby.CreateTable(ctx, &baccaratv1.CreateTableRequest{})
+	tables.JoinTable(ctx, &baccaratv1.JoinTableRequest{TableId: created.TableId})
+	if _, err := tables.SetClientSeed(ctx, &baccaratv1.SetClientSeedRequest{TableId: created.TableId, ClientSeed: "lucky"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("SetClientSeed without provably-fair shoes: %v, want FailedPrecondition", err)
+	}
+	if state, _ := tables.GetTableState(ctx, &baccaratv1.GetTableStateRequest{TableId: created.TableId}); state.GetShoeCommitment() != nil {
+		t.Errorf("commitment without provably-fair shoes = %v", state.ShoeCommitment)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := testConfig()
+	srv, err := New(cfg, storage.NewFiles(cfg.Storage.DataDir), 1000)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(srv.Close)
+	issuer, err := auth.NewHS256([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewHS256: %v", err)
+	}
+	idp := auth.NewFakeProvider("dev")
+	srv.EnableAuth(issuer, idp)
+	conn := dial(t, srv)
+	login := baccaratv1.NewAuthServiceClient(conn)
+	lobby, tables := baccaratv1.NewLobbyServiceClient(conn), baccaratv1.NewTableServiceClient(conn)
+	ctx := context.Background()
+
+	// The first login registers the player the provider suggests.
+	cred := idp.Issue("sub-1", "alice")
+	first, err := login.Login(ctx, &baccaratv1.LoginRequest{Provider: "dev", Credential: cred})
+	if err != nil || !first.NewPlayer || first.PlayerName != "alice" || first.Balance != 1000 || first.AccessToken == "" {
+		t.Fatalf("first Login = %v, %v", first, err)
+	}
+	if d := time.Until(time.UnixMilli(first.ExpiresAtUnixMs)); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("token expires in %v", d)
+	}
+	again, err := login.Login(ctx, &baccaratv1.LoginRequest{Provider: "dev", Credential: cred, PlayerName: "ignored"})
+	if err != nil || again.NewPlayer || again.PlayerName != "alice" {
+		t.Errorf("second Login = %v, %v", again, err)
+	}
+
+	// Another identity cannot take alice's name.
+	other := idp.Issue("sub-2", "alice")
+	if _, err := login.Login(ctx, &baccaratv1.LoginRequest{Provider: "dev", Credential: other}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Login with a taken name: %v, want AlreadyExists", err)
+	}
+	if _, err := login.Login(ctx, &baccaratv1.LoginRequest{Provider: "dev", Credential: other, PlayerName: "../bob"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Login with an invalid name: %v, want InvalidArgument", err)
+	}
+	if bob, err := login.Login(ctx, &baccaratv1.LoginRequest{Provider: "dev", Credential: other, PlayerName: "bob"}); err != nil || bob.PlayerName != "bob" {
+		t.Errorf("Login choosing a name = %v, %v", bob, err)
+	}
+
+	for _, req := range []struct {
+		req  *baccaratv1.LoginRequest
+		code codes.Code
+	}{
+		{&baccaratv1.LoginRequest{Provider: "dev", Credential: "forged"}, codes.Unauthenticated},
+		{&baccaratv1.LoginRequest{Provider: "github", Credential: cred}, codes.InvalidArgument},
+	} {
+		if _, err := login.Login(ctx, req.req); status.Code(err) != req.code {
+			t.Errorf("Login(%v): %v, want %s", req.req, err, req.code)
+		}
+	}
+
+	// Calls identify the player by the token alone.
+	authed := auth.BearerToken(ctx, first.AccessToken)
+	created, err := lobby.CreateTable(authed, &baccaratv1.CreateTableRequest{})
+	if err != nil {
+		t.Fatalf("CreateTable: %v", err)
+	}
+	join, err := tables.JoinTable(authed, &baccaratv1.JoinTableRequest{TableId: created.TableId})
+	if err != nil || !join.Success {
+		t.Fatalf("JoinTable with a token = %v, %v", join, err)
+	}
+	if st, _ := tables.GetTableState(authed, &baccaratv1.GetTableStateRequest{TableId: created.TableId}); len(st.GetPlayers()) != 1 || st.Players[0].PlayerName != "alice" {
+		t.Errorf("players = %v", st.GetPlayers())
+	}
+	if _, err := tables.JoinTable(asPlayer("mallory"), &baccaratv1.JoinTableRequest{TableId: created.TableId}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("JoinTable naming a player without a token: %v, want Unauthenticated", err)
+	}
+	if _, err := tables.JoinTable(auth.BearerToken(ctx, first.AccessToken+"x"), &baccaratv1.JoinTableRequest{TableId: created.TableId}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("JoinTable with a bad token: %v, want Unauthenticated", err)
+	}
+
+	// Streams are authenticated too.
+	stream, err := tables.SubscribeTable(auth.BearerToken(ctx, "nonsense"), &baccaratv1.SubscribeTableRequest{TableId: created.TableId})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("SubscribeTable with a bad token: %v, want Unauthenticated", err)
+	}
+}
+
+// failingLinks is a store that cannot link identities until fixed.
+type failingLinks struct {
+	storage.Store
+	broken bool
+}
+
+func (f *failingLinks) LinkIdentity(provider, subject, username string) error {
+	if f.broken {
+		return errors.New("disk full")
+	}
+	return f.Store.LinkIdentity(provider, subject, username)
+}
+
+func TestLoginLinkFailure(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := testConfig()
+	st := &failingLinks{Store: storage.NewFiles(cfg.Storage.DataDir), broken: true}
+	srv, err := New(cfg, st, 1000)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(srv.Close)
+	issuer, err := auth.NewHS256([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewHS256: %v", err)
+	}
+	idp := auth.NewFakeProvider("dev")
+	srv.EnableAuth(issuer, idp)
+	login := baccaratv1.NewAuthServiceClient(dial(t, srv))
+	ctx := context.Background()
+
+	req := &baccaratv1.LoginRequest{Provider: "dev", Credential: idp.Issue("sub-1", "alice")}
+	if _, err := login.Login(ctx, req); status.Code(err) != codes.Internal {
+		t.Fatalf("Login with a failing store: %v, want Internal", err)
+	}
+	if _, err := player.NewAccounts(st).Load("alice"); !errors.Is(err, player.ErrPlayerNotFound) {
+		t.Errorf("Load after a failed link: %v, want ErrPlayerNotFound", err)
+	}
+
+	// Once the store recovers, the same login registers alice.
+	st.broken = false
+	resp, err := login.Login(ctx, req)
+	if err != nil || !resp.NewPlayer || resp.PlayerName != "alice" {
+		t.Errorf("Login retry = %v, %v", resp, err)
+	}
+}
+
+func TestLoginWithoutAuth(t *testing.T) {
+	srv, _ := New(testConfig(), storage.NewFiles(t.TempDir()), 1000)
+	t.Cleanup(srv.Close)
+	login := baccaratv1.NewAuthServiceClient(dial(t, srv))
+	if _, err := login.Login(context.Background(), &baccaratv1.LoginRequest{Provider: "dev"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Login on a server without auth: %v, want Unimplemented", err)
+	}
+}
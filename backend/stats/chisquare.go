@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrMismatchedLengths = errors.New("observed and expected counts differ in length")
+
+// ChiSquare returns Pearson's chi-square statistic for observed counts against
+// expected counts. Cells with a zero expectation are skipped.
+func ChiSquare(observed []int, expected []float64) (float64, error) {
+	if len(observed) != len(expected) {
+		return 0, ErrMismatchedLengths
+	}
+	stat := 0.0
+	for i, o := range observed {
+		if expected[i] <= 0 {
+			continue
+		}
+		d := float64(o) - expected[i]
+		stat += d * d / expected[i]
+	}
+	return stat, nil
+}
+
+// ChiSquarePValue returns the probability that a chi-square distributed variable
+// with df degrees of freedom is at least stat (the upper tail).
+func ChiSquarePValue(stat float64, df int) float64 {
+	if df <= 0 {
+		return math.NaN()
+	}
+	if stat <= 0 {
+		return 1
+	}
+	return upperIncompleteGamma(float64(df)/2, stat/2)
+}
+
+const (
+	gammaMaxIter = 100000
+	gammaEpsilon = 1e-14
+)
+
+// upperIncompleteGamma computes the regularized upper incomplete gamma function Q(a, x).
+// It uses the series expansion below a+1 and the continued fraction above it.
+func upperIncompleteGamma(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lg)
+
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < gammaMaxIter; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*gammaEpsilon {
+				break
+			}
+		}
+		return math.Max(0, 1-sum*prefix)
+	}
+
+	// Lentz's method for the continued fraction.
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < gammaMaxIter; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < gammaEpsilon {
+			break
+		}
+	}
+	return prefix * h
+}
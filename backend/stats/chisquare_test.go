@@ -0,0 +1,45 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChiSquare(t *testing.T) {
+	stat, err := ChiSquare([]int{10, 20, 30}, []float64{20, 20, 20})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stat != 10 {
+		t.Errorf("ChiSquare = %v, want 10", stat)
+	}
+
+	if _, err := ChiSquare([]int{1}, []float64{1, 2}); err != ErrMismatchedLengths {
+		t.Errorf("Expected ErrMismatchedLengths, got %v", err)
+	}
+}
+
+func TestChiSquarePValue(t *testing.T) {
+	tests := []struct {
+		stat float64
+		df   int
+		want float64
+	}{
+		{3.841459, 1, 0.05},
+		{6.634897, 1, 0.01},
+		{18.307038, 10, 0.05},
+		{2.0, 2, math.Exp(-1)}, // df=2 is exponential: Q = e^(-x/2)
+		{124.342113, 100, 0.05},
+		{2771.0, 2652, 0.0527},
+	}
+	for _, tt := range tests {
+		got := ChiSquarePValue(tt.stat, tt.df)
+		if math.Abs(got-tt.want) > 5e-4 {
+			t.Errorf("ChiSquarePValue(%v, %d) = %v, want %v", tt.stat, tt.df, got, tt.want)
+		}
+	}
+
+	if got := ChiSquarePValue(0, 5); got != 1 {
+		t.Errorf("ChiSquarePValue(0, 5) = %v, want 1", got)
+	}
+}
@@ -0,0 +1,62 @@
+package stats
+
+import "math"
+
+// Histogram counts occurrences of small non-negative integers: h[v] is the
+// number of times v was seen.
+type Histogram []int
+
+// Add records n occurrences of v, growing the histogram as needed.
+func (h *Histogram) Add(v, n int) {
+	if v >= len(*h) {
+		*h = append(*h, make([]int, v+1-len(*h))...)
+	}
+	(*h)[v] += n
+}
+
+// Merge adds the counts of o to h.
+func (h *Histogram) Merge(o Histogram) {
+	for v, n := range o {
+		if n != 0 {
+			h.Add(v, n)
+		}
+	}
+}
+
+// Total returns the number of values recorded.
+func (h Histogram) Total() int {
+	total := 0
+	for _, n := range h {
+		total += n
+	}
+	return total
+}
+
+// Mean returns the mean of the recorded values, or NaN if there are none.
+func (h Histogram) Mean() float64 {
+	sum, total := 0, 0
+	for v, n := range h {
+		sum += v * n
+		total += n
+	}
+	if total == 0 {
+		return math.NaN()
+	}
+	return float64(sum) / float64(total)
+}
+
+// Range returns the smallest and largest recorded values, or (0, -1) if there
+// are none.
+func (h Histogram) Range() (lo, hi int) {
+	lo, hi = 0, -1
+	for v, n := range h {
+		if n == 0 {
+			continue
+		}
+		if hi < 0 {
+			lo = v
+		}
+		hi = v
+	}
+	return lo, hi
+}
@@ -0,0 +1,30 @@
+package stats
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	var h Histogram
+	if lo, hi := h.Range(); lo != 0 || hi != -1 || !math.IsNaN(h.Mean()) {
+		t.Errorf("empty histogram: range %d..%d, mean %v", lo, hi, h.Mean())
+	}
+
+	h.Add(3, 2)
+	h.Add(1, 1)
+	h.Merge(Histogram{0, 0, 0, 1, 0, 4})
+	if !reflect.DeepEqual(h, Histogram{0, 1, 0, 3, 0, 4}) {
+		t.Fatalf("histogram = %v", h)
+	}
+	if h.Total() != 8 {
+		t.Errorf("Total = %d, want 8", h.Total())
+	}
+	if got, want := h.Mean(), (1+3*3+5*4)/8.0; got != want {
+		t.Errorf("Mean = %v, want %v", got, want)
+	}
+	if lo, hi := h.Range(); lo != 1 || hi != 5 {
+		t.Errorf("Range = %d..%d, want 1..5", lo, hi)
+	}
+}
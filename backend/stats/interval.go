@@ -0,0 +1,33 @@
+package stats
+
+import "math"
+
+// Z95 is the standard normal quantile of a two-sided 95% confidence interval.
+const Z95 = 1.959963984540054
+
+// ProportionStdErr returns the standard error of the proportion count/n.
+func ProportionStdErr(count, n int) float64 {
+	if n <= 0 {
+		return math.NaN()
+	}
+	p := float64(count) / float64(n)
+	return math.Sqrt(p * (1 - p) / float64(n))
+}
+
+// MeanStdErr returns the standard error of the mean of n samples, given their
+// sum and sum of squares.
+func MeanStdErr(sum, sumSq float64, n int) float64 {
+	if n <= 1 {
+		return math.NaN()
+	}
+	mean := sum / float64(n)
+	// Sample variance with Bessel's correction, clamped against rounding.
+	variance := max((sumSq-mean*sum)/float64(n-1), 0)
+	return math.Sqrt(variance / float64(n))
+}
+
+// Interval95 returns the normal-approximation 95% confidence interval around
+// an estimate with the given standard error.
+func Interval95(estimate, stdErr float64) (low, high float64) {
+	return estimate - Z95*stdErr, estimate + Z95*stdErr
+}
@@ -0,0 +1,33 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProportionStdErr(t *testing.T) {
+	if got := ProportionStdErr(25, 100); math.Abs(got-math.Sqrt(0.25*0.75/100)) > 1e-12 {
+		t.Errorf("ProportionStdErr(25, 100) = %v", got)
+	}
+	if got := ProportionStdErr(0, 0); !math.IsNaN(got) {
+		t.Errorf("ProportionStdErr(0, 0) = %v, want NaN", got)
+	}
+}
+
+func TestMeanStdErr(t *testing.T) {
+	// Samples 1, 2, 3, 4: mean 2.5, sample variance 5/3.
+	got := MeanStdErr(10, 30, 4)
+	if want := math.Sqrt(5.0 / 3 / 4); math.Abs(got-want) > 1e-12 {
+		t.Errorf("MeanStdErr = %v, want %v", got, want)
+	}
+	if got := MeanStdErr(5, 25, 1); !math.IsNaN(got) {
+		t.Errorf("MeanStdErr of one sample = %v, want NaN", got)
+	}
+}
+
+func TestInterval95(t *testing.T) {
+	low, high := Interval95(0.5, 0.1)
+	if math.Abs(low-(0.5-0.1959963984540054)) > 1e-12 || math.Abs(high-(0.5+0.1959963984540054)) > 1e-12 {
+		t.Errorf("Interval95 = [%v, %v]", low, high)
+	}
+}
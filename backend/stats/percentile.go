@@ -0,0 +1,13 @@
+package stats
+
+import "math"
+
+// Percentile returns the p-th percentile (0-100) of sorted values using the
+// nearest-rank method. It returns 0 for an empty slice.
+func Percentile(sorted []int, p float64) int {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
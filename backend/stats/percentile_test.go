@@ -0,0 +1,21 @@
+package stats
+
+import "testing"
+
+func TestPercentile(t *testing.T) {
+	values := []int{15, 20, 35, 40, 50}
+	tests := []struct {
+		p    float64
+		want int
+	}{
+		{0, 15}, {5, 15}, {30, 20}, {40, 20}, {50, 35}, {100, 50},
+	}
+	for _, tt := range tests {
+		if got := Percentile(values, tt.p); got != tt.want {
+			t.Errorf("Percentile(%v) = %d, want %d", tt.p, got, tt.want)
+		}
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("Percentile(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/niubaoshu/es-Baccarat/backend/auth"
+	"github.com/niubaoshu/es-Baccarat/backend/engine"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+)
+
+// Files keeps everything under one directory: profiles and ledgers as the
+// player package lays them out, the round history in logs/, the tables in
+// tables.json and the identities linked to players in identities.json.
+type Files struct {
+	*player.FileBackend
+	rounds engine.FileRoundStore
+
+	mu             sync.Mutex // guards tables.json and identities.json
+	tablesPath     string
+	identitiesPath string
+}
+
+// NewFiles returns a store keeping its files under dir.
+func NewFiles(dir string) *Files {
+	return &Files{
+		FileBackend:    player.NewFileBackend(dir),
+		rounds:         engine.FileRoundStore{Dir: filepath.Join(dir, "logs")},
+		tablesPath:     filepath.Join(dir, "tables.json"),
+		identitiesPath: filepath.Join(dir, "identities.json"),
+	}
+}
+
+// LogRound appends a round to logs/game_history.jsonl.
+func (f *Files) LogRound(r engine.RoundLog) error {
+	return f.rounds.LogRound(r)
+}
+
+// LoadRounds reads logs/game_history.jsonl.
+func (f *Files) LoadRounds() ([]engine.RoundLog, error) {
+	return f.rounds.LoadRounds()
+}
+
+// SaveTable rewrites tables.json with t added or replaced.
+func (f *Files) SaveTable(t TableRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tables, err := f.readTables()
+	if err != nil {
+		return err
+	}
+	tables[t.ID] = t
+	return f.writeTables(tables)
+}
+
+// DeleteTable rewrites tables.json without the table.
+func (f *Files) DeleteTable(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tables, err := f.readTables()
+	if err != nil {
+		return err
+	}
+	if _, ok := tables[id]; !ok {
+		return nil
+	}
+	delete(tables, id)
+	return f.writeTables(tables)
+}
+
+// LoadTables reads tables.json.
+func (f *Files) LoadTables() ([]TableRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tables, err := f.readTables()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TableRecord, 0, len(tables))
+	for _, t := range tables {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// LookupIdentity reads the username linked to the subject from identities.json.
+func (f *Files) LookupIdentity(provider, subject string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	links, err := f.readIdentities()
+	if err != nil {
+		return "", err
+	}
+	for _, l := range links {
+		if l.Provider == provider && l.Subject == subject {
+			return l.Username, nil
+		}
+	}
+	return "", auth.ErrIdentityNotFound
+}
+
+// LinkIdentity rewrites identities.json with the link added.
+func (f *Files) LinkIdentity(provider, subject, username string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	links, err := f.readIdentities()
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		if l.Provider == provider && l.Subject == subject {
+			return auth.ErrIdentityLinked
+		}
+	}
+	links = append(links, identityLink{Provider: provider, Subject: subject, Username: username})
+	data, err := json.MarshalIndent(links, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(f.identitiesPath, data)
+}
+
+// Close does nothing; every write is complete when it returns.
+func (f *Files) Close() error {
+	return nil
+}
+
+func (f *Files) readTables() (map[string]TableRecord, error) {
+	tables := make(map[string]TableRecord)
+	data, err := os.ReadFile(f.tablesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return tables, nil
+		}
+		return nil, err
+	}
+	var list []TableRecord
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	for _, t := range list {
+		if t.Seats == nil {
+			t.Seats = make(map[int]string)
+		}
+		tables[t.ID] = t
+	}
+	return tables, nil
+}
+
+// identityLink is an entry of identities.json.
+type identityLink struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	Username string `json:"username"`
+}
+
+func (f *Files) readIdentities() ([]identityLink, error) {
+	data, err := os.ReadFile(f.identitiesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var links []identityLink
+	if err := json.Unmarshal(data, &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (f *Files) writeTables(tables map[string]TableRecord) error {
+	list := make([]TableRecord, 0, len(tables))
+	for _, t := range tables {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(f.tablesPath, data)
+}
+
+// writeFile replaces path through a synced temporary file, like the player
+// package does for profile snapshots.
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
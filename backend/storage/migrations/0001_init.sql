@@ -0,0 +1,66 @@
+-- Players and their append-only ledgers. users holds the profile snapshot;
+-- transactions is the ledger it is derived from.
+CREATE TABLE users (
+    username     TEXT PRIMARY KEY,
+    balance      BIGINT NOT NULL,
+    hands_played INTEGER NOT NULL DEFAULT 0,
+    total_wager  BIGINT NOT NULL DEFAULT 0,
+    ledger_seq   BIGINT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE transactions (
+    username     TEXT NOT NULL REFERENCES users (username),
+    seq          BIGINT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    kind         TEXT NOT NULL CHECK (kind IN ('open', 'debit', 'credit')),
+    round_id     TEXT,
+    bet_type     TEXT,
+    amount       BIGINT NOT NULL,
+    balance      BIGINT NOT NULL,
+    hands_played INTEGER NOT NULL DEFAULT 0,
+    total_wager  BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (username, seq)
+);
+
+CREATE INDEX transactions_round_idx ON transactions (round_id);
+
+-- Round history, one row per hand dealt to a player, with its bets.
+CREATE TABLE rounds (
+    id              BIGSERIAL PRIMARY KEY,
+    round_id        TEXT,
+    shoe_id         TEXT,
+    username        TEXT NOT NULL REFERENCES users (username),
+    played_at       TIMESTAMPTZ NOT NULL,
+    initial_balance BIGINT NOT NULL,
+    final_balance   BIGINT NOT NULL,
+    player_hand     TEXT[] NOT NULL,
+    banker_hand     TEXT[] NOT NULL,
+    player_points   SMALLINT NOT NULL,
+    banker_points   SMALLINT NOT NULL,
+    outcome         TEXT NOT NULL,
+    net_change      BIGINT NOT NULL
+);
+
+CREATE INDEX rounds_username_idx ON rounds (username, id);
+
+CREATE TABLE bets (
+    round    BIGINT NOT NULL REFERENCES rounds (id) ON DELETE CASCADE,
+    bet_type TEXT NOT NULL,
+    amount   BIGINT NOT NULL,
+    PRIMARY KEY (round, bet_type)
+);
+
+-- Lobby tables and their seats.
+CREATE TABLE game_tables (
+    id          TEXT PRIMARY KEY,
+    max_players INTEGER NOT NULL CHECK (max_players > 0)
+);
+
+CREATE TABLE table_seats (
+    table_id TEXT NOT NULL REFERENCES game_tables (id) ON DELETE CASCADE,
+    seat     INTEGER NOT NULL,
+    username TEXT NOT NULL REFERENCES users (username),
+    PRIMARY KEY (table_id, seat)
+);
@@ -0,0 +1,9 @@
+-- External identities (e.g. a Google account) linked to the players they log
+-- in as.
+CREATE TABLE identities (
+    provider   TEXT NOT NULL,
+    subject    TEXT NOT NULL,
+    username   TEXT NOT NULL REFERENCES users (username),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, subject)
+);
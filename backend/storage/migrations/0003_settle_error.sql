@@ -0,0 +1,3 @@
+-- Hands dealt to a player whose bets could not be settled are still logged,
+-- so the shoe can be replayed, with the reason they were not settled.
+ALTER TABLE rounds ADD COLUMN settle_error TEXT;
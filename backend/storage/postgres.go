@@ -0,0 +1,395 @@
+package storage
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/niubaoshu/es-Baccarat/backend/auth"
+	"github.com/niubaoshu/es-Baccarat/backend/engine"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// migrationLock is the advisory lock key that serializes migrations when
+// several servers start against the same database.
+const migrationLock = 0x65734261
+
+// Postgres keeps everything in a PostgreSQL database. A player's ledger
+// entries and snapshot are written in one transaction, so the snapshot never
+// lags the ledger.
+type Postgres struct {
+	pool *pgxpool.Pool
+}
+
+// OpenPostgres connects to the database at url and applies any migrations it
+// has not run yet.
+func OpenPostgres(ctx context.Context, url string) (*Postgres, error) {
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	if err := migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrating database: %w", err)
+	}
+	return &Postgres{pool: pool}, nil
+}
+
+// migrate runs the embedded migrations, in file name order, that are not yet
+// recorded in schema_migrations. Each run is one transaction.
+func migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLock); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    TEXT PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`); err != nil {
+			return err
+		}
+		for _, name := range names {
+			version := path.Base(name)
+			var applied bool
+			if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied); err != nil {
+				return err
+			}
+			if applied {
+				continue
+			}
+			script, err := migrations.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, string(script)); err != nil {
+				return fmt.Errorf("%s: %w", version, err)
+			}
+			if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close closes every connection to the database.
+func (s *Postgres) Close() error {
+	s.pool.Close()
+	return nil
+}
+
+// LoadSnapshot reads a player's row in users.
+func (s *Postgres) LoadSnapshot(username string) (*player.Profile, error) {
+	p := &player.Profile{Username: username}
+	err := s.pool.QueryRow(context.Background(),
+		"SELECT balance, hands_played, total_wager, ledger_seq FROM users WHERE username = $1", username).
+		Scan(&p.Balance, &p.HandsPlayed, &p.TotalWager, &p.LedgerSeq)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, player.ErrPlayerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SaveSnapshot writes a player's row in users.
+func (s *Postgres) SaveSnapshot(p *player.Profile) error {
+	_, err := s.pool.Exec(context.Background(), `
+		INSERT INTO users (username, balance, hands_played, total_wager, ledger_seq)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (username) DO UPDATE SET
+			balance = EXCLUDED.balance,
+			hands_played = EXCLUDED.hands_played,
+			total_wager = EXCLUDED.total_wager,
+			ledger_seq = EXCLUDED.ledger_seq,
+			updated_at = now()`,
+		p.Username, p.Balance, p.HandsPlayed, p.TotalWager, p.LedgerSeq)
+	return err
+}
+
+// LoadLedger reads a player's transactions, oldest first.
+func (s *Postgres) LoadLedger(username string) ([]player.LedgerEntry, error) {
+	rows, err := s.pool.Query(context.Background(), `
+		SELECT seq, created_at, kind, COALESCE(round_id, ''), COALESCE(bet_type, ''),
+			amount, balance, hands_played, total_wager
+		FROM transactions WHERE username = $1 ORDER BY seq`, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []player.LedgerEntry
+	for rows.Next() {
+		var e player.LedgerEntry
+		var kind string
+		if err := rows.Scan(&e.Seq, &e.Timestamp, &kind, &e.RoundID, &e.BetType,
+			&e.Amount, &e.Balance, &e.HandsPlayed, &e.TotalWager); err != nil {
+			return nil, err
+		}
+		e.Kind = player.EntryKind(kind)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Create inserts the user and its first transactions.
+func (s *Postgres) Create(p *player.Profile, entries []player.LedgerEntry) error {
+	ctx := context.Background()
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO users (username, balance, hands_played, total_wager, ledger_seq)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (username) DO NOTHING`,
+			p.Username, p.Balance, p.HandsPlayed, p.TotalWager, p.LedgerSeq)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return player.ErrPlayerAlreadyExists
+		}
+		return insertEntries(ctx, tx, p.Username, entries)
+	})
+}
+
+// Commit inserts the transactions and updates the user in one database
+// transaction. A concurrent writer that already used the same sequence
+// numbers makes it fail without changing anything.
+func (s *Postgres) Commit(p *player.Profile, entries []player.LedgerEntry) error {
+	ctx := context.Background()
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if err := insertEntries(ctx, tx, p.Username, entries); err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, `
+			UPDATE users SET balance = $2, hands_played = $3, total_wager = $4, ledger_seq = $5, updated_at = now()
+			WHERE username = $1`,
+			p.Username, p.Balance, p.HandsPlayed, p.TotalWager, p.LedgerSeq)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return player.ErrPlayerNotFound
+		}
+		return nil
+	})
+}
+
+// Delete deletes the user and its transactions in one database transaction.
+func (s *Postgres) Delete(username string) error {
+	ctx := context.Background()
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM transactions WHERE username = $1", username); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, "DELETE FROM users WHERE username = $1", username)
+		return err
+	})
+}
+
+func insertEntries(ctx context.Context, tx pgx.Tx, username string, entries []player.LedgerEntry) error {
+	batch := &pgx.Batch{}
+	for _, e := range entries {
+		batch.Queue(`
+			INSERT INTO transactions (username, seq, created_at, kind, round_id, bet_type, amount, balance, hands_played, total_wager)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			username, e.Seq, e.Timestamp, string(e.Kind), nullString(e.RoundID), nullString(e.BetType),
+			e.Amount, e.Balance, e.HandsPlayed, e.TotalWager)
+	}
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+// nullString maps the empty string to SQL NULL.
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// LogRound inserts a round and its bets.
+func (s *Postgres) LogRound(r engine.RoundLog) error {
+	ctx := context.Background()
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		var id int64
+		err := tx.QueryRow(ctx, `
+			INSERT INTO rounds (round_id, shoe_id, username, played_at, initial_balance, final_balance,
+				player_hand, banker_hand, player_points, banker_points, outcome, net_change, settle_error)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			RETURNING id`,
+			nullString(r.RoundID), nullString(r.ShoeID), r.Player, r.Timestamp, r.InitialBalance, r.FinalBalance,
+			r.PlayerHand, r.BankerHand, r.PlayerPoints, r.BankerPoints, r.Outcome, r.NetChange, nullString(r.SettleError)).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		batch := &pgx.Batch{}
+		for betType, amount := range r.Bets {
+			batch.Queue("INSERT INTO bets (round, bet_type, amount) VALUES ($1, $2, $3)", id, betType, amount)
+		}
+		return tx.SendBatch(ctx, batch).Close()
+	})
+}
+
+// LoadRounds reads every round with its bets, in the order they were logged.
+func (s *Postgres) LoadRounds() ([]engine.RoundLog, error) {
+	ctx := context.Background()
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, COALESCE(round_id, ''), COALESCE(shoe_id, ''), username, played_at,
+			initial_balance, final_balance, player_hand, banker_hand,
+			player_points, banker_points, outcome, net_change, COALESCE(settle_error, '')
+		FROM rounds ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rounds []engine.RoundLog
+	index := make(map[int64]int)
+	for rows.Next() {
+		var id int64
+		r := engine.RoundLog{Bets: make(map[string]int)}
+		if err := rows.Scan(&id, &r.RoundID, &r.ShoeID, &r.Player, &r.Timestamp,
+			&r.InitialBalance, &r.FinalBalance, &r.PlayerHand, &r.BankerHand,
+			&r.PlayerPoints, &r.BankerPoints, &r.Outcome, &r.NetChange, &r.SettleError); err != nil {
+			return nil, err
+		}
+		index[id] = len(rounds)
+		rounds = append(rounds, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	bets, err := s.pool.Query(ctx, "SELECT round, bet_type, amount FROM bets")
+	if err != nil {
+		return nil, err
+	}
+	defer bets.Close()
+	for bets.Next() {
+		var id int64
+		var betType string
+		var amount int
+		if err := bets.Scan(&id, &betType, &amount); err != nil {
+			return nil, err
+		}
+		if i, ok := index[id]; ok {
+			rounds[i].Bets[betType] = amount
+		}
+	}
+	return rounds, bets.Err()
+}
+
+// SaveTable replaces the table's row and seats.
+func (s *Postgres) SaveTable(t TableRecord) error {
+	ctx := context.Background()
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO game_tables (id, max_players) VALUES ($1, $2)
+			ON CONFLICT (id) DO UPDATE SET max_players = EXCLUDED.max_players`,
+			t.ID, t.MaxPlayers); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM table_seats WHERE table_id = $1", t.ID); err != nil {
+			return err
+		}
+		batch := &pgx.Batch{}
+		for seat, username := range t.Seats {
+			batch.Queue("INSERT INTO table_seats (table_id, seat, username) VALUES ($1, $2, $3)", t.ID, seat, username)
+		}
+		return tx.SendBatch(ctx, batch).Close()
+	})
+}
+
+// DeleteTable deletes the table and its seats.
+func (s *Postgres) DeleteTable(id string) error {
+	_, err := s.pool.Exec(context.Background(), "DELETE FROM game_tables WHERE id = $1", id)
+	return err
+}
+
+// LoadTables reads every table with its seats.
+func (s *Postgres) LoadTables() ([]TableRecord, error) {
+	ctx := context.Background()
+	rows, err := s.pool.Query(ctx, "SELECT id, max_players FROM game_tables ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableRecord
+	index := make(map[string]int)
+	for rows.Next() {
+		t := TableRecord{Seats: make(map[int]string)}
+		if err := rows.Scan(&t.ID, &t.MaxPlayers); err != nil {
+			return nil, err
+		}
+		index[t.ID] = len(tables)
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	seats, err := s.pool.Query(ctx, "SELECT table_id, seat, username FROM table_seats")
+	if err != nil {
+		return nil, err
+	}
+	defer seats.Close()
+	for seats.Next() {
+		var id, username string
+		var seat int
+		if err := seats.Scan(&id, &seat, &username); err != nil {
+			return nil, err
+		}
+		if i, ok := index[id]; ok {
+			tables[i].Seats[seat] = username
+		}
+	}
+	return tables, seats.Err()
+}
+
+// LookupIdentity reads the username linked to the subject.
+func (s *Postgres) LookupIdentity(provider, subject string) (string, error) {
+	var username string
+	err := s.pool.QueryRow(context.Background(),
+		"SELECT username FROM identities WHERE provider = $1 AND subject = $2", provider, subject).Scan(&username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", auth.ErrIdentityNotFound
+	}
+	return username, err
+}
+
+// LinkIdentity inserts the link.
+func (s *Postgres) LinkIdentity(provider, subject, username string) error {
+	tag, err := s.pool.Exec(context.Background(), `
+		INSERT INTO identities (provider, subject, username) VALUES ($1, $2, $3)
+		ON CONFLICT (provider, subject) DO NOTHING`,
+		provider, subject, username)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return auth.ErrIdentityLinked
+	}
+	return nil
+}
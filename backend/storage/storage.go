@@ -0,0 +1,61 @@
+// Package storage persists player accounts, round history, lobby tables and
+// the external identities linked to players, either in files or in a
+// PostgreSQL database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/niubaoshu/es-Baccarat/backend/auth"
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/engine"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+)
+
+// TableRecord is the persisted state of a lobby table: its size and who sits
+// where. Shoes are not persisted; a restored table is dealt a new shoe.
+type TableRecord struct {
+	ID         string         `json:"id"`
+	MaxPlayers int            `json:"max_players"`
+	Seats      map[int]string `json:"seats"` // seat number (1-based) -> username
+}
+
+// Store is a storage backend for everything the game persists.
+type Store interface {
+	player.Backend
+	engine.RoundStore
+	auth.Identities
+
+	// SaveTable stores t, replacing the table with the same ID.
+	SaveTable(t TableRecord) error
+	// DeleteTable removes the table with the given ID, if it exists.
+	DeleteTable(id string) error
+	// LoadTables returns every stored table, ordered by ID.
+	LoadTables() ([]TableRecord, error)
+
+	// Close releases the store's resources.
+	Close() error
+}
+
+var (
+	_ Store = (*Files)(nil)
+	_ Store = (*Postgres)(nil)
+)
+
+var ErrUnknownBackend = errors.New("unknown storage backend")
+
+// Open opens the store selected by cfg.
+func Open(ctx context.Context, cfg config.StorageConfig) (Store, error) {
+	switch cfg.Backend {
+	case config.StorageFile:
+		return NewFiles(cfg.DataDir), nil
+	case config.StoragePostgres:
+		if cfg.DatabaseURL == "" {
+			return nil, fmt.Errorf("the postgres storage backend needs a database URL")
+		}
+		return OpenPostgres(ctx, cfg.DatabaseURL)
+	}
+	return nil, fmt.Errorf("%w: %q (use %q or %q)", ErrUnknownBackend, cfg.Backend, config.StorageFile, config.StoragePostgres)
+}
@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/niubaoshu/es-Baccarat/backend/auth"
+	"github.com/niubaoshu/es-Baccarat/backend/config"
+	"github.com/niubaoshu/es-Baccarat/backend/engine"
+	"github.com/niubaoshu/es-Baccarat/backend/player"
+)
+
+func TestFiles(t *testing.T) {
+	testStore(t, NewFiles(t.TempDir()))
+}
+
+// TestPostgres runs the same checks against the database named by
+// ES_BACCARAT_TEST_DATABASE_URL, in a schema of its own that is dropped
+// afterwards.
+func TestPostgres(t *testing.T) {
+	url := os.Getenv("ES_BACCARAT_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("set ES_BACCARAT_TEST_DATABASE_URL to test against PostgreSQL")
+	}
+	ctx := context.Background()
+	admin, err := pgx.Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	schema := fmt.Sprintf("es_baccarat_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close(ctx)
+	})
+
+	url = withSearchPath(url, schema)
+	st, err := Open(ctx, config.StorageConfig{Backend: config.StoragePostgres, DatabaseURL: url})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer st.Close()
+	testStore(t, st)
+
+	// Migrations that already ran are skipped.
+	again, err := OpenPostgres(ctx, url)
+	if err != nil {
+		t.Fatalf("reopening a migrated database: %v", err)
+	}
+	again.Close()
+}
+
+// withSearchPath points the connections of url at schema.
+func withSearchPath(url, schema string) string {
+	if !strings.Contains(url, "://") {
+		return url + " search_path=" + schema
+	}
+	if strings.Contains(url, "?") {
+		return url + "&search_path=" + schema
+	}
+	return url + "?search_path=" + schema
+}
+
+func TestOpenUnknownBackend(t *testing.T) {
+	if _, err := Open(context.Background(), config.StorageConfig{Backend: "mysql"}); !errors.Is(err, ErrUnknownBackend) {
+		t.Errorf("Open(mysql) error = %v, want ErrUnknownBackend", err)
+	}
+}
+
+// testStore checks the behaviour every Store must share.
+func testStore(t *testing.T, st Store) {
+	t.Helper()
+	accounts := player.NewAccounts(st)
+
+	// Accounts.
+	p, err := accounts.Create("alice", 1000)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := accounts.Create("alice", 500); err != player.ErrPlayerAlreadyExists {
+		t.Errorf("second Create error = %v, want ErrPlayerAlreadyExists", err)
+	}
+	if _, err := accounts.Load("nobody"); err != player.ErrPlayerNotFound {
+		t.Errorf("Load of an unknown player error = %v, want ErrPlayerNotFound", err)
+	}
+	if err := p.Settle("shoe-1/1", []player.Wager{{BetType: "Player", Amount: 100, Returned: 200}}); err != nil {
+		t.Fatalf("Settle: %v", err)
+	}
+
+	loaded, err := accounts.Load("alice")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.Balance != 1100 || loaded.HandsPlayed != 1 || loaded.TotalWager != 100 || loaded.LedgerSeq != 3 {
+		t.Errorf("loaded profile = %+v", loaded)
+	}
+	entries, err := accounts.Ledger("alice")
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("Ledger = %v, %v", entries, err)
+	}
+	for i, kind := range []player.EntryKind{player.EntryOpen, player.EntryDebit, player.EntryCredit} {
+		if entries[i].Kind != kind || entries[i].Seq != int64(i+1) {
+			t.Errorf("entry %d = %+v, want %s #%d", i, entries[i], kind, i+1)
+		}
+	}
+	if e := entries[2]; e.RoundID != "shoe-1/1" || e.BetType != "Player" || e.Amount != 200 || e.Balance != 1100 {
+		t.Errorf("credit entry = %+v", e)
+	}
+	if rebuilt, err := accounts.Rebuild("alice"); err != nil || rebuilt.Balance != 1100 || rebuilt.HandsPlayed != 1 {
+		t.Errorf("Rebuild = %+v, %v", rebuilt, err)
+	}
+	if _, err := accounts.Create("carol", 1000); err != nil {
+		t.Fatalf("Create carol: %v", err)
+	}
+	if err := accounts.Delete("carol"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := accounts.Load("carol"); err != player.ErrPlayerNotFound {
+		t.Errorf("Load after Delete error = %v, want ErrPlayerNotFound", err)
+	}
+	if _, err := accounts.Create("carol", 500); err != nil {
+		t.Errorf("Create after Delete: %v", err)
+	}
+
+	// Round history.
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	want := []engine.RoundLog{
+		{
+			Timestamp: at, Player: "alice", InitialBalance: 1000, FinalBalance: 1100,
+			Bets:       map[string]int{"Player": 100},
+			PlayerHand: []string{"9♠", "K♥"}, BankerHand: []string{"2♦", "3♣", "A♠"},
+			PlayerPoints: 9, BankerPoints: 6, Outcome: "Player", NetChange: 100,
+			ShoeID: "shoe-1", RoundID: "shoe-1/1",
+		},
+		{
+			Timestamp: at.Add(time.Minute), Player: "alice", InitialBalance: 1100, FinalBalance: 1090,
+			Bets:       map[string]int{"Banker": 10, "Dragon": 5},
+			PlayerHand: []string{"5♠", "2♥"}, BankerHand: []string{"7♦", "Q♣"},
+			PlayerPoints: 7, BankerPoints: 7, Outcome: "Tie", NetChange: -5,
+		},
+		{
+			Timestamp: at.Add(2 * time.Minute), Player: "alice", InitialBalance: 1090, FinalBalance: 1090,
+			Bets:       map[string]int{"Player": 10},
+			PlayerHand: []string{"4♠", "4♥"}, BankerHand: []string{"6♦", "J♣"},
+			PlayerPoints: 8, BankerPoints: 6, Outcome: "Player",
+			ShoeID: "shoe-1", RoundID: "shoe-1/2", SettleError: "disk full",
+		},
+	}
+	for _, r := range want {
+		if err := st.LogRound(r); err != nil {
+			t.Fatalf("LogRound: %v", err)
+		}
+	}
+	rounds, err := st.LoadRounds()
+	if err != nil || len(rounds) != len(want) {
+		t.Fatalf("LoadRounds = %v, %v", rounds, err)
+	}
+	for i := range want {
+		if !rounds[i].Timestamp.Equal(want[i].Timestamp) {
+			t.Errorf("round %d timestamp = %v, want %v", i, rounds[i].Timestamp, want[i].Timestamp)
+		}
+		rounds[i].Timestamp = want[i].Timestamp
+		if !reflect.DeepEqual(rounds[i], want[i]) {
+			t.Errorf("round %d = %+v, want %+v", i, rounds[i], want[i])
+		}
+	}
+
+	// Tables.
+	if _, err := accounts.Create("bob", 1000); err != nil {
+		t.Fatalf("Create bob: %v", err)
+	}
+	for _, rec := range []TableRecord{
+		{ID: "table-2", MaxPlayers: 7, Seats: map[int]string{}},
+		{ID: "table-1", MaxPlayers: 3, Seats: map[int]string{1: "alice"}},
+		{ID: "table-1", MaxPlayers: 3, Seats: map[int]string{2: "alice", 3: "bob"}},
+	} {
+		if err := st.SaveTable(rec); err != nil {
+			t.Fatalf("SaveTable(%s): %v", rec.ID, err)
+		}
+	}
+	tables, err := st.LoadTables()
+	wantTables := []TableRecord{
+		{ID: "table-1", MaxPlayers: 3, Seats: map[int]string{2: "alice", 3: "bob"}},
+		{ID: "table-2", MaxPlayers: 7, Seats: map[int]string{}},
+	}
+	if err != nil || !reflect.DeepEqual(tables, wantTables) {
+		t.Errorf("LoadTables = %+v, %v, want %+v", tables, err, wantTables)
+	}
+	if err := st.DeleteTable("table-2"); err != nil {
+		t.Fatalf("DeleteTable: %v", err)
+	}
+	if err := st.DeleteTable("table-9"); err != nil {
+		t.Errorf("DeleteTable of a missing table: %v", err)
+	}
+	if tables, err := st.LoadTables(); err != nil || len(tables) != 1 || tables[0].ID != "table-1" {
+		t.Errorf("LoadTables after delete = %+v, %v", tables, err)
+	}
+
+	// Identities.
+	if _, err := st.LookupIdentity("google", "1234"); err != auth.ErrIdentityNotFound {
+		t.Errorf("LookupIdentity before linking: %v, want ErrIdentityNotFound", err)
+	}
+	if err := st.LinkIdentity("google", "1234", "alice"); err != nil {
+		t.Fatalf("LinkIdentity: %v", err)
+	}
+	if err := st.LinkIdentity("google", "1234", "bob"); err != auth.ErrIdentityLinked {
+		t.Errorf("second LinkIdentity: %v, want ErrIdentityLinked", err)
+	}
+	if err := st.LinkIdentity("apple", "1234", "bob"); err != nil {
+		t.Fatalf("LinkIdentity with another provider: %v", err)
+	}
+	for _, c := range []struct{ provider, want string }{{"google", "alice"}, {"apple", "bob"}} {
+		if name, err := st.LookupIdentity(c.provider, "1234"); err != nil || name != c.want {
+			t.Errorf("LookupIdentity(%s) = %q, %v, want %q", c.provider, name, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// CountSystem is a card-counting system for a side bet: each rank carries a tag,
+// the running count is the sum of the tags of the cards dealt, and the bet is
+// placed only when the true count (running count per deck left in the shoe)
+// reaches Trigger.
+type CountSystem struct {
+	Name    string
+	Bet     rules.BetType
+	Tags    [model.King + 1]int // indexed by model.Rank (index 0 is unused)
+	Trigger float64
+}
+
+// Dragon7Count is Eliot Jacobson's count for Dragon 7: removing 4s to 7s hurts
+// the bet and removing 8s and 9s helps it.
+var Dragon7Count = CountSystem{
+	Name:    "dragon7",
+	Bet:     rules.Dragon,
+	Tags:    tags(map[model.Rank]int{model.Four: -1, model.Five: -1, model.Six: -1, model.Seven: -1, model.Eight: 2, model.Nine: 2}),
+	Trigger: 4,
+}
+
+// Panda8Count is a balanced count for Panda 8 that follows the signs of the
+// bet's effects of removal: 3s, 4s, 5s and 8s count -1; Aces and 2s +1; 9s +2.
+var Panda8Count = CountSystem{
+	Name:    "panda8",
+	Bet:     rules.Panda,
+	Tags:    tags(map[model.Rank]int{model.Ace: 1, model.Two: 1, model.Three: -1, model.Four: -1, model.Five: -1, model.Eight: -1, model.Nine: 2}),
+	Trigger: 4,
+}
+
+var countSystems = []CountSystem{Dragon7Count, Panda8Count}
+
+func tags(m map[model.Rank]int) [model.King + 1]int {
+	var t [model.King + 1]int
+	for r, tag := range m {
+		t[r] = tag
+	}
+	return t
+}
+
+// CountNames lists the built-in count systems accepted by Count.
+func CountNames() []string {
+	names := make([]string, len(countSystems))
+	for i, c := range countSystems {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Count returns the named built-in count system (case-insensitive).
+func Count(name string) (CountSystem, error) {
+	for _, c := range countSystems {
+		if strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+			return c, nil
+		}
+	}
+	return CountSystem{}, fmt.Errorf("unknown count system %q (available: %s)", name, strings.Join(CountNames(), ", "))
+}
+
+// ParseCountTags parses rank tags such as "4:-1,5:-1,6:-1,7:-1,8:2,9:2". Ranks are
+// A, 2-10, J, Q and K; T is short for 10, J, Q and K together. Ranks that are not
+// listed are tagged 0.
+func ParseCountTags(s string) ([model.King + 1]int, error) {
+	var t [model.King + 1]int
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.Split(entry, ":")
+		if len(kv) != 2 {
+			return t, fmt.Errorf("invalid count tag %q, use <Rank>:<Tag>", entry)
+		}
+		tag, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return t, fmt.Errorf("invalid count tag %q", entry)
+		}
+		ranks, err := parseTagRanks(kv[0])
+		if err != nil {
+			return t, err
+		}
+		for _, r := range ranks {
+			t[r] = tag
+		}
+	}
+	return t, nil
+}
+
+func parseTagRanks(s string) ([]model.Rank, error) {
+	switch s = strings.ToUpper(strings.TrimSpace(s)); s {
+	case "A":
+		return []model.Rank{model.Ace}, nil
+	case "T":
+		return []model.Rank{model.Ten, model.Jack, model.Queen, model.King}, nil
+	case "J":
+		return []model.Rank{model.Jack}, nil
+	case "Q":
+		return []model.Rank{model.Queen}, nil
+	case "K":
+		return []model.Rank{model.King}, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 2 && n <= 10 {
+		return []model.Rank{model.Rank(n)}, nil
+	}
+	return nil, fmt.Errorf("invalid rank %q in count tags", s)
+}
+
+// RunningCount returns the running count of the cards dealt since a shoe held
+// start, given the cards it holds now.
+func (c *CountSystem) RunningCount(start, remaining [model.King + 1]int) int {
+	count := 0
+	for r := model.Ace; r <= model.King; r++ {
+		count += c.Tags[r] * (start[r] - remaining[r])
+	}
+	return count
+}
+
+// TrueCount converts a running count into a count per deck of cardsLeft cards.
+func TrueCount(running, cardsLeft int) float64 {
+	if cardsLeft == 0 {
+		return 0
+	}
+	return float64(running) * 52 / float64(cardsLeft)
+}
+
+// ShouldBet reports whether the bet is placed at the given true count.
+func (c *CountSystem) ShouldBet(trueCount float64) bool {
+	return trueCount >= c.Trigger
+}
@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/model"
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+func TestParseCountTags(t *testing.T) {
+	tags, err := ParseCountTags("4:-1, 5:-1,6:-1,7:-1,8:2,9:2")
+	if err != nil {
+		t.Fatalf("ParseCountTags: %v", err)
+	}
+	if tags != Dragon7Count.Tags {
+		t.Errorf("tags = %v, want the Dragon 7 count %v", tags, Dragon7Count.Tags)
+	}
+
+	tags, err = ParseCountTags("A:1,T:-1,K:2")
+	if err != nil {
+		t.Fatalf("ParseCountTags: %v", err)
+	}
+	if tags[model.Ace] != 1 || tags[model.Ten] != -1 || tags[model.Queen] != -1 || tags[model.King] != 2 {
+		t.Errorf("tags = %v", tags)
+	}
+
+	for _, bad := range []string{"4", "4:x", "1:1", "Z:1", "11:1"} {
+		if _, err := ParseCountTags(bad); err == nil {
+			t.Errorf("ParseCountTags(%q) should fail", bad)
+		}
+	}
+}
+
+func TestCountSystem(t *testing.T) {
+	cs, err := Count("Dragon7")
+	if err != nil || cs.Bet != rules.Dragon {
+		t.Fatalf("Count(Dragon7) = %+v, %v", cs, err)
+	}
+	if _, err := Count("hi-lo"); err == nil {
+		t.Errorf("unknown count system should fail")
+	}
+
+	var start, now [model.King + 1]int
+	start[model.Four], start[model.Eight], start[model.Two] = 10, 10, 10
+	now[model.Four], now[model.Eight], now[model.Two] = 7, 9, 5
+	// Three 4s (-1 each), one 8 (+2) and five untagged 2s were dealt.
+	if got := cs.RunningCount(start, now); got != -1 {
+		t.Errorf("RunningCount = %d, want -1", got)
+	}
+
+	if got := TrueCount(8, 104); got != 4 {
+		t.Errorf("TrueCount(8, 104) = %g, want 4", got)
+	}
+	if TrueCount(8, 0) != 0 {
+		t.Errorf("TrueCount of an empty shoe should be 0")
+	}
+	if !cs.ShouldBet(4) || cs.ShouldBet(3.9) {
+		t.Errorf("Dragon 7 count should bet from a true count of 4")
+	}
+}
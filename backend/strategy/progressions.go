@@ -0,0 +1,158 @@
+package strategy
+
+import "github.com/niubaoshu/es-Baccarat/backend/rules"
+
+// Flat bets Unit on Bet every hand.
+type Flat struct {
+	Bet  rules.BetType
+	Unit int
+}
+
+func (f *Flat) Name() string { return "flat" }
+func (f *Flat) Reset()       {}
+
+func (f *Flat) NextBets(s State) map[rules.BetType]int {
+	return map[rules.BetType]int{f.Bet: f.Unit}
+}
+
+// Martingale doubles the stake after every loss and returns to Unit after a win.
+type Martingale struct {
+	Bet  rules.BetType
+	Unit int
+
+	stake int
+}
+
+func (m *Martingale) Name() string { return "martingale" }
+func (m *Martingale) Reset()       { m.stake = 0 }
+
+func (m *Martingale) NextBets(s State) map[rules.BetType]int {
+	switch {
+	case m.stake == 0 || s.lastNet() > 0:
+		m.stake = m.Unit
+	case s.lastNet() < 0:
+		m.stake *= 2
+	}
+	return map[rules.BetType]int{m.Bet: m.stake}
+}
+
+// paroliWins is the number of consecutive wins Paroli lets ride before starting over.
+const paroliWins = 3
+
+// Paroli doubles the stake after every win, up to three wins in a row, and
+// returns to Unit after a loss or a completed run.
+type Paroli struct {
+	Bet  rules.BetType
+	Unit int
+
+	wins int
+}
+
+func (p *Paroli) Name() string { return "paroli" }
+func (p *Paroli) Reset()       { p.wins = 0 }
+
+func (p *Paroli) NextBets(s State) map[rules.BetType]int {
+	switch s.lastNet() {
+	case 1:
+		p.wins++
+		if p.wins == paroliWins {
+			p.wins = 0
+		}
+	case -1:
+		p.wins = 0
+	}
+	return map[rules.BetType]int{p.Bet: p.Unit << p.wins}
+}
+
+// Fibonacci stakes Unit times the Fibonacci sequence (1, 1, 2, 3, 5, ...),
+// moving one step forward after a loss and two steps back after a win.
+type Fibonacci struct {
+	Bet  rules.BetType
+	Unit int
+
+	step int
+}
+
+func (f *Fibonacci) Name() string { return "fibonacci" }
+func (f *Fibonacci) Reset()       { f.step = 0 }
+
+func (f *Fibonacci) NextBets(s State) map[rules.BetType]int {
+	switch s.lastNet() {
+	case 1:
+		f.step = max(f.step-2, 0)
+	case -1:
+		f.step++
+	}
+	a, b := 1, 1
+	for i := 0; i < f.step; i++ {
+		a, b = b, a+b
+	}
+	return map[rules.BetType]int{f.Bet: a * f.Unit}
+}
+
+// DAlembert raises the stake by Unit after a loss and lowers it by Unit after a
+// win, never below Unit.
+type DAlembert struct {
+	Bet  rules.BetType
+	Unit int
+
+	units int
+}
+
+func (d *DAlembert) Name() string { return "dalembert" }
+func (d *DAlembert) Reset()       { d.units = 0 }
+
+func (d *DAlembert) NextBets(s State) map[rules.BetType]int {
+	switch s.lastNet() {
+	case 1:
+		d.units--
+	case -1:
+		d.units++
+	}
+	d.units = max(d.units, 1)
+	return map[rules.BetType]int{d.Bet: d.units * d.Unit}
+}
+
+// labouchereLine is the starting line of the Labouchère system, in units.
+var labouchereLine = []int{1, 2, 3, 4}
+
+// Labouchere stakes the sum of the first and last numbers of a line (starting at
+// 1-2-3-4 units). A win crosses both numbers off, a loss appends the lost stake;
+// a fresh line is started when every number is crossed off.
+type Labouchere struct {
+	Bet  rules.BetType
+	Unit int
+
+	line []int
+}
+
+func (l *Labouchere) Name() string { return "labouchere" }
+func (l *Labouchere) Reset()       { l.line = append(l.line[:0], labouchereLine...) }
+
+func (l *Labouchere) NextBets(s State) map[rules.BetType]int {
+	if l.line == nil {
+		l.Reset()
+	}
+	switch s.lastNet() {
+	case 1:
+		if len(l.line) <= 2 {
+			l.line = l.line[:0]
+		} else {
+			l.line = l.line[1 : len(l.line)-1]
+		}
+	case -1:
+		l.line = append(l.line, l.stake())
+	}
+	if len(l.line) == 0 {
+		l.Reset()
+	}
+	return map[rules.BetType]int{l.Bet: l.stake() * l.Unit}
+}
+
+// stake returns the next stake in units.
+func (l *Labouchere) stake() int {
+	if len(l.line) == 1 {
+		return l.line[0]
+	}
+	return l.line[0] + l.line[len(l.line)-1]
+}
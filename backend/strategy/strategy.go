@@ -0,0 +1,87 @@
+// Package strategy defines betting strategies for simulated sessions: given the
+// bankroll and the hands played so far, a strategy decides the next bets.
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// Hand is the result of one hand of a session.
+type Hand struct {
+	Bets    map[rules.BetType]int // the bets actually placed, after table limits
+	Outcome rules.Outcome
+	Net     int
+}
+
+// State is what a strategy sees before each hand.
+type State struct {
+	Bankroll int
+	History  []Hand // the hands of the current session, oldest first
+}
+
+// lastNet returns the net result of the previous hand as +1 (win), -1 (loss) or
+// 0 (push, or no hand played yet).
+func (s State) lastNet() int {
+	if len(s.History) == 0 {
+		return 0
+	}
+	switch net := s.History[len(s.History)-1].Net; {
+	case net > 0:
+		return 1
+	case net < 0:
+		return -1
+	}
+	return 0
+}
+
+// Strategy decides the bets of a session hand by hand. Implementations may keep
+// state between hands and are not safe for concurrent use.
+type Strategy interface {
+	Name() string
+	// Reset prepares the strategy for a new session.
+	Reset()
+	// NextBets returns the bets for the next hand, or nil to end the session.
+	// The simulator caps them to the table limits and the bankroll.
+	NextBets(s State) map[rules.BetType]int
+}
+
+// Factory creates an independent Strategy, one per concurrent simulation worker.
+type Factory func() Strategy
+
+var builtins = []struct {
+	name string
+	new  func(bet rules.BetType, unit int) Strategy
+}{
+	{"flat", func(bet rules.BetType, unit int) Strategy { return &Flat{Bet: bet, Unit: unit} }},
+	{"martingale", func(bet rules.BetType, unit int) Strategy { return &Martingale{Bet: bet, Unit: unit} }},
+	{"paroli", func(bet rules.BetType, unit int) Strategy { return &Paroli{Bet: bet, Unit: unit} }},
+	{"fibonacci", func(bet rules.BetType, unit int) Strategy { return &Fibonacci{Bet: bet, Unit: unit} }},
+	{"dalembert", func(bet rules.BetType, unit int) Strategy { return &DAlembert{Bet: bet, Unit: unit} }},
+	{"labouchere", func(bet rules.BetType, unit int) Strategy { return &Labouchere{Bet: bet, Unit: unit} }},
+}
+
+// Names lists the built-in strategies accepted by Builtin.
+func Names() []string {
+	names := make([]string, len(builtins))
+	for i, b := range builtins {
+		names[i] = b.name
+	}
+	return names
+}
+
+// Builtin returns a factory for the named built-in strategy (case-insensitive),
+// betting on bet with a base stake of unit.
+func Builtin(name string, bet rules.BetType, unit int) (Factory, error) {
+	if unit <= 0 {
+		return nil, fmt.Errorf("strategy base bet must be positive, got %d", unit)
+	}
+	for _, b := range builtins {
+		if strings.EqualFold(b.name, strings.TrimSpace(name)) {
+			return func() Strategy { return b.new(bet, unit) }, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown strategy %q (available: %s)", name, strings.Join(Names(), ", "))
+}
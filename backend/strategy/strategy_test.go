@@ -0,0 +1,78 @@
+package strategy
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/niubaoshu/es-Baccarat/backend/rules"
+)
+
+// stakes plays s through hands with the given net signs (+1 win, -1 loss,
+// 0 push) and returns the stake of each hand, including the one after the last.
+func stakes(s Strategy, results ...int) []int {
+	s.Reset()
+	var state State
+	var got []int
+	for i := 0; ; i++ {
+		bets := s.NextBets(state)
+		stake := bets[rules.Banker]
+		got = append(got, stake)
+		if i == len(results) {
+			return got
+		}
+		state.History = append(state.History, Hand{Bets: bets, Net: results[i] * stake})
+	}
+}
+
+func TestProgressions(t *testing.T) {
+	const (
+		W = 1
+		L = -1
+		T = 0
+	)
+	tests := []struct {
+		name    string
+		results []int
+		want    []int
+	}{
+		{"flat", []int{L, W, L}, []int{10, 10, 10, 10}},
+		{"martingale", []int{L, L, T, L, W, L}, []int{10, 20, 40, 40, 80, 10, 20}},
+		{"paroli", []int{W, W, W, W, L, W}, []int{10, 20, 40, 10, 20, 10, 20}},
+		{"fibonacci", []int{L, L, L, L, W, W, W}, []int{10, 10, 20, 30, 50, 20, 10, 10}},
+		{"dalembert", []int{L, L, W, W, W, L}, []int{10, 20, 30, 20, 10, 10, 20}},
+		// Line 1-2-3-4: bet 5 (lose, 1-2-3-4-5), 6 (win, 2-3-4), 6 (win, 3), 3 (win, restart), 5.
+		{"labouchere", []int{L, W, W, W}, []int{50, 60, 60, 30, 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory, err := Builtin(tt.name, rules.Banker, 10)
+			if err != nil {
+				t.Fatalf("Builtin: %v", err)
+			}
+			s := factory()
+			if got := stakes(s, tt.results...); !slices.Equal(got, tt.want) {
+				t.Errorf("stakes = %v, want %v", got, tt.want)
+			}
+			// A new session starts from scratch.
+			if got := stakes(s, tt.results...); !slices.Equal(got, tt.want) {
+				t.Errorf("after Reset, stakes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltin(t *testing.T) {
+	if len(Names()) != 6 {
+		t.Errorf("expected 6 built-in strategies, got %v", Names())
+	}
+	if _, err := Builtin("Martingale", rules.Player, 10); err != nil {
+		t.Errorf("lookup should be case-insensitive: %v", err)
+	}
+	if _, err := Builtin("martingale", rules.Player, 0); err == nil {
+		t.Errorf("expected error for a zero base bet")
+	}
+	if _, err := Builtin("oscar", rules.Player, 10); err == nil {
+		t.Errorf("expected error for an unknown strategy")
+	}
+}